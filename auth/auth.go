@@ -0,0 +1,288 @@
+// Package auth authenticates callers of the REST and gRPC APIs. Game servers
+// and admin tools present a service API key; players present a JWT issued for
+// their own user ID. Either way the caller becomes a Principal holding scopes,
+// which the transports check per route or method.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// APIKeyHeader carries a service API key on REST requests. gRPC calls use the
+// same name, lowercased, as metadata.
+const APIKeyHeader = "X-API-Key"
+
+// Scope is a permission granted to a key or token
+type Scope string
+
+const (
+	ScopeWalletRead     Scope = "wallet:read"     // balances, transactions, limits, event streams
+	ScopeWalletPurchase Scope = "wallet:purchase" // buy packages
+	ScopeWalletWager    Scope = "wallet:wager"    // wagers, batches, tournament entries, jackpot wins
+	ScopeWalletRedeem   Scope = "wallet:redeem"   // redeem SC
+	ScopeWalletAccount  Scope = "wallet:account"  // a player's own settings, limits and daily bonus
+	ScopeAdminAdjust    Scope = "admin:adjust"    // adjustments, postings, bonus grants
+	ScopeAdminApprove   Scope = "admin:approve"   // decide maker-checker approval requests
+	ScopeAdminConfig    Scope = "admin:config"    // operator configuration and reviews
+)
+
+// AllScopes lists every scope, in the order they are documented
+var AllScopes = []Scope{
+	ScopeWalletRead, ScopeWalletPurchase, ScopeWalletWager, ScopeWalletRedeem, ScopeWalletAccount,
+	ScopeAdminAdjust, ScopeAdminApprove, ScopeAdminConfig,
+}
+
+// playerScopes are the scopes a player token can carry. Wagers are reported by
+// game servers, never by the player, and admin scopes need a service key.
+var playerScopes = map[Scope]bool{
+	ScopeWalletRead:     true,
+	ScopeWalletPurchase: true,
+	ScopeWalletRedeem:   true,
+	ScopeWalletAccount:  true,
+}
+
+// IsValid checks if the scope is known
+func (s Scope) IsValid() bool {
+	for _, known := range AllScopes {
+		if s == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Errors returned while authenticating
+var (
+	ErrMissingCredentials = errors.New("missing credentials")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Principal is an authenticated caller
+type Principal struct {
+	// Name is the API key name, or "user:<id>" for a player
+	Name string
+	// UserID is the player a token was issued to; zero for service keys
+	UserID int
+	Scopes []Scope
+}
+
+// IsPlayer reports whether the principal is a player rather than a service
+func (p *Principal) IsPlayer() bool {
+	return p.UserID != 0
+}
+
+// HasScope reports whether the principal was granted scope
+func (p *Principal) HasScope(scope Scope) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// CanAccessUser reports whether the principal may act on a user's wallet.
+// Services may act on any user; a player only on their own.
+func (p *Principal) CanAccessUser(userID int) bool {
+	return !p.IsPlayer() || p.UserID == userID
+}
+
+// APIKey configures a service API key
+type APIKey struct {
+	Name   string  `json:"name"`
+	Key    string  `json:"key"`
+	Scopes []Scope `json:"scopes"`
+}
+
+// ParseAPIKeys parses API keys from their JSON configuration, e.g.
+// [{"name": "game-server", "key": "...", "scopes": ["wallet:read", "wallet:wager"]}]
+func ParseAPIKeys(config string) ([]APIKey, error) {
+	if strings.TrimSpace(config) == "" {
+		return nil, nil
+	}
+	var keys []APIKey
+	if err := json.Unmarshal([]byte(config), &keys); err != nil {
+		return nil, fmt.Errorf("invalid API key configuration: %w", err)
+	}
+	return keys, nil
+}
+
+// Config configures an Authenticator
+type Config struct {
+	APIKeys []APIKey
+	// JWTSecret is the HS256 key player tokens are signed with; empty disables player tokens
+	JWTSecret string
+	// JWTIssuer, if set, must match the iss claim of player tokens
+	JWTIssuer string
+	// Disabled lets every request through with all scopes, for local development only
+	Disabled bool
+}
+
+// MinKeyLength is the shortest accepted API key or JWT secret
+const MinKeyLength = 32
+
+// Authenticator checks API keys and player tokens
+type Authenticator struct {
+	keys      []apiKey
+	jwtSecret []byte
+	jwtIssuer string
+	disabled  bool
+}
+
+// apiKey is a configured key; only its hash is kept
+type apiKey struct {
+	name   string
+	hash   [sha256.Size]byte
+	scopes []Scope
+}
+
+// playerClaims are the claims of a player token. Scope is space-separated, as in OAuth 2.0.
+type playerClaims struct {
+	Scope string `json:"scope"`
+	jwt.RegisteredClaims
+}
+
+// New creates an Authenticator, checking the configuration
+func New(cfg Config) (*Authenticator, error) {
+	a := &Authenticator{jwtIssuer: cfg.JWTIssuer, disabled: cfg.Disabled}
+	if cfg.Disabled {
+		return a, nil
+	}
+
+	if len(cfg.APIKeys) == 0 && cfg.JWTSecret == "" {
+		return nil, errors.New("no API keys or JWT secret configured")
+	}
+	if cfg.JWTSecret != "" {
+		if len(cfg.JWTSecret) < MinKeyLength {
+			return nil, fmt.Errorf("JWT secret must be at least %d characters", MinKeyLength)
+		}
+		a.jwtSecret = []byte(cfg.JWTSecret)
+	}
+
+	names := map[string]bool{}
+	for i, k := range cfg.APIKeys {
+		if k.Name == "" {
+			return nil, fmt.Errorf("API key %d: name is required", i)
+		}
+		if names[k.Name] {
+			return nil, fmt.Errorf("API key %q: duplicate name", k.Name)
+		}
+		names[k.Name] = true
+		if len(k.Key) < MinKeyLength {
+			return nil, fmt.Errorf("API key %q: key must be at least %d characters", k.Name, MinKeyLength)
+		}
+		if len(k.Scopes) == 0 {
+			return nil, fmt.Errorf("API key %q: at least one scope is required", k.Name)
+		}
+		for _, s := range k.Scopes {
+			if !s.IsValid() {
+				return nil, fmt.Errorf("API key %q: unknown scope %q", k.Name, s)
+			}
+		}
+		a.keys = append(a.keys, apiKey{name: k.Name, hash: sha256.Sum256([]byte(k.Key)), scopes: k.Scopes})
+	}
+	return a, nil
+}
+
+// Disabled reports whether authentication is turned off
+func (a *Authenticator) Disabled() bool {
+	return a.disabled
+}
+
+// Authenticate resolves the caller from an API key or a bearer token,
+// whichever is present. An API key takes precedence.
+func (a *Authenticator) Authenticate(key, bearerToken string) (*Principal, error) {
+	if a.disabled {
+		return &Principal{Name: "anonymous", Scopes: AllScopes}, nil
+	}
+	switch {
+	case key != "":
+		return a.authenticateKey(key)
+	case bearerToken != "":
+		return a.authenticateToken(bearerToken)
+	}
+	return nil, ErrMissingCredentials
+}
+
+// authenticateKey looks up a service API key by its hash in constant time
+func (a *Authenticator) authenticateKey(key string) (*Principal, error) {
+	hash := sha256.Sum256([]byte(key))
+	var match *apiKey
+	for i := range a.keys {
+		if subtle.ConstantTimeCompare(hash[:], a.keys[i].hash[:]) == 1 {
+			match = &a.keys[i]
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("%w: unknown API key", ErrInvalidCredentials)
+	}
+	return &Principal{Name: match.name, Scopes: match.scopes}, nil
+}
+
+// authenticateToken verifies a player token. The subject is the user ID and
+// scopes a player cannot hold are dropped.
+func (a *Authenticator) authenticateToken(token string) (*Principal, error) {
+	if a.jwtSecret == nil {
+		return nil, fmt.Errorf("%w: player tokens are not accepted", ErrInvalidCredentials)
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	}
+	if a.jwtIssuer != "" {
+		opts = append(opts, jwt.WithIssuer(a.jwtIssuer))
+	}
+
+	var claims playerClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return a.jwtSecret, nil
+	}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+
+	userID, err := strconv.Atoi(claims.Subject)
+	if err != nil || userID <= 0 {
+		return nil, fmt.Errorf("%w: subject must be a user id", ErrInvalidCredentials)
+	}
+
+	p := &Principal{Name: "user:" + claims.Subject, UserID: userID, Scopes: []Scope{}}
+	for _, s := range strings.Fields(claims.Scope) {
+		if playerScopes[Scope(s)] {
+			p.Scopes = append(p.Scopes, Scope(s))
+		}
+	}
+	return p, nil
+}
+
+// BearerToken extracts the token from an "Authorization: Bearer <token>" value
+func BearerToken(authorization string) string {
+	scheme, token, ok := strings.Cut(authorization, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+type contextKey struct{}
+
+// NewContext returns a context carrying the principal
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// FromContext returns the principal of an authenticated request, if any
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(contextKey{}).(*Principal)
+	return p, ok
+}
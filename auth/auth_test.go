@@ -0,0 +1,188 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testSecret = "test-jwt-secret-0123456789abcdef0123"
+	testKey    = "test-game-server-key-0123456789abcdef"
+)
+
+func newTestAuthenticator(t *testing.T, issuer string) *Authenticator {
+	t.Helper()
+	a, err := New(Config{
+		APIKeys:   []APIKey{{Name: "game-server", Key: testKey, Scopes: []Scope{ScopeWalletRead, ScopeWalletWager}}},
+		JWTSecret: testSecret,
+		JWTIssuer: issuer,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return a
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, secret string, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return token
+}
+
+// Test service API keys resolve to their configured scopes
+func TestAuthenticate_APIKey(t *testing.T) {
+	a := newTestAuthenticator(t, "")
+
+	p, err := a.Authenticate(testKey, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Name != "game-server" || p.IsPlayer() {
+		t.Errorf("unexpected principal %+v", p)
+	}
+	if !p.HasScope(ScopeWalletWager) || p.HasScope(ScopeAdminAdjust) {
+		t.Errorf("unexpected scopes %v", p.Scopes)
+	}
+	if !p.CanAccessUser(42) {
+		t.Error("service key should access any user")
+	}
+
+	if _, err := a.Authenticate("wrong-key", ""); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("expected ErrInvalidCredentials, got %v", err)
+	}
+	if _, err := a.Authenticate("", ""); !errors.Is(err, ErrMissingCredentials) {
+		t.Errorf("expected ErrMissingCredentials, got %v", err)
+	}
+}
+
+// Test player tokens are bound to their user and limited to player scopes
+func TestAuthenticate_PlayerToken(t *testing.T) {
+	a := newTestAuthenticator(t, "wallet-auth")
+	exp := time.Now().Add(time.Hour).Unix()
+
+	token := signToken(t, jwt.SigningMethodHS256, testSecret, jwt.MapClaims{
+		"sub": "7", "iss": "wallet-auth", "exp": exp, "scope": "wallet:read wallet:wager admin:adjust wallet:redeem",
+	})
+	p, err := a.Authenticate("", token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !p.IsPlayer() || p.UserID != 7 || p.Name != "user:7" {
+		t.Errorf("unexpected principal %+v", p)
+	}
+	if !p.HasScope(ScopeWalletRead) || !p.HasScope(ScopeWalletRedeem) {
+		t.Errorf("expected player scopes, got %v", p.Scopes)
+	}
+	if p.HasScope(ScopeWalletWager) || p.HasScope(ScopeAdminAdjust) {
+		t.Errorf("player token must not carry service scopes, got %v", p.Scopes)
+	}
+	if !p.CanAccessUser(7) || p.CanAccessUser(8) {
+		t.Error("player should access only their own user")
+	}
+}
+
+// Test invalid player tokens are rejected
+func TestAuthenticate_InvalidTokens(t *testing.T) {
+	a := newTestAuthenticator(t, "wallet-auth")
+	exp := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"wrong secret", signToken(t, jwt.SigningMethodHS256, "another-secret-0123456789abcdef01234", jwt.MapClaims{"sub": "7", "iss": "wallet-auth", "exp": exp})},
+		{"wrong algorithm", signToken(t, jwt.SigningMethodHS512, testSecret, jwt.MapClaims{"sub": "7", "iss": "wallet-auth", "exp": exp})},
+		{"expired", signToken(t, jwt.SigningMethodHS256, testSecret, jwt.MapClaims{"sub": "7", "iss": "wallet-auth", "exp": time.Now().Add(-time.Minute).Unix()})},
+		{"no expiry", signToken(t, jwt.SigningMethodHS256, testSecret, jwt.MapClaims{"sub": "7", "iss": "wallet-auth"})},
+		{"wrong issuer", signToken(t, jwt.SigningMethodHS256, testSecret, jwt.MapClaims{"sub": "7", "iss": "elsewhere", "exp": exp})},
+		{"non-numeric subject", signToken(t, jwt.SigningMethodHS256, testSecret, jwt.MapClaims{"sub": "alice", "iss": "wallet-auth", "exp": exp})},
+		{"malformed", "not-a-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := a.Authenticate("", tt.token); !errors.Is(err, ErrInvalidCredentials) {
+				t.Errorf("expected ErrInvalidCredentials, got %v", err)
+			}
+		})
+	}
+}
+
+// Test configuration is checked when the authenticator is created
+func TestNew_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"nothing configured", Config{}},
+		{"short secret", Config{JWTSecret: "short"}},
+		{"short key", Config{APIKeys: []APIKey{{Name: "a", Key: "short", Scopes: []Scope{ScopeWalletRead}}}}},
+		{"missing name", Config{APIKeys: []APIKey{{Key: testKey, Scopes: []Scope{ScopeWalletRead}}}}},
+		{"no scopes", Config{APIKeys: []APIKey{{Name: "a", Key: testKey}}}},
+		{"unknown scope", Config{APIKeys: []APIKey{{Name: "a", Key: testKey, Scopes: []Scope{"wallet:everything"}}}}},
+		{"duplicate name", Config{APIKeys: []APIKey{
+			{Name: "a", Key: testKey, Scopes: []Scope{ScopeWalletRead}},
+			{Name: "a", Key: testKey + "2", Scopes: []Scope{ScopeWalletRead}},
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.cfg); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+// Test a disabled authenticator lets requests through with every scope
+func TestAuthenticate_Disabled(t *testing.T) {
+	a, err := New(Config{Disabled: true})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	p, err := a.Authenticate("", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !p.HasScope(ScopeAdminConfig) || p.IsPlayer() {
+		t.Errorf("unexpected principal %+v", p)
+	}
+}
+
+// Test API keys parse from their JSON configuration
+func TestParseAPIKeys(t *testing.T) {
+	keys, err := ParseAPIKeys(`[{"name": "admin-tool", "key": "k", "scopes": ["admin:adjust", "admin:approve"]}]`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(keys) != 1 || keys[0].Name != "admin-tool" || len(keys[0].Scopes) != 2 {
+		t.Errorf("unexpected keys %+v", keys)
+	}
+
+	if keys, err := ParseAPIKeys(""); err != nil || keys != nil {
+		t.Errorf("expected no keys, got %v, %v", keys, err)
+	}
+	if _, err := ParseAPIKeys("{"); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+// Test the bearer token is taken from an Authorization header value
+func TestBearerToken(t *testing.T) {
+	if got := BearerToken("Bearer abc.def.ghi"); got != "abc.def.ghi" {
+		t.Errorf("got %q", got)
+	}
+	if got := BearerToken("bearer abc"); got != "abc" {
+		t.Errorf("got %q", got)
+	}
+	if got := BearerToken("Basic dXNlcjpwYXNz"); got != "" {
+		t.Errorf("expected no token, got %q", got)
+	}
+}
@@ -0,0 +1,262 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+	"wallet-ledger/models"
+
+	"github.com/lib/pq"
+)
+
+// Channel is the Postgres NOTIFY channel the transactions and SC expiry
+// warnings triggers publish to
+const Channel = "wallet_events"
+
+// subscriberBuffer is how many undelivered transactions a subscriber may queue
+// before it is considered too slow and disconnected
+const subscriberBuffer = 64
+
+// warningBuffer is how many undelivered warnings a subscriber may queue; further
+// warnings are dropped, as they can still be read back from the database
+const warningBuffer = 8
+
+// notification is the payload sent by the transactions_notify and
+// sc_expiry_warnings_notify triggers
+type notification struct {
+	ID              int `json:"id"`
+	SCExpiryWarning int `json:"sc_expiry_warning_id"`
+	UserID          int `json:"user_id"`
+}
+
+// Loader loads committed transactions and SC expiry warnings by ID
+type Loader interface {
+	GetTransaction(transactionID int) (*models.Transaction, error)
+	GetSCExpiryWarning(warningID int) (*models.SCExpiryWarning, error)
+}
+
+// Broker fans committed ledger transactions out to subscribers of each user.
+// Every instance listens on the same Postgres channel, so a transaction written
+// by any instance reaches clients connected to all of them.
+type Broker struct {
+	listener *pq.Listener
+	loader   Loader
+
+	mu     sync.Mutex
+	subs   map[int]map[*Subscription]struct{}
+	closed bool
+}
+
+// Subscription receives the transactions and warnings of a single user as they commit
+type Subscription struct {
+	broker   *Broker
+	userID   int
+	ch       chan *models.Transaction
+	warnings chan *models.SCExpiryWarning
+	once     sync.Once
+}
+
+func NewBroker(databaseURL string, loader Loader) *Broker {
+	listener := pq.NewListener(databaseURL, 10*time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("Event listener error: %v", err)
+		}
+	})
+
+	return &Broker{
+		listener: listener,
+		loader:   loader,
+		subs:     make(map[int]map[*Subscription]struct{}),
+	}
+}
+
+// Run listens for notifications until the context is cancelled. However it
+// stops, open subscriptions are ended so their clients reconnect instead of
+// waiting for events that will never arrive.
+func (b *Broker) Run(ctx context.Context) error {
+	defer b.shutdown()
+
+	if err := b.listener.Listen(Channel); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case n := <-b.listener.Notify:
+			if n == nil {
+				// The connection was re-established and notifications may have been
+				// missed; drop subscribers so clients resume from their last event ID
+				log.Println("Event listener reconnected, resetting subscriptions")
+				b.closeAll()
+				continue
+			}
+			b.dispatch(n.Extra)
+		case <-time.After(90 * time.Second):
+			go b.listener.Ping()
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Close disconnects all subscribers and stops listening
+func (b *Broker) Close() error {
+	b.shutdown()
+	return b.listener.Close()
+}
+
+// Closed reports whether the broker has stopped delivering events
+func (b *Broker) Closed() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.closed
+}
+
+// shutdown ends every subscription and refuses new ones
+func (b *Broker) shutdown() {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+
+	b.closeAll()
+}
+
+// Subscribe registers interest in a user's transactions
+func (b *Broker) Subscribe(userID int) *Subscription {
+	sub := &Subscription{
+		broker:   b,
+		userID:   userID,
+		ch:       make(chan *models.Transaction, subscriberBuffer),
+		warnings: make(chan *models.SCExpiryWarning, warningBuffer),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		sub.close()
+		return sub
+	}
+
+	if b.subs[userID] == nil {
+		b.subs[userID] = make(map[*Subscription]struct{})
+	}
+	b.subs[userID][sub] = struct{}{}
+
+	return sub
+}
+
+// Transactions returns the channel of committed transactions. The channel is
+// closed when the subscription ends, including on shutdown and when the
+// subscriber falls too far behind.
+func (s *Subscription) Transactions() <-chan *models.Transaction {
+	return s.ch
+}
+
+// Warnings returns the channel of SC expiry warnings. It is closed together
+// with the transactions channel.
+func (s *Subscription) Warnings() <-chan *models.SCExpiryWarning {
+	return s.warnings
+}
+
+// Close ends the subscription
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.remove(s)
+}
+
+// close closes the subscription's channels once
+func (s *Subscription) close() {
+	s.once.Do(func() {
+		close(s.ch)
+		close(s.warnings)
+	})
+}
+
+// dispatch loads the notified transaction or warning and delivers it to the
+// user's subscribers
+func (b *Broker) dispatch(payload string) {
+	var n notification
+	if err := json.Unmarshal([]byte(payload), &n); err != nil {
+		log.Printf("Invalid event payload %q: %v", payload, err)
+		return
+	}
+
+	// Skip the lookup entirely when nobody on this instance is listening
+	b.mu.Lock()
+	interested := len(b.subs[n.UserID]) > 0
+	b.mu.Unlock()
+	if !interested {
+		return
+	}
+
+	if n.SCExpiryWarning != 0 {
+		b.dispatchWarning(n.UserID, n.SCExpiryWarning)
+		return
+	}
+
+	t, err := b.loader.GetTransaction(n.ID)
+	if err != nil {
+		log.Printf("Error loading transaction %d for event: %v", n.ID, err)
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subs[n.UserID] {
+		select {
+		case sub.ch <- t:
+		default:
+			log.Printf("Event subscriber for user %d is too slow, disconnecting", n.UserID)
+			b.remove(sub)
+		}
+	}
+}
+
+// dispatchWarning loads an SC expiry warning and delivers it to the user's
+// subscribers, skipping those whose warning queue is full
+func (b *Broker) dispatchWarning(userID, warningID int) {
+	w, err := b.loader.GetSCExpiryWarning(warningID)
+	if err != nil {
+		log.Printf("Error loading SC expiry warning %d for event: %v", warningID, err)
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subs[userID] {
+		select {
+		case sub.warnings <- w:
+		default:
+			log.Printf("Dropping SC expiry warning %d for slow subscriber of user %d", warningID, userID)
+		}
+	}
+}
+
+// closeAll ends every active subscription
+func (b *Broker) closeAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, subs := range b.subs {
+		for sub := range subs {
+			b.remove(sub)
+		}
+	}
+}
+
+// remove unregisters a subscription and closes its channel; b.mu must be held
+func (b *Broker) remove(s *Subscription) {
+	if subs, ok := b.subs[s.userID]; ok {
+		delete(subs, s)
+		if len(subs) == 0 {
+			delete(b.subs, s.userID)
+		}
+	}
+	s.close()
+}
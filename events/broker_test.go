@@ -0,0 +1,129 @@
+package events
+
+import (
+	"testing"
+	"wallet-ledger/models"
+)
+
+// fakeLoader returns a transaction or warning for any ID without touching the database
+type fakeLoader struct{}
+
+func (fakeLoader) GetTransaction(transactionID int) (*models.Transaction, error) {
+	return &models.Transaction{ID: transactionID, UserID: 1}, nil
+}
+
+func (fakeLoader) GetSCExpiryWarning(warningID int) (*models.SCExpiryWarning, error) {
+	return &models.SCExpiryWarning{ID: warningID, UserID: 1}, nil
+}
+
+func newTestBroker() *Broker {
+	return &Broker{
+		loader: fakeLoader{},
+		subs:   make(map[int]map[*Subscription]struct{}),
+	}
+}
+
+// Test dispatch only reaches subscribers of the notified user
+func TestDispatch_RoutesByUser(t *testing.T) {
+	b := newTestBroker()
+	alice := b.Subscribe(1)
+	bob := b.Subscribe(2)
+
+	b.dispatch(`{"id": 7, "user_id": 1}`)
+
+	select {
+	case tx := <-alice.Transactions():
+		if tx.ID != 7 {
+			t.Errorf("expected transaction 7, got %d", tx.ID)
+		}
+	default:
+		t.Fatal("expected subscriber of user 1 to receive the transaction")
+	}
+
+	select {
+	case tx := <-bob.Transactions():
+		t.Errorf("expected no transaction for user 2, got %v", tx)
+	default:
+	}
+}
+
+// Test slow subscribers are disconnected instead of blocking the broker
+func TestDispatch_DisconnectsSlowSubscriber(t *testing.T) {
+	b := newTestBroker()
+	sub := b.Subscribe(1)
+
+	for i := 1; i <= subscriberBuffer+1; i++ {
+		b.dispatch(`{"id": 1, "user_id": 1}`)
+	}
+
+	received := 0
+	for range sub.Transactions() {
+		received++
+	}
+
+	if received != subscriberBuffer {
+		t.Errorf("expected %d buffered transactions before disconnect, got %d", subscriberBuffer, received)
+	}
+	if len(b.subs) != 0 {
+		t.Error("expected slow subscriber to be removed")
+	}
+}
+
+// Test subscriptions made after shutdown are closed immediately
+func TestSubscribe_AfterClose(t *testing.T) {
+	b := newTestBroker()
+	b.closed = true
+
+	sub := b.Subscribe(1)
+	if _, ok := <-sub.Transactions(); ok {
+		t.Error("expected closed subscription channel")
+	}
+	sub.Close()
+}
+
+// Test SC expiry warnings go to the warnings channel, not the transactions one
+func TestDispatch_DeliversWarnings(t *testing.T) {
+	b := newTestBroker()
+	sub := b.Subscribe(1)
+
+	b.dispatch(`{"sc_expiry_warning_id": 3, "user_id": 1}`)
+
+	select {
+	case w := <-sub.Warnings():
+		if w.ID != 3 {
+			t.Errorf("expected warning 3, got %d", w.ID)
+		}
+	default:
+		t.Fatal("expected subscriber to receive the warning")
+	}
+
+	select {
+	case tx := <-sub.Transactions():
+		t.Errorf("expected no transaction, got %v", tx)
+	default:
+	}
+
+	// A full warning queue drops warnings but keeps the subscriber
+	for i := 0; i <= warningBuffer; i++ {
+		b.dispatch(`{"sc_expiry_warning_id": 4, "user_id": 1}`)
+	}
+	if len(b.subs[1]) != 1 {
+		t.Error("expected subscriber with a full warning queue to stay connected")
+	}
+	sub.Close()
+}
+
+// Test shutting down ends open subscriptions so their clients reconnect
+func TestShutdown_ClosesSubscriptions(t *testing.T) {
+	b := newTestBroker()
+	sub := b.Subscribe(1)
+
+	b.shutdown()
+
+	if _, ok := <-sub.Transactions(); ok {
+		t.Error("expected closed subscription channel")
+	}
+	if !b.Closed() {
+		t.Error("expected broker to report closed")
+	}
+}
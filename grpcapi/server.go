@@ -0,0 +1,427 @@
+// Package grpcapi serves the wallet over gRPC, alongside the REST API in
+// handlers. Both transports call the same service.WalletService.
+package grpcapi
+
+//go:generate protoc -I ../proto --go_out=../proto --go_opt=paths=source_relative --go-grpc_out=../proto --go-grpc_opt=paths=source_relative walletpb/wallet.proto
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"strings"
+	"time"
+	"wallet-ledger/auth"
+	"wallet-ledger/models"
+	"wallet-ledger/proto/walletpb"
+	"wallet-ledger/service"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// IdempotencyKeyHeader is the metadata key carrying the idempotency key
+	IdempotencyKeyHeader = "idempotency-key"
+
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// methodScopes is the scope each method requires. A method missing here is denied.
+var methodScopes = map[string]auth.Scope{
+	walletpb.UserService_GetUser_FullMethodName:                 auth.ScopeWalletRead,
+	walletpb.UserService_GetBalances_FullMethodName:             auth.ScopeWalletRead,
+	walletpb.TransactionService_ListTransactions_FullMethodName: auth.ScopeWalletRead,
+	walletpb.WalletService_Purchase_FullMethodName:              auth.ScopeWalletPurchase,
+	walletpb.WalletService_Wager_FullMethodName:                 auth.ScopeWalletWager,
+	walletpb.WalletService_Redeem_FullMethodName:                auth.ScopeWalletRedeem,
+}
+
+// NewServer creates a gRPC server with all wallet services registered
+func NewServer(svc *service.WalletService, authenticator *auth.Authenticator) *grpc.Server {
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(loggingInterceptor, authInterceptor(authenticator)))
+
+	walletpb.RegisterUserServiceServer(server, &userServer{service: svc})
+	walletpb.RegisterTransactionServiceServer(server, &transactionServer{service: svc})
+	walletpb.RegisterWalletServiceServer(server, &walletServer{service: svc})
+
+	return server
+}
+
+type userServer struct {
+	walletpb.UnimplementedUserServiceServer
+	service *service.WalletService
+}
+
+type transactionServer struct {
+	walletpb.UnimplementedTransactionServiceServer
+	service *service.WalletService
+}
+
+type walletServer struct {
+	walletpb.UnimplementedWalletServiceServer
+	service *service.WalletService
+}
+
+// GetUser returns a user with balances and statistics
+func (s *userServer) GetUser(ctx context.Context, req *walletpb.GetUserRequest) (*walletpb.UserWithBalances, error) {
+	user, err := s.service.GetUserWithBalances(int(req.UserId))
+	if err != nil {
+		return nil, toStatus(err, "failed to get user")
+	}
+
+	gc, sc := models.CurrencyGC.MinorUnits(), models.CurrencySC.MinorUnits()
+	return &walletpb.UserWithBalances{
+		Id:                   int64(user.ID),
+		Username:             user.Username,
+		CreatedAt:            timestamppb.New(user.CreatedAt),
+		GoldBalance:          models.MinorToWhole(user.GoldBalance, gc),
+		SweepsBalance:        models.MinorToWhole(user.SweepsBalance, sc),
+		TotalGcWagered:       models.MinorToWhole(user.TotalGCWagered, gc),
+		TotalGcWon:           models.MinorToWhole(user.TotalGCWon, gc),
+		TotalScWagered:       models.MinorToWhole(user.TotalSCWagered, sc),
+		TotalScWon:           models.MinorToWhole(user.TotalSCWon, sc),
+		TotalScRedeemed:      models.MinorToWhole(user.TotalSCRedeemed, sc),
+		GoldBalanceMinor:     user.GoldBalance,
+		SweepsBalanceMinor:   user.SweepsBalance,
+		TotalGcWageredMinor:  user.TotalGCWagered,
+		TotalGcWonMinor:      user.TotalGCWon,
+		TotalScWageredMinor:  user.TotalSCWagered,
+		TotalScWonMinor:      user.TotalSCWon,
+		TotalScRedeemedMinor: user.TotalSCRedeemed,
+		Balances:             currencyBalances(user),
+	}, nil
+}
+
+// GetBalances returns only the current balances of a user
+func (s *userServer) GetBalances(ctx context.Context, req *walletpb.GetBalancesRequest) (*walletpb.Balances, error) {
+	user, err := s.service.GetUserWithBalances(int(req.UserId))
+	if err != nil {
+		return nil, toStatus(err, "failed to get balances")
+	}
+
+	return &walletpb.Balances{
+		UserId:             int64(user.ID),
+		GoldBalance:        models.MinorToWhole(user.GoldBalance, models.CurrencyGC.MinorUnits()),
+		SweepsBalance:      models.MinorToWhole(user.SweepsBalance, models.CurrencySC.MinorUnits()),
+		GoldBalanceMinor:   user.GoldBalance,
+		SweepsBalanceMinor: user.SweepsBalance,
+		Balances:           currencyBalances(user),
+	}, nil
+}
+
+// currencyBalances lists a user's balance and totals in every currency, by code
+func currencyBalances(user *models.UserWithBalances) []*walletpb.CurrencyBalance {
+	currencies := make([]models.Currency, 0, len(user.Balances))
+	for currency := range user.Balances {
+		currencies = append(currencies, currency)
+	}
+	sort.Slice(currencies, func(i, j int) bool { return currencies[i] < currencies[j] })
+
+	balances := make([]*walletpb.CurrencyBalance, 0, len(currencies))
+	for _, currency := range currencies {
+		stats := user.Stats[currency]
+		balances = append(balances, &walletpb.CurrencyBalance{
+			Currency:      string(currency),
+			BalanceMinor:  user.Balances[currency],
+			WageredMinor:  stats.Wagered,
+			WonMinor:      stats.Won,
+			RedeemedMinor: stats.Redeemed,
+		})
+	}
+	return balances
+}
+
+// ListTransactions returns a page of a user's transactions
+func (s *transactionServer) ListTransactions(ctx context.Context, req *walletpb.ListTransactionsRequest) (*walletpb.ListTransactionsResponse, error) {
+	limit := DefaultPageLimit
+	if req.Limit < 0 || req.Limit > MaxPageLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid limit: must be between 1 and %d", MaxPageLimit)
+	}
+	if req.Limit > 0 {
+		limit = int(req.Limit)
+	}
+
+	var cursor *string
+	if req.Cursor != "" {
+		cursor = &req.Cursor
+	}
+
+	var txType *models.TransactionType
+	if req.Type != "" {
+		t := models.TransactionType(req.Type)
+		if !t.IsValid() {
+			return nil, status.Error(codes.InvalidArgument, "invalid transaction type")
+		}
+		txType = &t
+	}
+
+	var currency *models.Currency
+	if req.Currency != "" {
+		c := models.Currency(req.Currency)
+		if _, err := s.service.GetCurrency(c); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid currency: not registered")
+		}
+		currency = &c
+	}
+
+	list, err := s.service.ListTransactions(int(req.UserId), cursor, limit, txType, currency)
+	if err != nil {
+		return nil, toStatus(err, "failed to list transactions")
+	}
+
+	resp := &walletpb.ListTransactionsResponse{
+		Items: make([]*walletpb.Transaction, 0, len(list.Items)),
+	}
+	for i := range list.Items {
+		resp.Items = append(resp.Items, toProtoTransaction(&list.Items[i]))
+	}
+	if list.NextCursor != nil {
+		resp.NextCursor = *list.NextCursor
+	}
+
+	return resp, nil
+}
+
+// Purchase buys a package
+func (s *walletServer) Purchase(ctx context.Context, req *walletpb.PurchaseRequest) (*walletpb.TransactionsResponse, error) {
+	key, err := idempotencyKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.PackageCode == "" {
+		return nil, status.Error(codes.InvalidArgument, "package_code is required")
+	}
+
+	transactions, err := s.service.Purchase(int(req.UserId), req.PackageCode, req.PromoCode, key)
+	if err != nil {
+		return nil, toStatus(err, "failed to process purchase")
+	}
+
+	return toProtoTransactions(transactions), nil
+}
+
+// Wager settles a game round
+func (s *walletServer) Wager(ctx context.Context, req *walletpb.WagerRequest) (*walletpb.TransactionsResponse, error) {
+	key, err := idempotencyKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GameId == "" {
+		return nil, status.Error(codes.InvalidArgument, "game_id is required")
+	}
+
+	legs, err := wagerLegs(req)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.service.Wager(int(req.UserId), req.GameId, req.RoundId, legs, key)
+	if err != nil {
+		return nil, toStatus(err, "failed to process wager")
+	}
+
+	return toProtoTransactions(transactions), nil
+}
+
+// Redeem redeems a redeemable currency, Sweeps Coins unless another is given
+func (s *walletServer) Redeem(ctx context.Context, req *walletpb.RedeemRequest) (*walletpb.Transaction, error) {
+	key, err := idempotencyKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	currency, amount, err := redeemAmount(req)
+	if err != nil {
+		return nil, err
+	}
+	if amount <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+
+	transaction, err := s.service.Redeem(int(req.UserId), currency, amount, key)
+	if err != nil {
+		return nil, toStatus(err, "failed to process redemption")
+	}
+
+	return toProtoTransaction(transaction), nil
+}
+
+// idempotencyKey extracts the required idempotency key from request metadata
+func idempotencyKey(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(IdempotencyKeyHeader)
+	if len(values) == 0 || values[0] == "" {
+		return "", status.Error(codes.InvalidArgument, "idempotency-key metadata is required")
+	}
+	return values[0], nil
+}
+
+// wagerLegs returns the legs of a wager, given either per currency by legs or
+// by the GC and SC stake and payout fields
+func wagerLegs(req *walletpb.WagerRequest) ([]models.WagerLeg, error) {
+	if len(req.Legs) > 0 {
+		if req.StakeGc != 0 || req.PayoutGc != 0 || req.StakeSc != 0 || req.PayoutSc != 0 ||
+			req.StakeGcMinor != 0 || req.PayoutGcMinor != 0 || req.StakeScMinor != 0 || req.PayoutScMinor != 0 {
+			return nil, status.Error(codes.InvalidArgument, "set legs or the stake and payout fields, not both")
+		}
+		legs := make([]models.WagerLeg, len(req.Legs))
+		for i, l := range req.Legs {
+			legs[i] = models.WagerLeg{Currency: models.Currency(strings.ToUpper(l.Currency)), Stake: l.StakeMinor, Payout: l.PayoutMinor}
+		}
+		return legs, nil
+	}
+
+	stakeGC, err := requestAmount("stake_gc", req.StakeGc, req.StakeGcMinor, models.CurrencyGC)
+	if err != nil {
+		return nil, err
+	}
+	payoutGC, err := requestAmount("payout_gc", req.PayoutGc, req.PayoutGcMinor, models.CurrencyGC)
+	if err != nil {
+		return nil, err
+	}
+	stakeSC, err := requestAmount("stake_sc", req.StakeSc, req.StakeScMinor, models.CurrencySC)
+	if err != nil {
+		return nil, err
+	}
+	payoutSC, err := requestAmount("payout_sc", req.PayoutSc, req.PayoutScMinor, models.CurrencySC)
+	if err != nil {
+		return nil, err
+	}
+	return models.CoinWagerLegs(stakeGC, payoutGC, stakeSC, payoutSC), nil
+}
+
+// redeemAmount returns the currency and amount of a redemption, given either by
+// currency and amount_minor or by the SC amount fields
+func redeemAmount(req *walletpb.RedeemRequest) (models.Currency, int64, error) {
+	if req.Currency == "" && req.AmountMinor == 0 {
+		amount, err := requestAmount("amount_sc", req.AmountSc, req.AmountScMinor, models.CurrencySC)
+		return models.CurrencySC, amount, err
+	}
+	if req.AmountSc != 0 || req.AmountScMinor != 0 {
+		return "", 0, status.Error(codes.InvalidArgument, "set currency and amount_minor or amount_sc, not both")
+	}
+
+	currency := models.Currency(strings.ToUpper(req.Currency))
+	if currency == "" {
+		currency = models.CurrencySC
+	}
+	return currency, req.AmountMinor, nil
+}
+
+// requestAmount returns an amount in minor units, given either in whole coins by
+// its deprecated field or in minor units by its *_minor field
+func requestAmount(field string, whole, minor int64, currency models.Currency) (int64, error) {
+	if whole == 0 {
+		return minor, nil
+	}
+	if minor != 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "set %s or %s_minor, not both", field, field)
+	}
+
+	amount, err := models.WholeToMinor(whole, currency.MinorUnits())
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "%s: %v", field, err)
+	}
+	return amount, nil
+}
+
+// toStatus maps service sentinel errors to gRPC status codes; anything else is
+// logged and reported as Internal without leaking details
+func toStatus(err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidInput), errors.Is(err, service.ErrInvalidPackage),
+		errors.Is(err, service.ErrInvalidGame), errors.Is(err, service.ErrCurrencyNotAllowed),
+		errors.Is(err, service.ErrStakeOutOfRange), errors.Is(err, service.ErrPromoCodeInvalid),
+		errors.Is(err, service.ErrPromoCodeNotApplicable):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrInsufficientFunds), errors.Is(err, service.ErrGameDisabled),
+		errors.Is(err, service.ErrPromoCodeExpired), errors.Is(err, service.ErrPromoCodeExhausted),
+		errors.Is(err, service.ErrPromoCodeAlreadyUsed), errors.Is(err, service.ErrOfferNotEligible),
+		errors.Is(err, service.ErrSpendLimitExceeded), errors.Is(err, service.ErrWagerLimitExceeded),
+		errors.Is(err, service.ErrSelfExcluded), errors.Is(err, service.ErrSelfExclusionActive),
+		errors.Is(err, service.ErrRealityCheckDue), errors.Is(err, service.ErrSessionLossCapReached),
+		errors.Is(err, service.ErrApprovalRequired):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	log.Printf("gRPC error: %s: %v", message, err)
+	return status.Error(codes.Internal, message)
+}
+
+func toProtoTransaction(t *models.Transaction) *walletpb.Transaction {
+	scale := t.Currency.MinorUnits()
+	return &walletpb.Transaction{
+		Id:                int64(t.ID),
+		UserId:            int64(t.UserID),
+		Currency:          string(t.Currency),
+		Type:              string(t.Type),
+		Amount:            models.MinorToWhole(t.Amount, scale),
+		BalanceAfter:      models.MinorToWhole(t.BalanceAfter, scale),
+		MetadataJson:      string(t.Metadata),
+		CreatedAt:         timestamppb.New(t.CreatedAt),
+		AmountMinor:       t.Amount,
+		BalanceAfterMinor: t.BalanceAfter,
+	}
+}
+
+func toProtoTransactions(transactions []*models.Transaction) *walletpb.TransactionsResponse {
+	resp := &walletpb.TransactionsResponse{
+		Transactions: make([]*walletpb.Transaction, 0, len(transactions)),
+	}
+	for _, t := range transactions {
+		resp.Transactions = append(resp.Transactions, toProtoTransaction(t))
+	}
+	return resp
+}
+
+// loggingInterceptor logs gRPC calls, matching the HTTP logging middleware
+func loggingInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	log.Printf("gRPC %s %s (%s)", info.FullMethod, status.Code(err), time.Since(start))
+	return resp, err
+}
+
+// authInterceptor authenticates calls from the x-api-key or authorization
+// metadata and checks the method's scope. A player token may only name its
+// own user_id.
+func authInterceptor(a *auth.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		principal, err := a.Authenticate(firstValue(md, strings.ToLower(auth.APIKeyHeader)), auth.BearerToken(firstValue(md, "authorization")))
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "authentication required: provide x-api-key or bearer authorization metadata")
+		}
+
+		scope, ok := methodScopes[info.FullMethod]
+		if !ok {
+			return nil, status.Errorf(codes.PermissionDenied, "no scope grants %s", info.FullMethod)
+		}
+		if !principal.HasScope(scope) {
+			return nil, status.Errorf(codes.PermissionDenied, "missing scope %s", scope)
+		}
+		if principal.IsPlayer() {
+			userReq, ok := req.(interface{ GetUserId() int64 })
+			if !ok || !principal.CanAccessUser(int(userReq.GetUserId())) {
+				return nil, status.Error(codes.PermissionDenied, "player tokens can only access their own user")
+			}
+		}
+
+		return handler(auth.NewContext(ctx, principal), req)
+	}
+}
+
+// firstValue returns the first value of a metadata key, or ""
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
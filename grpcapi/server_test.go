@@ -0,0 +1,161 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+	"wallet-ledger/auth"
+	"wallet-ledger/models"
+	"wallet-ledger/proto/walletpb"
+	"wallet-ledger/service"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Test sentinel errors map to the expected gRPC status codes
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"user not found", service.ErrUserNotFound, codes.NotFound},
+		{"invalid input", fmt.Errorf("amounts cannot be negative: %w", service.ErrInvalidInput), codes.InvalidArgument},
+		{"invalid package", fmt.Errorf("%w: unknown", service.ErrInvalidPackage), codes.InvalidArgument},
+		{"insufficient funds", fmt.Errorf("%w: gold coins", service.ErrInsufficientFunds), codes.FailedPrecondition},
+		{"unexpected error", errors.New("connection reset"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := status.Code(toStatus(tt.err, "failed")); code != tt.code {
+				t.Errorf("expected %s, got %s", tt.code, code)
+			}
+		})
+	}
+}
+
+// Test the idempotency key is read from incoming metadata
+func TestIdempotencyKey(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(IdempotencyKeyHeader, "wager-001"))
+
+	key, err := idempotencyKey(ctx)
+	if err != nil || key != "wager-001" {
+		t.Errorf("expected key wager-001, got %q (%v)", key, err)
+	}
+
+	if _, err := idempotencyKey(context.Background()); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for missing key, got %v", err)
+	}
+}
+
+// Test amounts are read from the deprecated whole-coin field or the minor-unit
+// field, never both, and transactions carry both
+func TestAmounts(t *testing.T) {
+	tests := []struct {
+		name         string
+		whole, minor int64
+		expected     int64
+		code         codes.Code
+	}{
+		{"whole coins", 5, 0, 500, codes.OK},
+		{"minor units", 0, 150, 150, codes.OK},
+		{"neither", 0, 0, 0, codes.OK},
+		{"both", 5, 500, 0, codes.InvalidArgument},
+		{"overflow", math.MaxInt64 / 10, 0, 0, codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, err := requestAmount("stake_sc", tt.whole, tt.minor, models.CurrencySC)
+			if status.Code(err) != tt.code || amount != tt.expected {
+				t.Errorf("expected %d (%s), got %d (%v)", tt.expected, tt.code, amount, err)
+			}
+		})
+	}
+
+	legs, err := wagerLegs(&walletpb.WagerRequest{Legs: []*walletpb.WagerLeg{{Currency: "pts", StakeMinor: 500, PayoutMinor: 200}}})
+	if err != nil || len(legs) != 1 || legs[0] != (models.WagerLeg{Currency: "PTS", Stake: 500, Payout: 200}) {
+		t.Errorf("expected a PTS leg, got %+v (%v)", legs, err)
+	}
+	legs, err = wagerLegs(&walletpb.WagerRequest{StakeGcMinor: 100, PayoutScMinor: 250})
+	if err != nil || len(legs) != 2 || legs[0].Stake != 100 || legs[1].Payout != 250 {
+		t.Errorf("expected GC and SC legs, got %+v (%v)", legs, err)
+	}
+	if _, err := wagerLegs(&walletpb.WagerRequest{StakeGcMinor: 100, Legs: []*walletpb.WagerLeg{{Currency: "PTS", StakeMinor: 1}}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected legs and GC fields together to be rejected, got %v", err)
+	}
+
+	currency, amount, err := redeemAmount(&walletpb.RedeemRequest{AmountSc: 5})
+	if err != nil || currency != models.CurrencySC || amount != 500 {
+		t.Errorf("expected 500 SC, got %d %s (%v)", amount, currency, err)
+	}
+	currency, amount, err = redeemAmount(&walletpb.RedeemRequest{Currency: "PTS", AmountMinor: 75})
+	if err != nil || currency != "PTS" || amount != 75 {
+		t.Errorf("expected 75 PTS, got %d %s (%v)", amount, currency, err)
+	}
+	if _, _, err := redeemAmount(&walletpb.RedeemRequest{Currency: "PTS", AmountScMinor: 75}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected currency with amount_sc to be rejected, got %v", err)
+	}
+
+	tx := toProtoTransaction(&models.Transaction{Currency: models.CurrencySC, Amount: 1299, BalanceAfter: -150})
+	if tx.Amount != 12 || tx.AmountMinor != 1299 || tx.BalanceAfter != -1 || tx.BalanceAfterMinor != -150 {
+		t.Errorf("expected amount 12/1299 and balance -1/-150, got %d/%d and %d/%d", tx.Amount, tx.AmountMinor, tx.BalanceAfter, tx.BalanceAfterMinor)
+	}
+}
+
+// Test calls are authenticated and checked against the method's scope, and
+// player tokens are held to their own user
+func TestAuthInterceptor(t *testing.T) {
+	const key = "test-game-server-key-0123456789abcdef"
+	const secret = "test-jwt-secret-0123456789abcdef0123"
+	authenticator, err := auth.New(auth.Config{
+		APIKeys:   []auth.APIKey{{Name: "reporting", Key: key, Scopes: []auth.Scope{auth.ScopeWalletRead}}},
+		JWTSecret: secret,
+	})
+	if err != nil {
+		t.Fatalf("auth.New: %v", err)
+	}
+	playerToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "7", "scope": "wallet:read", "exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+
+	interceptor := authInterceptor(authenticator)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	tests := []struct {
+		name   string
+		md     metadata.MD
+		method string
+		userID int64
+		code   codes.Code
+	}{
+		{"no credentials", metadata.MD{}, walletpb.UserService_GetUser_FullMethodName, 7, codes.Unauthenticated},
+		{"wrong key", metadata.Pairs("x-api-key", "wrong"), walletpb.UserService_GetUser_FullMethodName, 7, codes.Unauthenticated},
+		{"key with scope", metadata.Pairs("x-api-key", key), walletpb.UserService_GetUser_FullMethodName, 99, codes.OK},
+		{"key without scope", metadata.Pairs("x-api-key", key), walletpb.WalletService_Wager_FullMethodName, 7, codes.PermissionDenied},
+		{"player own user", metadata.Pairs("authorization", "Bearer "+playerToken), walletpb.UserService_GetUser_FullMethodName, 7, codes.OK},
+		{"player other user", metadata.Pairs("authorization", "Bearer "+playerToken), walletpb.UserService_GetUser_FullMethodName, 8, codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			info := &grpc.UnaryServerInfo{FullMethod: tt.method}
+			_, err := interceptor(ctx, &walletpb.GetUserRequest{UserId: tt.userID}, info, handler)
+			if code := status.Code(err); code != tt.code {
+				t.Errorf("expected %s, got %s (%v)", tt.code, code, err)
+			}
+		})
+	}
+}
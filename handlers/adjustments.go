@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"wallet-ledger/models"
+	"wallet-ledger/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Adjustment directions accepted in an AdjustmentRequest
+const (
+	adjustmentCredit = "credit"
+	adjustmentDebit  = "debit"
+)
+
+// AdjustmentRequest represents a manual credit or debit by an admin. The admin
+// is the authenticated caller, not a field of the request.
+type AdjustmentRequest struct {
+	Currency       models.Currency         `json:"currency"`
+	Direction      string                  `json:"direction"` // credit or debit
+	Amount         models.Decimal          `json:"amount"`
+	ReasonCode     models.AdjustmentReason `json:"reason_code"`
+	Note           string                  `json:"note"`
+	IdempotencyKey string                  `json:"idempotency_key"`
+}
+
+// AdjustBalance handles POST /admin/users/:id/adjustments
+func (h *Handler) AdjustBalance(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	var req AdjustmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.IdempotencyKey == "" {
+		respondError(w, http.StatusBadRequest, "idempotency_key is required")
+		return
+	}
+
+	currency := models.Currency(strings.ToUpper(string(req.Currency)))
+	amount, err := h.service.ParseAmount(currency, req.Amount)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if amount <= 0 {
+		respondError(w, http.StatusBadRequest, "amount must be positive")
+		return
+	}
+
+	switch req.Direction {
+	case adjustmentCredit:
+	case adjustmentDebit:
+		amount = -amount
+	default:
+		respondError(w, http.StatusBadRequest, "invalid direction: must be credit or debit")
+		return
+	}
+
+	adjustment, err := h.service.AdjustBalance(userID, currency, amount, req.ReasonCode, req.Note, principalName(r), req.IdempotencyKey)
+	if err != nil {
+		// Held for approval: 202 with the pending request instead of an adjustment
+		var approvalErr *service.ApprovalRequiredError
+		if errors.As(err, &approvalErr) {
+			respondJSON(w, http.StatusAccepted, approvalErr.Request)
+			return
+		}
+
+		log.Printf("Error adjusting balance: %v", err)
+
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			respondError(w, http.StatusNotFound, "user not found")
+		case errors.Is(err, service.ErrInsufficientFunds), errors.Is(err, service.ErrInvalidInput):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to adjust balance")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, adjustment)
+}
+
+// ListAdjustments handles GET /admin/adjustments and GET /admin/users/:id/adjustments
+func (h *Handler) ListAdjustments(w http.ResponseWriter, r *http.Request) {
+	var filter models.AdjustmentFilter
+	if idStr := chi.URLParam(r, "id"); idStr != "" {
+		userID, err := strconv.Atoi(idStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid user id")
+			return
+		}
+		filter.UserID = &userID
+	}
+	if reasonStr := r.URL.Query().Get("reason_code"); reasonStr != "" {
+		reason := models.AdjustmentReason(reasonStr)
+		if !reason.IsValid() {
+			respondError(w, http.StatusBadRequest, "invalid reason_code")
+			return
+		}
+		filter.Reason = &reason
+	}
+	filter.Admin = r.URL.Query().Get("admin")
+
+	cursor := r.URL.Query().Get("cursor")
+	var cursorPtr *string
+	if cursor != "" {
+		cursorPtr = &cursor
+	}
+
+	limit := DefaultPageLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit <= 0 || parsedLimit > MaxPageLimit {
+			respondError(w, http.StatusBadRequest, "invalid limit: must be between 1 and 100")
+			return
+		}
+		limit = parsedLimit
+	}
+
+	adjustments, err := h.service.ListAdjustments(filter, cursorPtr, limit)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			respondError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		log.Printf("Error listing adjustments: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list adjustments")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, adjustments)
+}
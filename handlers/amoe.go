@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"wallet-ledger/models"
+	"wallet-ledger/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// AMOEEntryRequest represents a mail-in or web-form AMOE entry
+type AMOEEntryRequest struct {
+	Code   string            `json:"code"`
+	Method models.AMOEMethod `json:"method"`
+}
+
+// AMOEReviewRequest represents an approval or rejection of an AMOE entry.
+// The reviewer is the authenticated principal, never a body field.
+type AMOEReviewRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+// RequestAMOECode handles POST /users/:id/amoe-codes
+func (h *Handler) RequestAMOECode(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	code, err := h.service.RequestAMOECode(userID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			respondError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		log.Printf("Error issuing AMOE code: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to issue amoe code")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, code)
+}
+
+// SubmitAMOEEntry handles POST /amoe/entries
+func (h *Handler) SubmitAMOEEntry(w http.ResponseWriter, r *http.Request) {
+	var req AMOEEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.Code == "" {
+		respondError(w, http.StatusBadRequest, "code is required")
+		return
+	}
+
+	// The operator keying the entry in is the authenticated principal
+	entry, err := h.service.SubmitAMOEEntry(req.Code, req.Method, principalName(r))
+	if err != nil {
+		log.Printf("Error submitting AMOE entry: %v", err)
+
+		switch {
+		case errors.Is(err, service.ErrAMOECodeNotFound):
+			respondError(w, http.StatusNotFound, "amoe code not found")
+		case errors.Is(err, service.ErrAMOECodeUsed):
+			respondError(w, http.StatusConflict, err.Error())
+		case errors.Is(err, service.ErrInvalidInput),
+			errors.Is(err, service.ErrAMOECodeExpired),
+			errors.Is(err, service.ErrAMOELimitReached):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to submit amoe entry")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, entry)
+}
+
+// ListAMOEEntries handles GET /amoe/entries
+func (h *Handler) ListAMOEEntries(w http.ResponseWriter, r *http.Request) {
+	var status *models.AMOEStatus
+	if statusStr := r.URL.Query().Get("status"); statusStr != "" {
+		s := models.AMOEStatus(statusStr)
+		if s != models.AMOEStatusPending && s != models.AMOEStatusApproved && s != models.AMOEStatusRejected {
+			respondError(w, http.StatusBadRequest, "invalid status: must be pending, approved or rejected")
+			return
+		}
+		status = &s
+	}
+
+	cursor := r.URL.Query().Get("cursor")
+	var cursorPtr *string
+	if cursor != "" {
+		cursorPtr = &cursor
+	}
+
+	limit := DefaultPageLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit <= 0 || parsedLimit > MaxPageLimit {
+			respondError(w, http.StatusBadRequest, "invalid limit: must be between 1 and 100")
+			return
+		}
+		limit = parsedLimit
+	}
+
+	entries, err := h.service.ListAMOEEntries(status, cursorPtr, limit)
+	if err != nil {
+		log.Printf("Error listing AMOE entries: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list amoe entries")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, entries)
+}
+
+// GetAMOEEntry handles GET /amoe/entries/:entryID
+func (h *Handler) GetAMOEEntry(w http.ResponseWriter, r *http.Request) {
+	entryID, err := strconv.Atoi(chi.URLParam(r, "entryID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid entry id")
+		return
+	}
+
+	entry, err := h.service.GetAMOEEntry(entryID)
+	if err != nil {
+		if errors.Is(err, service.ErrAMOEEntryNotFound) {
+			respondError(w, http.StatusNotFound, "amoe entry not found")
+			return
+		}
+		log.Printf("Error getting AMOE entry: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get amoe entry")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, entry)
+}
+
+// ApproveAMOEEntry handles POST /amoe/entries/:entryID/approve
+func (h *Handler) ApproveAMOEEntry(w http.ResponseWriter, r *http.Request) {
+	h.reviewAMOEEntry(w, r, h.service.ApproveAMOEEntry)
+}
+
+// RejectAMOEEntry handles POST /amoe/entries/:entryID/reject
+func (h *Handler) RejectAMOEEntry(w http.ResponseWriter, r *http.Request) {
+	h.reviewAMOEEntry(w, r, h.service.RejectAMOEEntry)
+}
+
+func (h *Handler) reviewAMOEEntry(w http.ResponseWriter, r *http.Request, review func(int, string, string) (*models.AMOEEntry, error)) {
+	entryID, err := strconv.Atoi(chi.URLParam(r, "entryID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid entry id")
+		return
+	}
+
+	// The body only carries an optional note
+	var req AMOEReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	entry, err := review(entryID, principalName(r), req.Note)
+	if err != nil {
+		log.Printf("Error reviewing AMOE entry: %v", err)
+
+		switch {
+		case errors.Is(err, service.ErrAMOEEntryNotFound):
+			respondError(w, http.StatusNotFound, "amoe entry not found")
+		case errors.Is(err, service.ErrAMOEEntryReviewed):
+			respondError(w, http.StatusConflict, err.Error())
+		case errors.Is(err, service.ErrInvalidInput):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to review amoe entry")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, entry)
+}
+
+// GetAMOESettings handles GET /amoe/settings
+func (h *Handler) GetAMOESettings(w http.ResponseWriter, r *http.Request) {
+	settings, err := h.service.GetAMOESettings()
+	if err != nil {
+		log.Printf("Error getting AMOE settings: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get amoe settings")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, settings)
+}
+
+// SaveAMOESettings handles PUT /amoe/settings
+func (h *Handler) SaveAMOESettings(w http.ResponseWriter, r *http.Request) {
+	var settings models.AMOESettings
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		respondError(w, http.StatusBadRequest, invalidBody(err))
+		return
+	}
+
+	if err := h.service.SaveAMOESettings(&settings); err != nil {
+		log.Printf("Error saving AMOE settings: %v", err)
+
+		if errors.Is(err, service.ErrInvalidInput) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		respondError(w, http.StatusInternalServerError, "failed to save amoe settings")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, settings)
+}
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"wallet-ledger/models"
+	"wallet-ledger/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ApprovalThresholdRequest represents a change of an approval threshold, with
+// a decimal amount
+type ApprovalThresholdRequest struct {
+	Threshold         models.Decimal `json:"threshold"`
+	ExpiresAfterHours int            `json:"expires_after_hours"`
+}
+
+// ApprovalDecisionRequest represents an approval or rejection of a pending
+// request. The deciding admin is the authenticated caller, so one person cannot
+// approve their own request by naming someone else.
+type ApprovalDecisionRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+// ListApprovalThresholds handles GET /admin/approval-thresholds
+func (h *Handler) ListApprovalThresholds(w http.ResponseWriter, r *http.Request) {
+	thresholds, err := h.service.ListApprovalThresholds()
+	if err != nil {
+		log.Printf("Error listing approval thresholds: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list approval thresholds")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, thresholds)
+}
+
+// SaveApprovalThreshold handles PUT /admin/approval-thresholds/:operation/:currency
+func (h *Handler) SaveApprovalThreshold(w http.ResponseWriter, r *http.Request) {
+	var req ApprovalThresholdRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	currency := models.Currency(strings.ToUpper(chi.URLParam(r, "currency")))
+	amount, err := h.service.ParseAmount(currency, req.Threshold)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	threshold := &models.ApprovalThreshold{
+		Operation:         models.ApprovalOperation(chi.URLParam(r, "operation")),
+		Currency:          currency,
+		Threshold:         amount,
+		ExpiresAfterHours: req.ExpiresAfterHours,
+	}
+
+	if err := h.service.SaveApprovalThreshold(threshold); err != nil {
+		if errors.Is(err, service.ErrInvalidInput) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		log.Printf("Error saving approval threshold: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to save approval threshold")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, threshold)
+}
+
+// ListApprovalRequests handles GET /admin/approvals
+func (h *Handler) ListApprovalRequests(w http.ResponseWriter, r *http.Request) {
+	var status *models.ApprovalStatus
+	if statusStr := r.URL.Query().Get("status"); statusStr != "" {
+		s := models.ApprovalStatus(statusStr)
+		if !s.IsValid() {
+			respondError(w, http.StatusBadRequest, "invalid status: must be pending, approved, rejected or expired")
+			return
+		}
+		status = &s
+	}
+
+	var operation *models.ApprovalOperation
+	if operationStr := r.URL.Query().Get("operation"); operationStr != "" {
+		o := models.ApprovalOperation(operationStr)
+		if !o.IsValid() {
+			respondError(w, http.StatusBadRequest, "invalid operation: must be adjustment, redemption or bonus")
+			return
+		}
+		operation = &o
+	}
+
+	cursor := r.URL.Query().Get("cursor")
+	var cursorPtr *string
+	if cursor != "" {
+		cursorPtr = &cursor
+	}
+
+	limit := DefaultPageLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit <= 0 || parsedLimit > MaxPageLimit {
+			respondError(w, http.StatusBadRequest, "invalid limit: must be between 1 and 100")
+			return
+		}
+		limit = parsedLimit
+	}
+
+	requests, err := h.service.ListApprovalRequests(status, operation, cursorPtr, limit)
+	if err != nil {
+		log.Printf("Error listing approval requests: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list approval requests")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, requests)
+}
+
+// GetApprovalRequest handles GET /admin/approvals/:requestID
+func (h *Handler) GetApprovalRequest(w http.ResponseWriter, r *http.Request) {
+	requestID, err := strconv.Atoi(chi.URLParam(r, "requestID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request id")
+		return
+	}
+
+	request, err := h.service.GetApprovalRequest(requestID)
+	if err != nil {
+		if errors.Is(err, service.ErrApprovalNotFound) {
+			respondError(w, http.StatusNotFound, "approval request not found")
+			return
+		}
+		log.Printf("Error getting approval request: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get approval request")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, request)
+}
+
+// ApproveRequest handles POST /admin/approvals/:requestID/approve
+func (h *Handler) ApproveRequest(w http.ResponseWriter, r *http.Request) {
+	h.decideApproval(w, r, h.service.ApproveRequest)
+}
+
+// RejectRequest handles POST /admin/approvals/:requestID/reject
+func (h *Handler) RejectRequest(w http.ResponseWriter, r *http.Request) {
+	h.decideApproval(w, r, h.service.RejectRequest)
+}
+
+func (h *Handler) decideApproval(w http.ResponseWriter, r *http.Request, decide func(int, string, string) (*models.ApprovalRequest, error)) {
+	requestID, err := strconv.Atoi(chi.URLParam(r, "requestID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request id")
+		return
+	}
+
+	// The body only carries an optional note
+	var req ApprovalDecisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	request, err := decide(requestID, principalName(r), req.Note)
+	if err != nil {
+		log.Printf("Error deciding approval request: %v", err)
+
+		switch {
+		case errors.Is(err, service.ErrApprovalNotFound):
+			respondError(w, http.StatusNotFound, "approval request not found")
+		case errors.Is(err, service.ErrApprovalDecided), errors.Is(err, service.ErrApprovalExpired):
+			respondError(w, http.StatusConflict, err.Error())
+		case errors.Is(err, service.ErrSelfApproval):
+			respondError(w, http.StatusForbidden, err.Error())
+		case errors.Is(err, service.ErrInsufficientFunds), errors.Is(err, service.ErrInvalidInput),
+			errors.Is(err, service.ErrCurrencyNotAllowed), isCampaignError(err):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to decide approval request")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, request)
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"wallet-ledger/models"
+	"wallet-ledger/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// BonusRequest represents a promotional bonus grant, with decimal amounts
+type BonusRequest struct {
+	CampaignID     string         `json:"campaign_id"`
+	AmountGC       models.Decimal `json:"amount_gc,omitempty"`
+	AmountSC       models.Decimal `json:"amount_sc,omitempty"`
+	IdempotencyKey string         `json:"idempotency_key"`
+}
+
+// ListCampaigns handles GET /campaigns
+func (h *Handler) ListCampaigns(w http.ResponseWriter, r *http.Request) {
+	campaigns, err := h.service.ListCampaigns()
+	if err != nil {
+		log.Printf("Error listing campaigns: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list campaigns")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, campaigns)
+}
+
+// GetCampaign handles GET /campaigns/:campaignID
+func (h *Handler) GetCampaign(w http.ResponseWriter, r *http.Request) {
+	campaign, err := h.service.GetCampaign(chi.URLParam(r, "campaignID"))
+	if err != nil {
+		if errors.Is(err, service.ErrCampaignNotFound) {
+			respondError(w, http.StatusNotFound, "campaign not found")
+			return
+		}
+		log.Printf("Error getting campaign: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get campaign")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, campaign)
+}
+
+// SaveCampaign handles PUT /campaigns/:campaignID
+func (h *Handler) SaveCampaign(w http.ResponseWriter, r *http.Request) {
+	var campaign models.BonusCampaign
+	if err := json.NewDecoder(r.Body).Decode(&campaign); err != nil {
+		respondError(w, http.StatusBadRequest, invalidBody(err))
+		return
+	}
+	campaign.ID = chi.URLParam(r, "campaignID")
+
+	if err := h.service.SaveCampaign(&campaign); err != nil {
+		log.Printf("Error saving campaign: %v", err)
+
+		if errors.Is(err, service.ErrInvalidInput) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		respondError(w, http.StatusInternalServerError, "failed to save campaign")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, campaign)
+}
+
+// GetCampaignReport handles GET /campaigns/:campaignID/report
+func (h *Handler) GetCampaignReport(w http.ResponseWriter, r *http.Request) {
+	report, err := h.service.GetCampaignReport(chi.URLParam(r, "campaignID"))
+	if err != nil {
+		if errors.Is(err, service.ErrCampaignNotFound) {
+			respondError(w, http.StatusNotFound, "campaign not found")
+			return
+		}
+		log.Printf("Error getting campaign report: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get campaign report")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, report)
+}
+
+// GrantBonus handles POST /users/:id/bonus
+func (h *Handler) GrantBonus(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	var req BonusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.CampaignID == "" {
+		respondError(w, http.StatusBadRequest, "campaign_id is required")
+		return
+	}
+
+	if req.IdempotencyKey == "" {
+		respondError(w, http.StatusBadRequest, "idempotency_key is required")
+		return
+	}
+
+	amountGC, err := h.service.ParseAmount(models.CurrencyGC, req.AmountGC)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	amountSC, err := h.service.ParseAmount(models.CurrencySC, req.AmountSC)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	transactions, err := h.service.GrantBonus(userID, req.CampaignID, amountGC, amountSC, principalName(r), req.IdempotencyKey)
+	if err != nil {
+		// Held for approval: 202 with the pending request instead of transactions
+		var approvalErr *service.ApprovalRequiredError
+		if errors.As(err, &approvalErr) {
+			respondJSON(w, http.StatusAccepted, approvalErr.Request)
+			return
+		}
+
+		log.Printf("Error granting bonus: %v", err)
+
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			respondError(w, http.StatusNotFound, "user not found")
+		case errors.Is(err, service.ErrInvalidInput), isCampaignError(err):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to grant bonus")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, transactions)
+}
+
+// isCampaignError reports whether err is a bonus rejected by its campaign
+func isCampaignError(err error) bool {
+	return errors.Is(err, service.ErrCampaignNotFound) ||
+		errors.Is(err, service.ErrCampaignInactive) ||
+		errors.Is(err, service.ErrBonusBudgetExceeded) ||
+		errors.Is(err, service.ErrBonusCapExceeded)
+}
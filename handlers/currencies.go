@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"wallet-ledger/models"
+	"wallet-ledger/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ListCurrencies handles GET /currencies
+func (h *Handler) ListCurrencies(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, h.service.ListCurrencies())
+}
+
+// GetCurrency handles GET /currencies/{code}
+func (h *Handler) GetCurrency(w http.ResponseWriter, r *http.Request) {
+	currency, err := h.service.GetCurrency(models.Currency(chi.URLParam(r, "code")))
+	if err != nil {
+		respondError(w, http.StatusNotFound, "currency not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, currency)
+}
+
+// SaveCurrency handles PUT /currencies/{code}
+func (h *Handler) SaveCurrency(w http.ResponseWriter, r *http.Request) {
+	var currency models.CurrencyInfo
+	if err := json.NewDecoder(r.Body).Decode(&currency); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	currency.Code = models.Currency(chi.URLParam(r, "code"))
+
+	if err := h.service.SaveCurrency(&currency); err != nil {
+		log.Printf("Error saving currency: %v", err)
+
+		if errors.Is(err, service.ErrInvalidInput) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		respondError(w, http.StatusInternalServerError, "failed to save currency")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, currency)
+}
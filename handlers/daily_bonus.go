@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"wallet-ledger/models"
+	"wallet-ledger/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TimeZoneRequest represents a change of a user's time zone
+type TimeZoneRequest struct {
+	TimeZone string `json:"time_zone"`
+}
+
+// ClaimDailyBonus handles POST /users/:id/daily-bonus
+func (h *Handler) ClaimDailyBonus(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	claim, err := h.service.ClaimDailyBonus(userID)
+	if err != nil {
+		log.Printf("Error claiming daily bonus: %v", err)
+
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			respondError(w, http.StatusNotFound, "user not found")
+		case errors.Is(err, service.ErrDailyBonusUnavailable), isCampaignError(err):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to claim daily bonus")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, claim)
+}
+
+// SetTimeZone handles PUT /users/:id/time-zone
+func (h *Handler) SetTimeZone(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	var req TimeZoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.service.SetUserTimeZone(userID, req.TimeZone); err != nil {
+		log.Printf("Error setting time zone: %v", err)
+
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			respondError(w, http.StatusNotFound, "user not found")
+		case errors.Is(err, service.ErrInvalidInput):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to set time zone")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, req)
+}
+
+// GetDailyBonusSchedule handles GET /daily-bonus/schedule
+func (h *Handler) GetDailyBonusSchedule(w http.ResponseWriter, r *http.Request) {
+	schedule, err := h.service.GetDailyBonusSchedule()
+	if err != nil {
+		log.Printf("Error getting daily bonus schedule: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get daily bonus schedule")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, schedule)
+}
+
+// SaveDailyBonusSchedule handles PUT /daily-bonus/schedule
+func (h *Handler) SaveDailyBonusSchedule(w http.ResponseWriter, r *http.Request) {
+	var schedule []models.DailyBonusDay
+	if err := json.NewDecoder(r.Body).Decode(&schedule); err != nil {
+		respondError(w, http.StatusBadRequest, invalidBody(err))
+		return
+	}
+
+	if err := h.service.SaveDailyBonusSchedule(schedule); err != nil {
+		log.Printf("Error saving daily bonus schedule: %v", err)
+
+		if errors.Is(err, service.ErrInvalidInput) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		respondError(w, http.StatusInternalServerError, "failed to save daily bonus schedule")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, schedule)
+}
@@ -0,0 +1,345 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+	"wallet-ledger/models"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// HeartbeatInterval is how often idle event streams are kept alive
+	HeartbeatInterval = 15 * time.Second
+
+	// MaxReplayEvents caps how many missed transactions are replayed on resume;
+	// a larger gap is reported with a replay_limit_exceeded event instead
+	MaxReplayEvents = 1000
+)
+
+// BalanceEvent reports the new balance of one currency after a transaction
+type BalanceEvent struct {
+	UserID   int             `json:"user_id"`
+	Currency models.Currency `json:"currency"`
+	Balance  string          `json:"balance"` // decimal string
+}
+
+// ReplayLimitEvent tells a resuming client its gap was too large to replay. The
+// stream continues with live events after the user's latest transaction, so the
+// client must reload balances and history.
+type ReplayLimitEvent struct {
+	Error           string `json:"error"`
+	MaxReplayEvents int    `json:"max_replay_events"`
+}
+
+// eventSource loads the transactions an event stream starts from
+type eventSource interface {
+	LatestTransactionID(userID int) (int, error)
+	ListTransactionsAfter(userID int, afterID int, limit int) ([]models.Transaction, error)
+}
+
+// StreamMessage is the envelope for events sent over WebSocket
+type StreamMessage struct {
+	ID    int         `json:"id,omitempty"`
+	Event string      `json:"event"`
+	Data  interface{} `json:"data,omitempty"`
+}
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// CORS is open for the REST API as well, so accept any origin
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// Events handles GET /users/:id/events (Server-Sent Events)
+func (h *Handler) Events(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	lastEventID, resuming, err := parseLastEventID(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid last event id")
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		respondError(w, http.StatusInternalServerError, "streaming unsupported")
+		return
+	}
+
+	if _, err := h.service.GetUserWithBalances(userID); err != nil {
+		respondError(w, http.StatusNotFound, "user not found")
+		return
+	}
+
+	if h.events.Closed() {
+		respondError(w, http.StatusServiceUnavailable, "event stream unavailable")
+		return
+	}
+
+	// Subscribe before replaying so nothing committed in between is lost
+	sub := h.events.Subscribe(userID)
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(t *models.Transaction) error {
+		if err := writeSSE(w, 0, "balance", balanceEvent(t)); err != nil {
+			return err
+		}
+		if err := writeSSE(w, t.ID, "transaction", t); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	}
+
+	gapTooLarge := func(latestID int) error {
+		return writeSSE(w, latestID, "replay_limit_exceeded", replayLimitEvent())
+	}
+
+	lastEventID, err = startStream(h.service, userID, lastEventID, resuming, send, gapTooLarge)
+	if err != nil {
+		log.Printf("Error replaying events: %v", err)
+		return
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case t, ok := <-sub.Transactions():
+			if !ok {
+				return
+			}
+			if t.ID <= lastEventID {
+				continue
+			}
+			if err := send(t); err != nil {
+				return
+			}
+			lastEventID = t.ID
+		case warning, ok := <-sub.Warnings():
+			if !ok {
+				return
+			}
+			if err := writeSSE(w, 0, "sc_expiry_warning", warning); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// EventsWebSocket handles GET /users/:id/ws
+func (h *Handler) EventsWebSocket(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	lastEventID, resuming, err := parseLastEventID(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid last event id")
+		return
+	}
+
+	if _, err := h.service.GetUserWithBalances(userID); err != nil {
+		respondError(w, http.StatusNotFound, "user not found")
+		return
+	}
+
+	if h.events.Closed() {
+		respondError(w, http.StatusServiceUnavailable, "event stream unavailable")
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("Error upgrading websocket: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	sub := h.events.Subscribe(userID)
+	defer sub.Close()
+
+	// Clients only send control frames; reading is required to process them
+	// and to notice when the client goes away
+	closed := make(chan struct{})
+	conn.SetReadDeadline(time.Now().Add(2 * HeartbeatInterval))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * HeartbeatInterval))
+	})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	send := func(t *models.Transaction) error {
+		if err := conn.WriteJSON(StreamMessage{Event: "balance", Data: balanceEvent(t)}); err != nil {
+			return err
+		}
+		return conn.WriteJSON(StreamMessage{ID: t.ID, Event: "transaction", Data: t})
+	}
+
+	gapTooLarge := func(latestID int) error {
+		return conn.WriteJSON(StreamMessage{ID: latestID, Event: "replay_limit_exceeded", Data: replayLimitEvent()})
+	}
+
+	lastEventID, err = startStream(h.service, userID, lastEventID, resuming, send, gapTooLarge)
+	if err != nil {
+		log.Printf("Error replaying events: %v", err)
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseInternalServerErr, "failed to replay events"))
+		return
+	}
+
+	heartbeat := time.NewTicker(HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	warnings := sub.Warnings()
+	for {
+		select {
+		case t, ok := <-sub.Transactions():
+			if !ok {
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseGoingAway, "stream closed"),
+					time.Now().Add(time.Second))
+				return
+			}
+			if t.ID <= lastEventID {
+				continue
+			}
+			if err := send(t); err != nil {
+				return
+			}
+			lastEventID = t.ID
+		case warning, ok := <-warnings:
+			if !ok {
+				// Closed together with the transactions channel, which reports it
+				warnings = nil
+				continue
+			}
+			if err := conn.WriteJSON(StreamMessage{Event: "sc_expiry_warning", Data: warning}); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(HeartbeatInterval)); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+// startStream positions a new stream and returns the ID live events continue
+// after. A fresh connection starts after the user's latest transaction and
+// gets live events only. A resuming one is sent the transactions it missed, up
+// to MaxReplayEvents; a larger gap is skipped and reported with gapTooLarge.
+// Transactions committed meanwhile are queued on the subscription, which the
+// caller opens first.
+func startStream(src eventSource, userID, lastEventID int, resuming bool, send func(*models.Transaction) error, gapTooLarge func(latestID int) error) (int, error) {
+	if !resuming {
+		return src.LatestTransactionID(userID)
+	}
+
+	missed, err := src.ListTransactionsAfter(userID, lastEventID, MaxReplayEvents+1)
+	if err != nil {
+		return lastEventID, err
+	}
+	if len(missed) > MaxReplayEvents {
+		latestID, err := src.LatestTransactionID(userID)
+		if err != nil {
+			return lastEventID, err
+		}
+		return latestID, gapTooLarge(latestID)
+	}
+
+	for i := range missed {
+		if err := send(&missed[i]); err != nil {
+			return lastEventID, err
+		}
+		lastEventID = missed[i].ID
+	}
+	return lastEventID, nil
+}
+
+// replayLimitEvent builds the event sent instead of a gap too large to replay
+func replayLimitEvent() ReplayLimitEvent {
+	return ReplayLimitEvent{
+		Error:           fmt.Sprintf("more than %d missed transactions; reload balances and history", MaxReplayEvents),
+		MaxReplayEvents: MaxReplayEvents,
+	}
+}
+
+// parseLastEventID reads the resume position from the Last-Event-ID header or
+// the last_event_id query parameter (for clients that cannot set headers).
+// resuming is false when neither is given.
+func parseLastEventID(r *http.Request) (id int, resuming bool, err error) {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("last_event_id")
+	}
+	if value == "" {
+		return 0, false, nil
+	}
+
+	id, err = strconv.Atoi(value)
+	if err != nil || id < 0 {
+		return 0, false, fmt.Errorf("invalid last event id: %q", value)
+	}
+	return id, true, nil
+}
+
+// balanceEvent builds the balance change implied by a transaction
+func balanceEvent(t *models.Transaction) BalanceEvent {
+	return BalanceEvent{
+		UserID:   t.UserID,
+		Currency: t.Currency,
+		Balance:  models.FormatAmount(t.BalanceAfter, t.Currency.MinorUnits()),
+	}
+}
+
+// writeSSE writes a single Server-Sent Event; id 0 omits the id field
+func writeSSE(w http.ResponseWriter, id int, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if id > 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", id); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+	return err
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+	"wallet-ledger/models"
+)
+
+// fakeEventSource serves a user's transactions from memory, in ID order
+type fakeEventSource struct {
+	transactions []models.Transaction
+}
+
+func (f *fakeEventSource) LatestTransactionID(userID int) (int, error) {
+	if len(f.transactions) == 0 {
+		return 0, nil
+	}
+	return f.transactions[len(f.transactions)-1].ID, nil
+}
+
+func (f *fakeEventSource) ListTransactionsAfter(userID int, afterID int, limit int) ([]models.Transaction, error) {
+	var page []models.Transaction
+	for _, t := range f.transactions {
+		if t.ID > afterID && len(page) < limit {
+			page = append(page, t)
+		}
+	}
+	return page, nil
+}
+
+func newFakeEventSource(n int) *fakeEventSource {
+	src := &fakeEventSource{}
+	for id := 1; id <= n; id++ {
+		src.transactions = append(src.transactions, models.Transaction{ID: id, UserID: 1, Currency: models.CurrencyGC})
+	}
+	return src
+}
+
+// connect parses the resume position of a stream request and starts the
+// stream, returning the IDs sent, the position live events continue after and
+// the position reported as too large a gap, if any
+func connect(t *testing.T, src eventSource, target, lastEventIDHeader string) ([]int, int, int) {
+	t.Helper()
+
+	r := httptest.NewRequest("GET", target, nil)
+	if lastEventIDHeader != "" {
+		r.Header.Set("Last-Event-ID", lastEventIDHeader)
+	}
+	lastEventID, resuming, err := parseLastEventID(r)
+	if err != nil {
+		t.Fatalf("parseLastEventID: %v", err)
+	}
+
+	var sent []int
+	gapID := 0
+	send := func(tx *models.Transaction) error {
+		sent = append(sent, tx.ID)
+		return nil
+	}
+	gapTooLarge := func(latestID int) error {
+		gapID = latestID
+		return nil
+	}
+
+	position, err := startStream(src, 1, lastEventID, resuming, send, gapTooLarge)
+	if err != nil {
+		t.Fatalf("startStream: %v", err)
+	}
+	return sent, position, gapID
+}
+
+// Test a fresh connection gets no backlog and continues after the latest transaction
+func TestStartStream_FreshConnectHasNoBacklog(t *testing.T) {
+	sent, position, gapID := connect(t, newFakeEventSource(5000), "/users/1/events", "")
+
+	if len(sent) != 0 {
+		t.Errorf("expected no replayed transactions, got %d", len(sent))
+	}
+	if position != 5000 || gapID != 0 {
+		t.Errorf("expected live events after 5000 without a gap event, got %d (gap %d)", position, gapID)
+	}
+}
+
+// Test a resuming client is sent exactly the transactions it missed
+func TestStartStream_Resume(t *testing.T) {
+	src := newFakeEventSource(10)
+
+	sent, position, gapID := connect(t, src, "/users/1/events", "7")
+	if len(sent) != 3 || sent[0] != 8 || position != 10 || gapID != 0 {
+		t.Errorf("expected 8-10 replayed, got %v (position %d, gap %d)", sent, position, gapID)
+	}
+
+	// last_event_id=0 explicitly resumes from the start
+	sent, _, _ = connect(t, src, "/users/1/ws?last_event_id=0", "")
+	if len(sent) != 10 {
+		t.Errorf("expected the whole history when resuming from 0, got %d", len(sent))
+	}
+}
+
+// Test a gap larger than MaxReplayEvents is reported instead of replayed
+func TestStartStream_GapTooLarge(t *testing.T) {
+	sent, position, gapID := connect(t, newFakeEventSource(MaxReplayEvents+10), "/users/1/events", "5")
+
+	if len(sent) != 0 {
+		t.Errorf("expected nothing replayed, got %d", len(sent))
+	}
+	if gapID != MaxReplayEvents+10 || position != MaxReplayEvents+10 {
+		t.Errorf("expected gap event and position at %d, got gap %d, position %d", MaxReplayEvents+10, gapID, position)
+	}
+}
+
+// Test malformed resume positions are rejected
+func TestParseLastEventID_Invalid(t *testing.T) {
+	for _, value := range []string{"abc", "-1"} {
+		r := httptest.NewRequest("GET", "/users/1/events", nil)
+		r.Header.Set("Last-Event-ID", value)
+		if _, _, err := parseLastEventID(r); err == nil {
+			t.Errorf("%q: expected error", value)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"wallet-ledger/models"
+	"wallet-ledger/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GetGameSession handles GET /users/:id/session
+func (h *Handler) GetGameSession(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	session, err := h.service.GetGameSession(userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			respondError(w, http.StatusNotFound, "user not found")
+		case errors.Is(err, service.ErrGameSessionNotFound):
+			respondError(w, http.StatusNotFound, err.Error())
+		default:
+			log.Printf("Error getting game session: %v", err)
+			respondError(w, http.StatusInternalServerError, "failed to get game session")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, session)
+}
+
+// AcknowledgeRealityCheck handles POST /users/:id/session/reality-check
+func (h *Handler) AcknowledgeRealityCheck(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	session, err := h.service.AcknowledgeRealityCheck(userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			respondError(w, http.StatusNotFound, "user not found")
+		case errors.Is(err, service.ErrGameSessionNotFound):
+			respondError(w, http.StatusNotFound, err.Error())
+		default:
+			log.Printf("Error acknowledging reality check: %v", err)
+			respondError(w, http.StatusInternalServerError, "failed to acknowledge reality check")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, session)
+}
+
+// ListGameSessions handles GET /users/:id/sessions
+func (h *Handler) ListGameSessions(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	limit := DefaultPageLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit <= 0 || parsedLimit > MaxPageLimit {
+			respondError(w, http.StatusBadRequest, "invalid limit: must be between 1 and 100")
+			return
+		}
+		limit = parsedLimit
+	}
+
+	sessions, err := h.service.ListGameSessions(userID, limit)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			respondError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		log.Printf("Error listing game sessions: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list game sessions")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, sessions)
+}
+
+// GetGameSessionSettings handles GET /users/:id/session-settings
+func (h *Handler) GetGameSessionSettings(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	settings, err := h.service.GetGameSessionSettings(userID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			respondError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		log.Printf("Error getting game session settings: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get game session settings")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, settings)
+}
+
+// SaveGameSessionSettings handles PUT /users/:id/session-settings
+func (h *Handler) SaveGameSessionSettings(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	var settings models.GameSessionSettings
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		respondError(w, http.StatusBadRequest, invalidBody(err))
+		return
+	}
+	settings.UserID = userID
+
+	if err := h.service.SaveGameSessionSettings(&settings); err != nil {
+		log.Printf("Error saving game session settings: %v", err)
+
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			respondError(w, http.StatusNotFound, "user not found")
+		case errors.Is(err, service.ErrInvalidInput):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to save game session settings")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, settings)
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"wallet-ledger/models"
+	"wallet-ledger/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ListGames handles GET /games
+func (h *Handler) ListGames(w http.ResponseWriter, r *http.Request) {
+	games, err := h.service.ListGames()
+	if err != nil {
+		log.Printf("Error listing games: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list games")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, games)
+}
+
+// GetGame handles GET /games/:gameID
+func (h *Handler) GetGame(w http.ResponseWriter, r *http.Request) {
+	game, err := h.service.GetGame(chi.URLParam(r, "gameID"))
+	if err != nil {
+		if errors.Is(err, service.ErrGameNotFound) {
+			respondError(w, http.StatusNotFound, "game not found")
+			return
+		}
+		log.Printf("Error getting game: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get game")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, game)
+}
+
+// SaveGame handles PUT /games/:gameID
+func (h *Handler) SaveGame(w http.ResponseWriter, r *http.Request) {
+	var game models.Game
+	if err := json.NewDecoder(r.Body).Decode(&game); err != nil {
+		respondError(w, http.StatusBadRequest, invalidBody(err))
+		return
+	}
+	game.ID = chi.URLParam(r, "gameID")
+
+	if err := h.service.SaveGame(&game); err != nil {
+		log.Printf("Error saving game: %v", err)
+
+		if errors.Is(err, service.ErrInvalidInput) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		respondError(w, http.StatusInternalServerError, "failed to save game")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, game)
+}
+
+// isGameError reports whether err is a wager rejected by the game registry
+func isGameError(err error) bool {
+	return errors.Is(err, service.ErrInvalidGame) ||
+		errors.Is(err, service.ErrGameDisabled) ||
+		errors.Is(err, service.ErrCurrencyNotAllowed) ||
+		errors.Is(err, service.ErrStakeOutOfRange)
+}
@@ -3,10 +3,15 @@ package handlers
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"wallet-ledger/auth"
+	"wallet-ledger/events"
 	"wallet-ledger/models"
+	"wallet-ledger/providers"
 	"wallet-ledger/service"
 
 	"github.com/go-chi/chi/v5"
@@ -18,14 +23,20 @@ const (
 )
 
 type Handler struct {
-	service *service.WalletService
-	repo    interface{ Ping() error }
+	service   *service.WalletService
+	repo      interface{ Ping() error }
+	events    *events.Broker
+	providers *providers.Registry
+	auth      *auth.Authenticator
 }
 
-func New(service *service.WalletService, repo interface{ Ping() error }) *Handler {
+func New(service *service.WalletService, repo interface{ Ping() error }, broker *events.Broker, registry *providers.Registry, authenticator *auth.Authenticator) *Handler {
 	return &Handler{
-		service: service,
-		repo:    repo,
+		service:   service,
+		repo:      repo,
+		events:    broker,
+		providers: registry,
+		auth:      authenticator,
 	}
 }
 
@@ -37,22 +48,115 @@ type ErrorResponse struct {
 // PurchaseRequest represents a purchase request
 type PurchaseRequest struct {
 	PackageCode    string `json:"package_code"`
+	PromoCode      string `json:"promo_code,omitempty"`
 	IdempotencyKey string `json:"idempotency_key"`
 }
 
+// WagerLegRequest is the stake and payout of a wager in one currency, as
+// decimal amounts
+type WagerLegRequest struct {
+	Currency models.Currency `json:"currency"`
+	Stake    models.Decimal  `json:"stake,omitempty"`
+	Payout   models.Decimal  `json:"payout,omitempty"`
+}
+
+// WagerAmounts are the stakes and payouts of a wager as decimal amounts: a leg
+// per currency, or the GC and SC fields wagers were placed with before legs
+type WagerAmounts struct {
+	Legs     []WagerLegRequest `json:"legs,omitempty"`
+	StakeGC  models.Decimal    `json:"stake_gc,omitempty"`
+	PayoutGC models.Decimal    `json:"payout_gc,omitempty"`
+	StakeSC  models.Decimal    `json:"stake_sc,omitempty"`
+	PayoutSC models.Decimal    `json:"payout_sc,omitempty"`
+}
+
 // WagerRequest represents a wager request
 type WagerRequest struct {
-	StakeGC        int64  `json:"stake_gc,omitempty"`
-	PayoutGC       int64  `json:"payout_gc,omitempty"`
-	StakeSC        int64  `json:"stake_sc,omitempty"`
-	PayoutSC       int64  `json:"payout_sc,omitempty"`
+	GameID       string `json:"game_id"`
+	RoundID      string `json:"round_id,omitempty"`
+	TournamentID string `json:"tournament_id,omitempty"`
+	WagerAmounts
+	IdempotencyKey string `json:"idempotency_key"`
+}
+
+// WagerBatchItemRequest represents a single wager within a batch
+type WagerBatchItemRequest struct {
+	UserID       int    `json:"user_id"`
+	GameID       string `json:"game_id"`
+	RoundID      string `json:"round_id,omitempty"`
+	TournamentID string `json:"tournament_id,omitempty"`
+	WagerAmounts
 	IdempotencyKey string `json:"idempotency_key"`
 }
 
+// WagerBatchRequest represents a batch of wagers, possibly for many users
+type WagerBatchRequest struct {
+	Wagers []WagerBatchItemRequest `json:"wagers"`
+}
+
 // RedeemRequest represents a redeem request
 type RedeemRequest struct {
-	AmountSC       int64  `json:"amount_sc"`
-	IdempotencyKey string `json:"idempotency_key"`
+	Currency       models.Currency `json:"currency,omitempty"` // SC when empty
+	Amount         models.Decimal  `json:"amount,omitempty"`
+	AmountSC       models.Decimal  `json:"amount_sc,omitempty"` // deprecated: amount in SC
+	IdempotencyKey string          `json:"idempotency_key"`
+}
+
+// wagerLegs converts a wager's decimal amounts to legs in minor units
+func (h *Handler) wagerLegs(a WagerAmounts) ([]models.WagerLeg, error) {
+	if len(a.Legs) == 0 {
+		stakeGC, err := h.service.ParseAmount(models.CurrencyGC, a.StakeGC)
+		if err != nil {
+			return nil, err
+		}
+		payoutGC, err := h.service.ParseAmount(models.CurrencyGC, a.PayoutGC)
+		if err != nil {
+			return nil, err
+		}
+		stakeSC, err := h.service.ParseAmount(models.CurrencySC, a.StakeSC)
+		if err != nil {
+			return nil, err
+		}
+		payoutSC, err := h.service.ParseAmount(models.CurrencySC, a.PayoutSC)
+		if err != nil {
+			return nil, err
+		}
+		return models.CoinWagerLegs(stakeGC, payoutGC, stakeSC, payoutSC), nil
+	}
+
+	if a.StakeGC != "" || a.PayoutGC != "" || a.StakeSC != "" || a.PayoutSC != "" {
+		return nil, errors.New("set either legs or the stake and payout fields, not both")
+	}
+	legs := make([]models.WagerLeg, len(a.Legs))
+	for i, l := range a.Legs {
+		currency := models.Currency(strings.ToUpper(string(l.Currency)))
+		stake, err := h.service.ParseAmount(currency, l.Stake)
+		if err != nil {
+			return nil, fmt.Errorf("legs[%d]: %w", i, err)
+		}
+		payout, err := h.service.ParseAmount(currency, l.Payout)
+		if err != nil {
+			return nil, fmt.Errorf("legs[%d]: %w", i, err)
+		}
+		legs[i] = models.WagerLeg{Currency: currency, Stake: stake, Payout: payout}
+	}
+	return legs, nil
+}
+
+// redeemAmount returns the currency and decimal amount of a redemption,
+// accepting the deprecated amount_sc field for Sweeps Coins
+func redeemAmount(req RedeemRequest) (models.Currency, models.Decimal, error) {
+	currency := models.Currency(strings.ToUpper(string(req.Currency)))
+	if currency == "" {
+		currency = models.CurrencySC
+	}
+	if req.AmountSC == "" {
+		return currency, req.Amount, nil
+	}
+	if req.Amount != "" || currency != models.CurrencySC {
+		return "", "", errors.New("amount_sc only redeems SC; set currency and amount instead")
+	}
+	return currency, req.AmountSC, nil
 }
 
 // GetUser handles GET /users/:id
@@ -110,12 +214,7 @@ func (h *Handler) ListTransactions(w http.ResponseWriter, r *http.Request) {
 	if typeStr := r.URL.Query().Get("type"); typeStr != "" {
 		t := models.TransactionType(typeStr)
 		// Validate transaction type
-		if t != models.TransactionTypePurchase &&
-			t != models.TransactionTypeWagerGC &&
-			t != models.TransactionTypeWinGC &&
-			t != models.TransactionTypeWagerSC &&
-			t != models.TransactionTypeWinSC &&
-			t != models.TransactionTypeRedeemSC {
+		if !t.IsValid() {
 			respondError(w, http.StatusBadRequest, "invalid transaction type")
 			return
 		}
@@ -126,8 +225,8 @@ func (h *Handler) ListTransactions(w http.ResponseWriter, r *http.Request) {
 	if currencyStr := r.URL.Query().Get("currency"); currencyStr != "" {
 		c := models.Currency(currencyStr)
 		// Validate currency
-		if c != models.CurrencyGC && c != models.CurrencySC {
-			respondError(w, http.StatusBadRequest, "invalid currency: must be GC or SC")
+		if _, err := h.service.GetCurrency(c); err != nil {
+			respondError(w, http.StatusBadRequest, "invalid currency: not registered")
 			return
 		}
 		currency = &c
@@ -167,12 +266,19 @@ func (h *Handler) Purchase(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	transactions, err := h.service.Purchase(userID, req.PackageCode, req.IdempotencyKey)
+	transactions, err := h.service.Purchase(userID, req.PackageCode, req.PromoCode, req.IdempotencyKey)
 	if err != nil {
 		log.Printf("Error processing purchase: %v", err)
 
+		if errors.Is(err, service.ErrSelfExcluded) {
+			respondError(w, http.StatusForbidden, err.Error())
+			return
+		}
+
 		// Check if it's a business logic error
-		if errors.Is(err, service.ErrInvalidPackage) {
+		if errors.Is(err, service.ErrInvalidPackage) || errors.Is(err, service.ErrOfferNotEligible) ||
+			errors.Is(err, service.ErrSpendLimitExceeded) || errors.Is(err, service.ErrCurrencyNotAllowed) ||
+			isPromoCodeError(err) {
 			respondError(w, http.StatusBadRequest, err.Error())
 			return
 		}
@@ -198,17 +304,48 @@ func (h *Handler) Wager(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if req.GameID == "" {
+		respondError(w, http.StatusBadRequest, "game_id is required")
+		return
+	}
+
 	if req.IdempotencyKey == "" {
 		respondError(w, http.StatusBadRequest, "idempotency_key is required")
 		return
 	}
 
-	transactions, err := h.service.Wager(userID, req.StakeGC, req.PayoutGC, req.StakeSC, req.PayoutSC, req.IdempotencyKey)
+	legs, err := h.wagerLegs(req.WagerAmounts)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Tag the wager with its tournament so it is scored there
+	var metadata map[string]interface{}
+	if req.TournamentID != "" {
+		metadata = map[string]interface{}{service.TournamentMetadataKey: req.TournamentID}
+	}
+
+	transactions, err := h.service.WagerWithMetadata(userID, req.GameID, req.RoundID, legs, req.IdempotencyKey, metadata)
 	if err != nil {
 		log.Printf("Error processing wager: %v", err)
 
-		// Check if it's a business logic error (insufficient funds, invalid input)
-		if errors.Is(err, service.ErrInsufficientFunds) || errors.Is(err, service.ErrInvalidInput) {
+		if respondWagerLimitError(w, err) {
+			return
+		}
+
+		if errors.Is(err, service.ErrSelfExcluded) {
+			respondError(w, http.StatusForbidden, err.Error())
+			return
+		}
+		if errors.Is(err, service.ErrRealityCheckDue) {
+			respondError(w, http.StatusPreconditionRequired, err.Error())
+			return
+		}
+
+		// Check if it's a business logic error (insufficient funds, invalid input, game or tournament rules)
+		if errors.Is(err, service.ErrInsufficientFunds) || errors.Is(err, service.ErrInvalidInput) || isGameError(err) || isTournamentError(err) ||
+			errors.Is(err, service.ErrSessionLossCapReached) {
 			respondError(w, http.StatusBadRequest, err.Error())
 			return
 		}
@@ -220,6 +357,53 @@ func (h *Handler) Wager(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, transactions)
 }
 
+// WagerBatch handles POST /wagers/batch
+func (h *Handler) WagerBatch(w http.ResponseWriter, r *http.Request) {
+	var req WagerBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	items := make([]models.WagerBatchItem, len(req.Wagers))
+	for i, wager := range req.Wagers {
+		legs, err := h.wagerLegs(wager.WagerAmounts)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("wagers[%d]: %v", i, err))
+			return
+		}
+		items[i] = models.WagerBatchItem{
+			UserID:         wager.UserID,
+			GameID:         wager.GameID,
+			RoundID:        wager.RoundID,
+			TournamentID:   wager.TournamentID,
+			Legs:           legs,
+			IdempotencyKey: wager.IdempotencyKey,
+		}
+	}
+
+	result, err := h.service.WagerBatch(items)
+	if err != nil {
+		log.Printf("Error processing wager batch: %v", err)
+
+		if errors.Is(err, service.ErrInvalidInput) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		respondError(w, http.StatusInternalServerError, "failed to process wager batch")
+		return
+	}
+
+	// 207 signals that the per-item results must be inspected
+	status := http.StatusOK
+	if result.Summary.Failed > 0 {
+		status = http.StatusMultiStatus
+	}
+
+	respondJSON(w, status, result)
+}
+
 // Redeem handles POST /users/:id/redeem
 func (h *Handler) Redeem(w http.ResponseWriter, r *http.Request) {
 	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
@@ -234,8 +418,18 @@ func (h *Handler) Redeem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.AmountSC <= 0 {
-		respondError(w, http.StatusBadRequest, "amount_sc must be positive")
+	currency, decimal, err := redeemAmount(req)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	amount, err := h.service.ParseAmount(currency, decimal)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if amount <= 0 {
+		respondError(w, http.StatusBadRequest, "amount must be positive")
 		return
 	}
 
@@ -244,12 +438,20 @@ func (h *Handler) Redeem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	transaction, err := h.service.Redeem(userID, req.AmountSC, req.IdempotencyKey)
+	transaction, err := h.service.Redeem(userID, currency, amount, req.IdempotencyKey)
 	if err != nil {
+		// Held for approval: 202 with the pending request instead of a transaction
+		var approvalErr *service.ApprovalRequiredError
+		if errors.As(err, &approvalErr) {
+			respondJSON(w, http.StatusAccepted, approvalErr.Request)
+			return
+		}
+
 		log.Printf("Error processing redemption: %v", err)
 
 		// Check if it's a business logic error (insufficient funds, invalid input)
-		if errors.Is(err, service.ErrInsufficientFunds) || errors.Is(err, service.ErrInvalidInput) {
+		if errors.Is(err, service.ErrInsufficientFunds) || errors.Is(err, service.ErrInvalidInput) ||
+			errors.Is(err, service.ErrCurrencyNotAllowed) {
 			respondError(w, http.StatusBadRequest, err.Error())
 			return
 		}
@@ -272,6 +474,15 @@ func respondError(w http.ResponseWriter, status int, message string) {
 	respondJSON(w, status, ErrorResponse{Error: message})
 }
 
+// invalidBody describes a request body that could not be decoded, naming the
+// field when it held a malformed or too precise amount
+func invalidBody(err error) string {
+	if errors.Is(err, models.ErrInvalidAmount) {
+		return err.Error()
+	}
+	return "invalid request body"
+}
+
 // HealthCheck handles GET /health
 func (h *Handler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	// Check database connectivity
@@ -289,6 +500,10 @@ func (h *Handler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) ListPackages(w http.ResponseWriter, r *http.Request) {
 	packages := make([]models.Package, 0, len(models.Packages))
 	for _, pkg := range models.Packages {
+		// Offers are only listed per user, for users eligible for them
+		if pkg.Offer != "" {
+			continue
+		}
 		packages = append(packages, pkg)
 	}
 	respondJSON(w, http.StatusOK, packages)
@@ -305,16 +520,132 @@ func (h *Handler) SetupRoutes() http.Handler {
 	// Health check
 	r.Get("/health", h.HealthCheck)
 
-	// List available packages
-	r.Get("/packages", h.ListPackages)
+	// Route authorization: catalog reads need any credentials, everything
+	// else a scope. Provider callbacks authenticate with their mandatory
+	// signature instead, checked by the provider's protocol before anything runs.
+	authenticated := h.authenticate
+	read := h.require(auth.ScopeWalletRead)
+	purchase := h.require(auth.ScopeWalletPurchase)
+	wager := h.require(auth.ScopeWalletWager)
+	redeem := h.require(auth.ScopeWalletRedeem)
+	account := h.require(auth.ScopeWalletAccount)
+	adjust := h.require(auth.ScopeAdminAdjust)
+	approve := h.require(auth.ScopeAdminApprove)
+	config := h.require(auth.ScopeAdminConfig)
 
-	// User routes
+	// List available packages
+	r.With(authenticated).Get("/packages", h.ListPackages)
+
+	// Game registry
+	r.With(authenticated).Get("/currencies", h.ListCurrencies)
+	r.With(authenticated).Get("/currencies/{code}", h.GetCurrency)
+	r.With(config).Put("/currencies/{code}", h.SaveCurrency)
+	r.With(authenticated).Get("/games", h.ListGames)
+	r.With(authenticated).Get("/games/{gameID}", h.GetGame)
+	r.With(config).Put("/games/{gameID}", h.SaveGame)
+
+	// Progressive jackpots
+	r.With(authenticated).Get("/jackpots", h.ListJackpots)
+	r.With(authenticated).Get("/jackpots/{poolID}", h.GetJackpot)
+	r.With(config).Put("/jackpots/{poolID}", h.SaveJackpot)
+	r.With(config).Get("/jackpots/{poolID}/contributions", h.ListJackpotContributions)
+	r.With(wager).Post("/jackpots/{poolID}/win", h.JackpotWin)
+
+	// Tournaments
+	r.With(authenticated).Get("/tournaments", h.ListTournaments)
+	r.With(authenticated).Get("/tournaments/{tournamentID}", h.GetTournament)
+	r.With(config).Put("/tournaments/{tournamentID}", h.SaveTournament)
+	r.With(wager).Post("/tournaments/{tournamentID}/entries", h.JoinTournament)
+	r.With(authenticated).Get("/tournaments/{tournamentID}/leaderboard", h.GetLeaderboard)
+	r.With(config).Post("/tournaments/{tournamentID}/settle", h.SettleTournament)
+
+	// Promotional bonus campaigns
+	r.With(config).Get("/campaigns", h.ListCampaigns)
+	r.With(config).Get("/campaigns/{campaignID}", h.GetCampaign)
+	r.With(config).Put("/campaigns/{campaignID}", h.SaveCampaign)
+	r.With(config).Get("/campaigns/{campaignID}/report", h.GetCampaignReport)
+
+	// Daily login bonus streak schedule
+	r.With(authenticated).Get("/daily-bonus/schedule", h.GetDailyBonusSchedule)
+	r.With(config).Put("/daily-bonus/schedule", h.SaveDailyBonusSchedule)
+
+	// Operator-wide purchase spend caps
+	r.With(config).Get("/spend-limits/defaults", h.GetSpendLimitDefaults)
+	r.With(config).Put("/spend-limits/defaults", h.SaveSpendLimitDefaults)
+
+	// Purchase promo codes
+	r.With(config).Get("/promo-codes", h.ListPromoCodes)
+	r.With(config).Get("/promo-codes/{code}", h.GetPromoCode)
+	r.With(config).Put("/promo-codes/{code}", h.SavePromoCode)
+
+	// Alternative Method of Entry review queue
+	r.With(config).Get("/amoe/settings", h.GetAMOESettings)
+	r.With(config).Put("/amoe/settings", h.SaveAMOESettings)
+	r.With(config).Post("/amoe/entries", h.SubmitAMOEEntry)
+	r.With(config).Get("/amoe/entries", h.ListAMOEEntries)
+	r.With(config).Get("/amoe/entries/{entryID}", h.GetAMOEEntry)
+	r.With(config).Post("/amoe/entries/{entryID}/approve", h.ApproveAMOEEntry)
+	r.With(config).Post("/amoe/entries/{entryID}/reject", h.RejectAMOEEntry)
+
+	// Inactive account SC expiry
+	r.With(config).Get("/sc-expiry/settings", h.GetSCExpirySettings)
+	r.With(config).Put("/sc-expiry/settings", h.SaveSCExpirySettings)
+	r.With(config).Post("/sc-expiry/runs", h.RunSCExpiry)
+	r.With(config).Get("/sc-expiry/runs", h.ListSCExpiryRuns)
+	r.With(config).Get("/sc-expiry/runs/{runID}", h.GetSCExpiryRun)
+
+	// Batch wager settlement for game providers
+	r.With(wager).Post("/wagers/batch", h.WagerBatch)
+
+	// All-or-nothing postings for many users (payouts, rewards, corrections)
+	r.With(adjust).Post("/postings/batch", h.PostBatch)
+
+	// Game provider seamless-wallet callbacks; unsigned requests get 401
+	r.Post("/providers/{provider}/callback", h.ProviderCallback)
+
+	// Manual balance adjustments by support staff
+	r.With(adjust).Get("/admin/adjustments", h.ListAdjustments)
+	r.With(adjust).Get("/admin/users/{id}/adjustments", h.ListAdjustments)
+	r.With(adjust).Post("/admin/users/{id}/adjustments", h.AdjustBalance)
+
+	// Maker-checker approval of large adjustments and redemptions
+	r.With(config).Get("/admin/approval-thresholds", h.ListApprovalThresholds)
+	r.With(config).Put("/admin/approval-thresholds/{operation}/{currency}", h.SaveApprovalThreshold)
+	r.With(approve).Get("/admin/approvals", h.ListApprovalRequests)
+	r.With(approve).Get("/admin/approvals/{requestID}", h.GetApprovalRequest)
+	r.With(approve).Post("/admin/approvals/{requestID}/approve", h.ApproveRequest)
+	r.With(approve).Post("/admin/approvals/{requestID}/reject", h.RejectRequest)
+
+	// User routes; a player token only reaches its own user
 	r.Route("/users/{id}", func(r chi.Router) {
-		r.Get("/", h.GetUser)
-		r.Get("/transactions", h.ListTransactions)
-		r.Post("/purchase", h.Purchase)
-		r.Post("/wager", h.Wager)
-		r.Post("/redeem", h.Redeem)
+		r.With(read).Get("/", h.GetUser)
+		r.With(read).Get("/transactions", h.ListTransactions)
+		r.With(read).Get("/ledger/verify", h.VerifyLedger)
+		r.With(purchase).Post("/purchase", h.Purchase)
+		r.With(wager).Post("/wager", h.Wager)
+		r.With(redeem).Post("/redeem", h.Redeem)
+		r.With(adjust).Post("/postings", h.Post)
+		r.With(adjust).Post("/bonus", h.GrantBonus)
+		r.With(account).Post("/daily-bonus", h.ClaimDailyBonus)
+		r.With(account).Put("/time-zone", h.SetTimeZone)
+		r.With(account).Post("/amoe-codes", h.RequestAMOECode)
+		r.With(read).Get("/packages", h.ListUserPackages)
+		r.With(config).Put("/vip-tier", h.SetVIPTier)
+		r.With(read).Get("/spend-limits", h.GetSpendLimits)
+		r.With(read).Get("/spend-limits/history", h.ListSpendLimitHistory)
+		r.With(account).Put("/spend-limits/{period}", h.SetSpendLimit)
+		r.With(read).Get("/wager-limits", h.GetWagerLimits)
+		r.With(read).Get("/wager-limits/history", h.ListWagerLimitHistory)
+		r.With(account).Put("/wager-limits/{currency}/{kind}", h.SetWagerLimit)
+		r.With(read).Get("/self-exclusion", h.GetSelfExclusion)
+		r.With(account).Post("/self-exclusion", h.SelfExclude)
+		r.With(read).Get("/session", h.GetGameSession)
+		r.With(account).Post("/session/reality-check", h.AcknowledgeRealityCheck)
+		r.With(read).Get("/sessions", h.ListGameSessions)
+		r.With(read).Get("/session-settings", h.GetGameSessionSettings)
+		r.With(account).Put("/session-settings", h.SaveGameSessionSettings)
+		r.With(tokenFromQuery, read).Get("/events", h.Events)
+		r.With(tokenFromQuery, read).Get("/ws", h.EventsWebSocket)
 	})
 
 	return r
@@ -325,7 +656,7 @@ func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 
 		// Handle preflight requests
 		if r.Method == "OPTIONS" {
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"wallet-ledger/models"
+	"wallet-ledger/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// JackpotWinRequest represents a jackpot payout to a player
+type JackpotWinRequest struct {
+	UserID         int    `json:"user_id"`
+	IdempotencyKey string `json:"idempotency_key"`
+}
+
+// ListJackpots handles GET /jackpots
+func (h *Handler) ListJackpots(w http.ResponseWriter, r *http.Request) {
+	pools, err := h.service.ListJackpotPools()
+	if err != nil {
+		log.Printf("Error listing jackpot pools: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list jackpot pools")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, pools)
+}
+
+// GetJackpot handles GET /jackpots/:poolID
+func (h *Handler) GetJackpot(w http.ResponseWriter, r *http.Request) {
+	pool, err := h.service.GetJackpotPool(chi.URLParam(r, "poolID"))
+	if err != nil {
+		if errors.Is(err, service.ErrJackpotNotFound) {
+			respondError(w, http.StatusNotFound, "jackpot pool not found")
+			return
+		}
+		log.Printf("Error getting jackpot pool: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get jackpot pool")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, pool)
+}
+
+// SaveJackpot handles PUT /jackpots/:poolID
+func (h *Handler) SaveJackpot(w http.ResponseWriter, r *http.Request) {
+	var pool models.JackpotPool
+	if err := json.NewDecoder(r.Body).Decode(&pool); err != nil {
+		respondError(w, http.StatusBadRequest, invalidBody(err))
+		return
+	}
+	pool.ID = chi.URLParam(r, "poolID")
+
+	if err := h.service.SaveJackpotPool(&pool); err != nil {
+		log.Printf("Error saving jackpot pool: %v", err)
+
+		if errors.Is(err, service.ErrInvalidInput) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		respondError(w, http.StatusInternalServerError, "failed to save jackpot pool")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, pool)
+}
+
+// ListJackpotContributions handles GET /jackpots/:poolID/contributions
+func (h *Handler) ListJackpotContributions(w http.ResponseWriter, r *http.Request) {
+	cursor := r.URL.Query().Get("cursor")
+	var cursorPtr *string
+	if cursor != "" {
+		cursorPtr = &cursor
+	}
+
+	limit := DefaultPageLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit <= 0 || parsedLimit > MaxPageLimit {
+			respondError(w, http.StatusBadRequest, "invalid limit: must be between 1 and 100")
+			return
+		}
+		limit = parsedLimit
+	}
+
+	contributions, err := h.service.ListJackpotContributions(chi.URLParam(r, "poolID"), cursorPtr, limit)
+	if err != nil {
+		if errors.Is(err, service.ErrJackpotNotFound) {
+			respondError(w, http.StatusNotFound, "jackpot pool not found")
+			return
+		}
+		log.Printf("Error listing jackpot contributions: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list jackpot contributions")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, contributions)
+}
+
+// JackpotWin handles POST /jackpots/:poolID/win
+func (h *Handler) JackpotWin(w http.ResponseWriter, r *http.Request) {
+	var req JackpotWinRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.UserID <= 0 {
+		respondError(w, http.StatusBadRequest, "user_id is required")
+		return
+	}
+
+	if req.IdempotencyKey == "" {
+		respondError(w, http.StatusBadRequest, "idempotency_key is required")
+		return
+	}
+
+	transaction, err := h.service.JackpotWin(chi.URLParam(r, "poolID"), req.UserID, req.IdempotencyKey)
+	if err != nil {
+		log.Printf("Error processing jackpot win: %v", err)
+
+		switch {
+		case errors.Is(err, service.ErrJackpotNotFound):
+			respondError(w, http.StatusNotFound, "jackpot pool not found")
+		case errors.Is(err, service.ErrUserNotFound):
+			respondError(w, http.StatusNotFound, "user not found")
+		case errors.Is(err, service.ErrJackpotEmpty), errors.Is(err, service.ErrJackpotDisabled), errors.Is(err, service.ErrInvalidInput):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to process jackpot win")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, transaction)
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"wallet-ledger/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// VerifyLedger handles GET /users/:id/ledger/verify
+func (h *Handler) VerifyLedger(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	verification, err := h.service.VerifyLedger(userID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			respondError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		log.Printf("Error verifying ledger: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to verify ledger")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, verification)
+}
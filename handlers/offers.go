@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"wallet-ledger/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// VIPTierRequest represents a change of a user's VIP tier
+type VIPTierRequest struct {
+	VIPTier int `json:"vip_tier"`
+}
+
+// ListUserPackages handles GET /users/:id/packages
+func (h *Handler) ListUserPackages(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	packages, err := h.service.ListUserPackages(userID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			respondError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		log.Printf("Error listing user packages: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list packages")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, packages)
+}
+
+// SetVIPTier handles PUT /users/:id/vip-tier
+func (h *Handler) SetVIPTier(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	var req VIPTierRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.service.SetUserVIPTier(userID, req.VIPTier); err != nil {
+		log.Printf("Error setting VIP tier: %v", err)
+
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			respondError(w, http.StatusNotFound, "user not found")
+		case errors.Is(err, service.ErrInvalidInput):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to set vip tier")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, req)
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"wallet-ledger/models"
+	"wallet-ledger/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// PostingLegRequest is one leg of a posting, with a decimal amount
+type PostingLegRequest struct {
+	Currency models.Currency        `json:"currency"`
+	Type     models.TransactionType `json:"type"`
+	Amount   models.Decimal         `json:"amount"`
+	Metadata json.RawMessage        `json:"metadata,omitempty"`
+}
+
+// PostingRequest represents a multi-leg posting
+type PostingRequest struct {
+	Legs           []PostingLegRequest `json:"legs"`
+	IdempotencyKey string              `json:"idempotency_key"`
+}
+
+// BatchPostingLegRequest is a posting leg for one user of a batch
+type BatchPostingLegRequest struct {
+	UserID int `json:"user_id"`
+	PostingLegRequest
+}
+
+// BatchPostingRequest represents an all-or-nothing posting for many users
+type BatchPostingRequest struct {
+	Legs     []BatchPostingLegRequest `json:"legs"`
+	BatchKey string                   `json:"batch_key"`
+}
+
+// postingLeg converts a leg's decimal amount to its currency's minor units. A
+// leading minus is kept for signed types such as correction, where it debits.
+func (h *Handler) postingLeg(l PostingLegRequest) (models.PostingLeg, error) {
+	currency := models.Currency(strings.ToUpper(string(l.Currency)))
+	value, negative := strings.CutPrefix(string(l.Amount), "-")
+	amount, err := h.service.ParseAmount(currency, models.Decimal(value))
+	if err != nil {
+		return models.PostingLeg{}, err
+	}
+	if negative {
+		amount = -amount
+	}
+	return models.PostingLeg{Currency: currency, Type: l.Type, Amount: amount, Metadata: l.Metadata}, nil
+}
+
+// Post handles POST /users/:id/postings
+func (h *Handler) Post(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	var req PostingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.IdempotencyKey == "" {
+		respondError(w, http.StatusBadRequest, "idempotency_key is required")
+		return
+	}
+
+	legs := make([]models.PostingLeg, len(req.Legs))
+	for i, l := range req.Legs {
+		legs[i], err = h.postingLeg(l)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("leg %d: %v", i, err))
+			return
+		}
+	}
+
+	transactions, err := h.service.Post(userID, legs, req.IdempotencyKey)
+	if err != nil {
+		log.Printf("Error processing posting: %v", err)
+
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			respondError(w, http.StatusNotFound, "user not found")
+		case errors.Is(err, service.ErrInsufficientFunds), errors.Is(err, service.ErrInvalidInput):
+			respondError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrPostingOverThreshold):
+			respondError(w, http.StatusForbidden, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to process posting")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, transactions)
+}
+
+// PostBatch handles POST /postings/batch
+func (h *Handler) PostBatch(w http.ResponseWriter, r *http.Request) {
+	var req BatchPostingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.BatchKey == "" {
+		respondError(w, http.StatusBadRequest, "batch_key is required")
+		return
+	}
+
+	legs := make([]models.BatchPostingLeg, len(req.Legs))
+	for i, l := range req.Legs {
+		leg, err := h.postingLeg(l.PostingLegRequest)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("leg %d: %v", i, err))
+			return
+		}
+		legs[i] = models.BatchPostingLeg{UserID: l.UserID, PostingLeg: leg}
+	}
+
+	transactions, err := h.service.PostBatch(legs, req.BatchKey)
+	if err != nil {
+		log.Printf("Error processing batch posting: %v", err)
+
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			respondError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, service.ErrInsufficientFunds), errors.Is(err, service.ErrInvalidInput):
+			respondError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrPostingOverThreshold):
+			respondError(w, http.StatusForbidden, err.Error())
+		case errors.Is(err, service.ErrBatchKeyReused):
+			respondError(w, http.StatusConflict, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to process batch posting")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, transactions)
+}
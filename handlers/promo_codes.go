@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"wallet-ledger/models"
+	"wallet-ledger/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ListPromoCodes handles GET /promo-codes
+func (h *Handler) ListPromoCodes(w http.ResponseWriter, r *http.Request) {
+	codes, err := h.service.ListPromoCodes()
+	if err != nil {
+		log.Printf("Error listing promo codes: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list promo codes")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, codes)
+}
+
+// GetPromoCode handles GET /promo-codes/:code
+func (h *Handler) GetPromoCode(w http.ResponseWriter, r *http.Request) {
+	promo, err := h.service.GetPromoCode(chi.URLParam(r, "code"))
+	if err != nil {
+		if errors.Is(err, service.ErrPromoCodeNotFound) {
+			respondError(w, http.StatusNotFound, "promo code not found")
+			return
+		}
+		log.Printf("Error getting promo code: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get promo code")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, promo)
+}
+
+// SavePromoCode handles PUT /promo-codes/:code
+func (h *Handler) SavePromoCode(w http.ResponseWriter, r *http.Request) {
+	var promo models.PromoCode
+	if err := json.NewDecoder(r.Body).Decode(&promo); err != nil {
+		respondError(w, http.StatusBadRequest, invalidBody(err))
+		return
+	}
+	promo.Code = chi.URLParam(r, "code")
+
+	if err := h.service.SavePromoCode(&promo); err != nil {
+		log.Printf("Error saving promo code: %v", err)
+
+		if errors.Is(err, service.ErrInvalidInput) || errors.Is(err, service.ErrInvalidPackage) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		respondError(w, http.StatusInternalServerError, "failed to save promo code")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, promo)
+}
+
+// isPromoCodeError reports whether err is a purchase rejected because of its promo code
+func isPromoCodeError(err error) bool {
+	return errors.Is(err, service.ErrPromoCodeInvalid) ||
+		errors.Is(err, service.ErrPromoCodeExpired) ||
+		errors.Is(err, service.ErrPromoCodeExhausted) ||
+		errors.Is(err, service.ErrPromoCodeAlreadyUsed) ||
+		errors.Is(err, service.ErrPromoCodeNotApplicable)
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ProviderCallback handles POST /providers/:provider/callback
+func (h *Handler) ProviderCallback(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "provider")
+
+	protocol, ok := h.providers.Get(name)
+	if !ok {
+		respondError(w, http.StatusNotFound, "unknown provider")
+		return
+	}
+
+	callback, err := protocol.Decode(r)
+	if err != nil {
+		protocol.Encode(w, nil, err)
+		return
+	}
+
+	result, err := h.providers.Handle(name, callback)
+	protocol.Encode(w, result, err)
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+	"wallet-ledger/models"
+	"wallet-ledger/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GetSCExpirySettings handles GET /sc-expiry/settings
+func (h *Handler) GetSCExpirySettings(w http.ResponseWriter, r *http.Request) {
+	settings, err := h.service.GetSCExpirySettings()
+	if err != nil {
+		log.Printf("Error getting SC expiry settings: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get sc expiry settings")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, settings)
+}
+
+// SaveSCExpirySettings handles PUT /sc-expiry/settings
+func (h *Handler) SaveSCExpirySettings(w http.ResponseWriter, r *http.Request) {
+	var settings models.SCExpirySettings
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.service.SaveSCExpirySettings(&settings); err != nil {
+		log.Printf("Error saving SC expiry settings: %v", err)
+
+		if errors.Is(err, service.ErrInvalidInput) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		respondError(w, http.StatusInternalServerError, "failed to save sc expiry settings")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, settings)
+}
+
+// RunSCExpiry handles POST /sc-expiry/runs
+func (h *Handler) RunSCExpiry(w http.ResponseWriter, r *http.Request) {
+	run, err := h.service.RunSCExpiry(time.Now())
+	if err != nil {
+		log.Printf("Error running SC expiry: %v", err)
+		respondError(w, http.StatusInternalServerError, "sc expiry run did not complete")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, run)
+}
+
+// ListSCExpiryRuns handles GET /sc-expiry/runs
+func (h *Handler) ListSCExpiryRuns(w http.ResponseWriter, r *http.Request) {
+	limit := DefaultPageLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit <= 0 || parsedLimit > MaxPageLimit {
+			respondError(w, http.StatusBadRequest, "invalid limit: must be between 1 and 100")
+			return
+		}
+		limit = parsedLimit
+	}
+
+	runs, err := h.service.ListSCExpiryRuns(limit)
+	if err != nil {
+		log.Printf("Error listing SC expiry runs: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list sc expiry runs")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, runs)
+}
+
+// GetSCExpiryRun handles GET /sc-expiry/runs/:runID
+func (h *Handler) GetSCExpiryRun(w http.ResponseWriter, r *http.Request) {
+	runID, err := strconv.Atoi(chi.URLParam(r, "runID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid run id")
+		return
+	}
+
+	run, err := h.service.GetSCExpiryRun(runID)
+	if err != nil {
+		if errors.Is(err, service.ErrSCExpiryRunNotFound) {
+			respondError(w, http.StatusNotFound, "sc expiry run not found")
+			return
+		}
+		log.Printf("Error getting SC expiry run: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get sc expiry run")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, run)
+}
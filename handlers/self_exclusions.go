@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"wallet-ledger/models"
+	"wallet-ledger/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// SelfExclusionRequest represents a player excluding themselves
+type SelfExclusionRequest struct {
+	Period models.SelfExclusionPeriod `json:"period"`
+}
+
+// GetSelfExclusion handles GET /users/:id/self-exclusion
+func (h *Handler) GetSelfExclusion(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	status, err := h.service.GetSelfExclusionStatus(userID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			respondError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		log.Printf("Error getting self-exclusion: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get self-exclusion")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, status)
+}
+
+// SelfExclude handles POST /users/:id/self-exclusion
+func (h *Handler) SelfExclude(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	var req SelfExclusionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	exclusion, err := h.service.SelfExclude(userID, req.Period)
+	if err != nil {
+		log.Printf("Error creating self-exclusion: %v", err)
+
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			respondError(w, http.StatusNotFound, "user not found")
+		case errors.Is(err, service.ErrInvalidInput):
+			respondError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrSelfExclusionActive):
+			respondError(w, http.StatusConflict, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to create self-exclusion")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, exclusion)
+}
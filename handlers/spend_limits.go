@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"wallet-ledger/models"
+	"wallet-ledger/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// SpendLimitRequest represents a change of a player's own spend limit, as a
+// decimal dollar amount
+type SpendLimitRequest struct {
+	Limit *models.Decimal `json:"limit"`
+	// Deprecated: use Limit. Read only when Limit is absent.
+	LimitCents int64 `json:"limit_cents"`
+}
+
+// GetSpendLimits handles GET /users/:id/spend-limits
+func (h *Handler) GetSpendLimits(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	limits, err := h.service.GetSpendLimits(userID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			respondError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		log.Printf("Error getting spend limits: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get spend limits")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, limits)
+}
+
+// SetSpendLimit handles PUT /users/:id/spend-limits/:period
+func (h *Handler) SetSpendLimit(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	var req SpendLimitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	limitCents := req.LimitCents
+	if req.Limit != nil {
+		if limitCents, err = models.ParseAmount(string(*req.Limit), models.USDMinorUnits); err != nil {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("limit: %v", err))
+			return
+		}
+	}
+
+	period := models.SpendLimitPeriod(chi.URLParam(r, "period"))
+	limit, err := h.service.SetSpendLimit(userID, period, limitCents)
+	if err != nil {
+		log.Printf("Error setting spend limit: %v", err)
+
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			respondError(w, http.StatusNotFound, "user not found")
+		case errors.Is(err, service.ErrInvalidInput):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to set spend limit")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, limit)
+}
+
+// ListSpendLimitHistory handles GET /users/:id/spend-limits/history
+func (h *Handler) ListSpendLimitHistory(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	changes, err := h.service.ListSpendLimitHistory(userID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			respondError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		log.Printf("Error listing spend limit history: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list spend limit history")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, changes)
+}
+
+// GetSpendLimitDefaults handles GET /spend-limits/defaults
+func (h *Handler) GetSpendLimitDefaults(w http.ResponseWriter, r *http.Request) {
+	defaults, err := h.service.GetSpendLimitDefaults()
+	if err != nil {
+		log.Printf("Error getting spend limit defaults: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get spend limit defaults")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, defaults)
+}
+
+// SaveSpendLimitDefaults handles PUT /spend-limits/defaults
+func (h *Handler) SaveSpendLimitDefaults(w http.ResponseWriter, r *http.Request) {
+	var defaults models.SpendLimitDefaults
+	if err := json.NewDecoder(r.Body).Decode(&defaults); err != nil {
+		respondError(w, http.StatusBadRequest, invalidBody(err))
+		return
+	}
+
+	if err := h.service.SaveSpendLimitDefaults(&defaults); err != nil {
+		log.Printf("Error saving spend limit defaults: %v", err)
+
+		if errors.Is(err, service.ErrInvalidInput) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		respondError(w, http.StatusInternalServerError, "failed to save spend limit defaults")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, defaults)
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"wallet-ledger/models"
+	"wallet-ledger/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// JoinTournamentRequest represents a user entering a tournament
+type JoinTournamentRequest struct {
+	UserID int `json:"user_id"`
+}
+
+// ListTournaments handles GET /tournaments
+func (h *Handler) ListTournaments(w http.ResponseWriter, r *http.Request) {
+	tournaments, err := h.service.ListTournaments()
+	if err != nil {
+		log.Printf("Error listing tournaments: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list tournaments")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, tournaments)
+}
+
+// GetTournament handles GET /tournaments/:tournamentID
+func (h *Handler) GetTournament(w http.ResponseWriter, r *http.Request) {
+	tournament, err := h.service.GetTournament(chi.URLParam(r, "tournamentID"))
+	if err != nil {
+		if errors.Is(err, service.ErrTournamentNotFound) {
+			respondError(w, http.StatusNotFound, "tournament not found")
+			return
+		}
+		log.Printf("Error getting tournament: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get tournament")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, tournament)
+}
+
+// SaveTournament handles PUT /tournaments/:tournamentID
+func (h *Handler) SaveTournament(w http.ResponseWriter, r *http.Request) {
+	var tournament models.Tournament
+	if err := json.NewDecoder(r.Body).Decode(&tournament); err != nil {
+		respondError(w, http.StatusBadRequest, invalidBody(err))
+		return
+	}
+	tournament.ID = chi.URLParam(r, "tournamentID")
+	tournament.SettledAt = nil
+
+	if err := h.service.SaveTournament(&tournament); err != nil {
+		log.Printf("Error saving tournament: %v", err)
+
+		if errors.Is(err, service.ErrInvalidInput) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		respondError(w, http.StatusInternalServerError, "failed to save tournament")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, tournament)
+}
+
+// JoinTournament handles POST /tournaments/:tournamentID/entries
+func (h *Handler) JoinTournament(w http.ResponseWriter, r *http.Request) {
+	var req JoinTournamentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.UserID <= 0 {
+		respondError(w, http.StatusBadRequest, "user_id is required")
+		return
+	}
+
+	entry, err := h.service.JoinTournament(chi.URLParam(r, "tournamentID"), req.UserID)
+	if err != nil {
+		log.Printf("Error joining tournament: %v", err)
+
+		if respondWagerLimitError(w, err) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, service.ErrTournamentNotFound):
+			respondError(w, http.StatusNotFound, "tournament not found")
+		case errors.Is(err, service.ErrUserNotFound):
+			respondError(w, http.StatusNotFound, "user not found")
+		case errors.Is(err, service.ErrSelfExcluded):
+			respondError(w, http.StatusForbidden, err.Error())
+		case errors.Is(err, service.ErrInsufficientFunds), errors.Is(err, service.ErrTournamentClosed):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to join tournament")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, entry)
+}
+
+// GetLeaderboard handles GET /tournaments/:tournamentID/leaderboard
+func (h *Handler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	limit := DefaultPageLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit <= 0 || parsedLimit > MaxPageLimit {
+			respondError(w, http.StatusBadRequest, "invalid limit: must be between 1 and 100")
+			return
+		}
+		limit = parsedLimit
+	}
+
+	leaderboard, err := h.service.GetLeaderboard(chi.URLParam(r, "tournamentID"), limit)
+	if err != nil {
+		if errors.Is(err, service.ErrTournamentNotFound) {
+			respondError(w, http.StatusNotFound, "tournament not found")
+			return
+		}
+		log.Printf("Error getting leaderboard: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get leaderboard")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, leaderboard)
+}
+
+// SettleTournament handles POST /tournaments/:tournamentID/settle
+func (h *Handler) SettleTournament(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.SettleTournament(chi.URLParam(r, "tournamentID"))
+	if err != nil {
+		log.Printf("Error settling tournament: %v", err)
+
+		switch {
+		case errors.Is(err, service.ErrTournamentNotFound):
+			respondError(w, http.StatusNotFound, "tournament not found")
+		case errors.Is(err, service.ErrTournamentNotEnded):
+			respondError(w, http.StatusConflict, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to settle tournament")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// isTournamentError reports whether err is a wager rejected by its tournament
+func isTournamentError(err error) bool {
+	return errors.Is(err, service.ErrTournamentNotFound) ||
+		errors.Is(err, service.ErrTournamentClosed) ||
+		errors.Is(err, service.ErrTournamentNotEntered)
+}
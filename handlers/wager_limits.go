@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"wallet-ledger/models"
+	"wallet-ledger/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// WagerLimitRequest represents a change of a player's own wager limit
+type WagerLimitRequest struct {
+	Limit models.Decimal `json:"limit"`
+}
+
+// WagerLimitErrorResponse is returned when a wager breaks a wager limit
+type WagerLimitErrorResponse struct {
+	Error     string                `json:"error"`
+	Currency  models.Currency       `json:"currency"`
+	Limit     models.WagerLimitKind `json:"limit"`
+	Amount    string                `json:"limit_amount"`
+	Remaining string                `json:"remaining"`
+}
+
+// respondWagerLimitError writes a WagerLimitErrorResponse if err is a wager
+// limit error, reporting the remaining allowance for the client to display
+func respondWagerLimitError(w http.ResponseWriter, err error) bool {
+	var limitErr *service.WagerLimitError
+	if !errors.As(err, &limitErr) {
+		return false
+	}
+
+	scale := limitErr.Currency.MinorUnits()
+	respondJSON(w, http.StatusBadRequest, WagerLimitErrorResponse{
+		Error:     err.Error(),
+		Currency:  limitErr.Currency,
+		Limit:     limitErr.Kind,
+		Amount:    models.FormatAmount(limitErr.Limit, scale),
+		Remaining: models.FormatAmount(limitErr.Remaining, scale),
+	})
+	return true
+}
+
+// GetWagerLimits handles GET /users/:id/wager-limits
+func (h *Handler) GetWagerLimits(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	limits, err := h.service.GetWagerLimits(userID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			respondError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		log.Printf("Error getting wager limits: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get wager limits")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, limits)
+}
+
+// SetWagerLimit handles PUT /users/:id/wager-limits/:currency/:kind
+func (h *Handler) SetWagerLimit(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	var req WagerLimitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	currency := models.Currency(strings.ToUpper(chi.URLParam(r, "currency")))
+	kind := models.WagerLimitKind(chi.URLParam(r, "kind"))
+
+	limitAmount, err := h.service.ParseAmount(currency, req.Limit)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit, err := h.service.SetWagerLimit(userID, currency, kind, limitAmount)
+	if err != nil {
+		log.Printf("Error setting wager limit: %v", err)
+
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			respondError(w, http.StatusNotFound, "user not found")
+		case errors.Is(err, service.ErrInvalidInput):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to set wager limit")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, limit)
+}
+
+// ListWagerLimitHistory handles GET /users/:id/wager-limits/history
+func (h *Handler) ListWagerLimitHistory(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	changes, err := h.service.ListWagerLimitHistory(userID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			respondError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		log.Printf("Error listing wager limit history: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list wager limit history")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, changes)
+}
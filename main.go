@@ -5,12 +5,19 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // user time zones; the runtime image has no zoneinfo
+	"wallet-ledger/auth"
+	"wallet-ledger/events"
+	"wallet-ledger/grpcapi"
 	"wallet-ledger/handlers"
+	"wallet-ledger/models"
+	"wallet-ledger/providers"
 	"wallet-ledger/repository"
 	"wallet-ledger/service"
 
@@ -35,6 +42,29 @@ func main() {
 		port = "8080"
 	}
 
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9090"
+	}
+
+	// API keys (JSON list of name, key and scopes) and the player token secret
+	apiKeys, err := auth.ParseAPIKeys(os.Getenv("API_KEYS"))
+	if err != nil {
+		log.Fatalf("Invalid API_KEYS: %v", err)
+	}
+	authenticator, err := auth.New(auth.Config{
+		APIKeys:   apiKeys,
+		JWTSecret: os.Getenv("JWT_SECRET"),
+		JWTIssuer: os.Getenv("JWT_ISSUER"),
+		Disabled:  os.Getenv("AUTH_DISABLED") == "true",
+	})
+	if err != nil {
+		log.Fatalf("Invalid authentication configuration (set API_KEYS or JWT_SECRET, or AUTH_DISABLED=true for local development): %v", err)
+	}
+	if authenticator.Disabled() {
+		log.Println("WARNING: authentication is disabled; every request has every scope")
+	}
+
 	// Connect to database
 	db, err := sql.Open("postgres", databaseURL)
 	if err != nil {
@@ -57,12 +87,32 @@ func main() {
 	// Initialize layers
 	repo := repository.New(db)
 	svc := service.New(repo)
-	handler := handlers.New(svc, repo)
+	if err := svc.LoadCurrencies(); err != nil {
+		log.Fatalf("Failed to load currency registry: %v", err)
+	}
+	broker := events.NewBroker(databaseURL, repo)
+
+	// Register game provider protocols
+	registry := providers.NewRegistry(svc)
+	generic, err := providers.NewGenericProtocol("generic", os.Getenv("PROVIDER_GENERIC_SECRET"))
+	if err != nil {
+		log.Fatalf("PROVIDER_GENERIC_SECRET environment variable is required: %v", err)
+	}
+	registry.Register(generic)
+
+	handler := handlers.New(svc, repo, broker, registry, authenticator)
 
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Start real-time event listener (Postgres LISTEN/NOTIFY fan-out)
+	go func() {
+		if err := broker.Run(ctx); err != nil {
+			log.Printf("Event listener stopped: %v", err)
+		}
+	}()
+
 	// Start background cleanup goroutine
 	go func() {
 		ticker := time.NewTicker(1 * time.Hour)
@@ -84,6 +134,99 @@ func main() {
 		}
 	}()
 
+	// Start currency registry refresh goroutine, so currencies changed through
+	// another instance are picked up
+	go func() {
+		ticker := time.NewTicker(1 * time.Minute)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := svc.LoadCurrencies(); err != nil {
+					log.Printf("Error reloading currency registry: %v", err)
+				}
+			case <-ctx.Done():
+				log.Println("Stopping currency refresh goroutine...")
+				return
+			}
+		}
+	}()
+
+	// Start SC expiry goroutine. Each UTC day has a single run, so hourly ticks
+	// only do work once a day, or resume a run that did not complete.
+	go func() {
+		ticker := time.NewTicker(1 * time.Hour)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				started := time.Now()
+				run, err := svc.RunSCExpiry(started)
+				if err != nil {
+					log.Printf("Error running SC expiry: %v", err)
+				} else if run.FinishedAt != nil && !run.FinishedAt.Before(started.Add(-time.Minute)) {
+					log.Printf("SC expiry run %d: %d warnings, %d users expired (%s SC)", run.ID, run.WarningsSent, run.UsersExpired,
+						models.FormatAmount(run.TotalExpiredSC, models.CurrencySC.MinorUnits()))
+				}
+			case <-ctx.Done():
+				log.Println("Stopping SC expiry goroutine...")
+				return
+			}
+		}
+	}()
+
+	// Start responsible-gaming maintenance goroutine: expires self-exclusions
+	// and closes idle game sessions
+	go func() {
+		ticker := time.NewTicker(5 * time.Minute)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				expired, err := svc.ExpireSelfExclusions()
+				if err != nil {
+					log.Printf("Error expiring self-exclusions: %v", err)
+				} else if expired > 0 {
+					log.Printf("Expired %d self-exclusions", expired)
+				}
+
+				closed, err := svc.CloseIdleGameSessions()
+				if err != nil {
+					log.Printf("Error closing idle game sessions: %v", err)
+				} else if closed > 0 {
+					log.Printf("Closed %d idle game sessions", closed)
+				}
+			case <-ctx.Done():
+				log.Println("Stopping responsible-gaming maintenance goroutine...")
+				return
+			}
+		}
+	}()
+
+	// Start approval expiry goroutine: closes requests no second admin approved in time
+	go func() {
+		ticker := time.NewTicker(5 * time.Minute)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				expired, err := svc.ExpireApprovalRequests()
+				if err != nil {
+					log.Printf("Error expiring approval requests: %v", err)
+				} else if expired > 0 {
+					log.Printf("Expired %d approval requests", expired)
+				}
+			case <-ctx.Done():
+				log.Println("Stopping approval expiry goroutine...")
+				return
+			}
+		}
+	}()
+
 	// Setup routes
 	router := handler.SetupRoutes()
 
@@ -106,6 +249,19 @@ func main() {
 		}
 	}()
 
+	// Start gRPC server on its own port
+	grpcServer := grpcapi.NewServer(svc, authenticator)
+	grpcListener, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
+	if err != nil {
+		log.Fatalf("Failed to listen on gRPC port: %v", err)
+	}
+	go func() {
+		log.Printf("Starting gRPC server on :%s", grpcPort)
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			log.Fatalf("gRPC server failed to start: %v", err)
+		}
+	}()
+
 	// Wait for interrupt signal
 	<-quit
 	log.Println("Shutting down server...")
@@ -113,6 +269,12 @@ func main() {
 	// Cancel context to stop background goroutines
 	cancel()
 
+	// Close event streams first; Shutdown does not wait for hijacked WebSocket
+	// connections and would otherwise block on open SSE responses until timeout
+	if err := broker.Close(); err != nil {
+		log.Printf("Error closing event listener: %v", err)
+	}
+
 	// Give the server 30 seconds to finish current requests
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer shutdownCancel()
@@ -121,5 +283,18 @@ func main() {
 		log.Printf("Server forced to shutdown: %v", err)
 	}
 
+	// Drain in-flight gRPC calls within the same deadline
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+	select {
+	case <-grpcStopped:
+	case <-shutdownCtx.Done():
+		log.Println("gRPC server forced to shutdown")
+		grpcServer.Stop()
+	}
+
 	log.Println("Server exited")
 }
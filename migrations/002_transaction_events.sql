@@ -0,0 +1,16 @@
+-- Publish committed transactions to listeners so every instance can push
+-- real-time updates to its connected clients. NOTIFY is only delivered once
+-- the inserting transaction commits, so rolled back ledger writes never leak.
+CREATE OR REPLACE FUNCTION notify_transaction_created() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify(
+        'wallet_events',
+        json_build_object('id', NEW.id, 'user_id', NEW.user_id)::text
+    );
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER transactions_notify
+    AFTER INSERT ON transactions
+    FOR EACH ROW EXECUTE FUNCTION notify_transaction_created();
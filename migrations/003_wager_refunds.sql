@@ -0,0 +1,5 @@
+-- Refund transaction types credit back the stake of a rolled back wager
+-- (seamless-wallet provider rollbacks)
+ALTER TABLE transactions DROP CONSTRAINT transactions_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_check
+    CHECK (type IN ('purchase', 'wager_gc', 'win_gc', 'wager_sc', 'win_sc', 'redeem_sc', 'refund_gc', 'refund_sc'));
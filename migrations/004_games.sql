@@ -0,0 +1,36 @@
+-- Game registry: every wager must reference an enabled game
+CREATE TABLE games (
+    id VARCHAR(64) PRIMARY KEY,
+    provider VARCHAR(64) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    rtp NUMERIC(5,2) NOT NULL CHECK (rtp > 0 AND rtp <= 100),
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Currencies a game may be played in, with per-currency stake limits
+-- (max_stake = 0 means no upper limit)
+CREATE TABLE game_currencies (
+    game_id VARCHAR(64) NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    currency VARCHAR(2) NOT NULL CHECK (currency IN ('GC', 'SC')),
+    min_stake BIGINT NOT NULL DEFAULT 0 CHECK (min_stake >= 0),
+    max_stake BIGINT NOT NULL DEFAULT 0 CHECK (max_stake >= 0),
+    PRIMARY KEY (game_id, currency)
+);
+
+-- Sample games for testing
+INSERT INTO games (id, provider, name, rtp) VALUES
+    ('starburst', 'netent', 'Starburst', 96.09),
+    ('sweet_bonanza', 'pragmatic', 'Sweet Bonanza', 96.48),
+    ('blackjack_classic', 'inhouse', 'Classic Blackjack', 99.50),
+    ('demo_slots', 'inhouse', 'Demo Slots', 95.00);
+
+INSERT INTO game_currencies (game_id, currency, min_stake, max_stake) VALUES
+    ('starburst', 'GC', 100, 100000),
+    ('starburst', 'SC', 1, 100),
+    ('sweet_bonanza', 'GC', 200, 200000),
+    ('sweet_bonanza', 'SC', 1, 200),
+    ('blackjack_classic', 'GC', 500, 500000),
+    ('demo_slots', 'GC', 1, 0),
+    ('demo_slots', 'SC', 1, 0);
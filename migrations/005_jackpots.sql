@@ -0,0 +1,52 @@
+-- Jackpot wins are paid to players with their own transaction types
+ALTER TABLE transactions DROP CONSTRAINT transactions_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_check
+    CHECK (type IN ('purchase', 'wager_gc', 'win_gc', 'wager_sc', 'win_sc', 'redeem_sc', 'refund_gc', 'refund_sc',
+                    'jackpot_gc', 'jackpot_sc'));
+
+-- Progressive jackpot pools: every qualifying stake in the pool's currency adds
+-- contribution_bps / 10000 of the stake to amount. A win pays out amount and
+-- resets it to seed_amount.
+CREATE TABLE jackpot_pools (
+    id VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    currency VARCHAR(2) NOT NULL CHECK (currency IN ('GC', 'SC')),
+    contribution_bps INTEGER NOT NULL CHECK (contribution_bps >= 0 AND contribution_bps <= 10000),
+    min_stake BIGINT NOT NULL DEFAULT 0 CHECK (min_stake >= 0),
+    seed_amount BIGINT NOT NULL DEFAULT 0 CHECK (seed_amount >= 0),
+    amount BIGINT NOT NULL DEFAULT 0 CHECK (amount >= 0),
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- One row per stake that funded a pool, written in the wager's DB transaction
+CREATE TABLE jackpot_contributions (
+    id SERIAL PRIMARY KEY,
+    pool_id VARCHAR(64) NOT NULL REFERENCES jackpot_pools(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+    stake BIGINT NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    pool_amount_after BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_jackpot_contributions_pool_created ON jackpot_contributions(pool_id, created_at DESC, id DESC);
+
+-- Audit of paid jackpots
+CREATE TABLE jackpot_wins (
+    id SERIAL PRIMARY KEY,
+    pool_id VARCHAR(64) NOT NULL REFERENCES jackpot_pools(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+    amount BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_jackpot_wins_pool ON jackpot_wins(pool_id, created_at DESC);
+
+-- Sample pools for testing
+INSERT INTO jackpot_pools (id, name, currency, contribution_bps, min_stake, seed_amount, amount) VALUES
+    ('mega_gc', 'Mega Gold Jackpot', 'GC', 50, 200, 1000000, 1000000),
+    ('daily_sc', 'Daily Sweeps Jackpot', 'SC', 200, 50, 500, 500);
@@ -0,0 +1,41 @@
+-- Tournament entry fees and prizes are posted in the tournament's currency
+ALTER TABLE transactions DROP CONSTRAINT transactions_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_check
+    CHECK (type IN ('purchase', 'wager_gc', 'win_gc', 'wager_sc', 'win_sc', 'redeem_sc', 'refund_gc', 'refund_sc',
+                    'jackpot_gc', 'jackpot_sc', 'tournament_entry', 'tournament_prize'));
+
+-- Tournaments rank entrants by wagers tagged with the tournament ID between
+-- starts_at and ends_at. prizes[1] is paid to first place, and so on.
+CREATE TABLE tournaments (
+    id VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    currency VARCHAR(2) NOT NULL CHECK (currency IN ('GC', 'SC')),
+    entry_fee BIGINT NOT NULL DEFAULT 0 CHECK (entry_fee >= 0),
+    scoring VARCHAR(32) NOT NULL CHECK (scoring IN ('total_wagered', 'biggest_multiplier')),
+    prizes BIGINT[] NOT NULL DEFAULT '{}',
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    settled_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (ends_at > starts_at)
+);
+
+CREATE TABLE tournament_entries (
+    tournament_id VARCHAR(64) NOT NULL REFERENCES tournaments(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    score BIGINT NOT NULL DEFAULT 0,
+    entry_transaction_id INTEGER REFERENCES transactions(id),
+    prize BIGINT NOT NULL DEFAULT 0,
+    prize_transaction_id INTEGER REFERENCES transactions(id),
+    joined_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    score_updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tournament_id, user_id)
+);
+
+-- Leaderboard order: higher score first, then whoever reached it first
+CREATE INDEX idx_tournament_entries_leaderboard ON tournament_entries(tournament_id, score DESC, score_updated_at ASC, user_id ASC);
+
+-- Sample tournament for testing
+INSERT INTO tournaments (id, name, currency, entry_fee, scoring, prizes, starts_at, ends_at) VALUES
+    ('weekly_gc', 'Weekly Gold Race', 'GC', 1000, 'total_wagered', '{50000, 25000, 10000}', NOW(), NOW() + INTERVAL '7 days');
@@ -0,0 +1,41 @@
+-- Promotional bonus credits
+ALTER TABLE transactions DROP CONSTRAINT transactions_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_check
+    CHECK (type IN ('purchase', 'wager_gc', 'win_gc', 'wager_sc', 'win_sc', 'redeem_sc', 'refund_gc', 'refund_sc',
+                    'jackpot_gc', 'jackpot_sc', 'tournament_entry', 'tournament_prize', 'bonus_gc', 'bonus_sc'));
+
+-- Marketing campaigns that bonuses are granted under. Budgets and per-user caps
+-- of 0 are unlimited. issued_gc / issued_sc are updated in the grant's DB
+-- transaction while the campaign row is locked, so budgets cannot be overrun.
+CREATE TABLE bonus_campaigns (
+    id VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    budget_gc BIGINT NOT NULL DEFAULT 0 CHECK (budget_gc >= 0),
+    budget_sc BIGINT NOT NULL DEFAULT 0 CHECK (budget_sc >= 0),
+    per_user_cap_gc BIGINT NOT NULL DEFAULT 0 CHECK (per_user_cap_gc >= 0),
+    per_user_cap_sc BIGINT NOT NULL DEFAULT 0 CHECK (per_user_cap_sc >= 0),
+    issued_gc BIGINT NOT NULL DEFAULT 0,
+    issued_sc BIGINT NOT NULL DEFAULT 0,
+    starts_at TIMESTAMP,
+    ends_at TIMESTAMP,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- One row per grant, used for per-user caps and campaign reporting
+CREATE TABLE bonus_grants (
+    id SERIAL PRIMARY KEY,
+    campaign_id VARCHAR(64) NOT NULL REFERENCES bonus_campaigns(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    amount_gc BIGINT NOT NULL DEFAULT 0,
+    amount_sc BIGINT NOT NULL DEFAULT 0,
+    transaction_ids INTEGER[] NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_bonus_grants_campaign_user ON bonus_grants(campaign_id, user_id);
+
+-- Sample campaign for testing
+INSERT INTO bonus_campaigns (id, name, budget_gc, budget_sc, per_user_cap_gc, per_user_cap_sc) VALUES
+    ('welcome', 'Welcome Bonus', 10000000, 10000, 50000, 5);
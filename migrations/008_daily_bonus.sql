@@ -0,0 +1,36 @@
+-- Calendar days for daily bonuses are counted in the user's time zone
+ALTER TABLE users ADD COLUMN time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+-- Streak schedule: day N of a streak pays the amounts of day N, and streaks
+-- longer than the schedule keep paying the last day
+CREATE TABLE daily_bonus_schedule (
+    day INTEGER PRIMARY KEY CHECK (day >= 1),
+    amount_gc BIGINT NOT NULL DEFAULT 0 CHECK (amount_gc >= 0),
+    amount_sc BIGINT NOT NULL DEFAULT 0 CHECK (amount_sc >= 0),
+    CHECK (amount_gc > 0 OR amount_sc > 0)
+);
+
+-- One claim per user per local calendar day; the primary key makes a second
+-- claim for the same day impossible even across instances
+CREATE TABLE daily_bonus_claims (
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    claim_date DATE NOT NULL,
+    streak INTEGER NOT NULL CHECK (streak >= 1),
+    amount_gc BIGINT NOT NULL DEFAULT 0,
+    amount_sc BIGINT NOT NULL DEFAULT 0,
+    transaction_ids INTEGER[] NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, claim_date)
+);
+
+-- Daily bonuses are issued under their own campaign for reporting
+INSERT INTO bonus_campaigns (id, name) VALUES ('daily_login', 'Daily Login Bonus');
+
+INSERT INTO daily_bonus_schedule (day, amount_gc, amount_sc) VALUES
+    (1, 1000, 0),
+    (2, 1500, 0),
+    (3, 2000, 0),
+    (4, 2500, 0),
+    (5, 3000, 0),
+    (6, 4000, 0),
+    (7, 5000, 1);
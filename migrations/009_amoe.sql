@@ -0,0 +1,69 @@
+-- Alternative Method of Entry: free SC credited once an entry is approved
+ALTER TABLE transactions DROP CONSTRAINT transactions_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_check
+    CHECK (type IN ('purchase', 'wager_gc', 'win_gc', 'wager_sc', 'win_sc', 'redeem_sc', 'refund_gc', 'refund_sc',
+                    'jackpot_gc', 'jackpot_sc', 'tournament_entry', 'tournament_prize', 'bonus_gc', 'bonus_sc',
+                    'amoe_sc'));
+
+-- Single-row settings; the row is also locked while an entry is submitted so
+-- daily limits hold under concurrent submissions
+CREATE TABLE amoe_settings (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    amount_sc BIGINT NOT NULL CHECK (amount_sc > 0),
+    max_entries_per_user_per_day INTEGER NOT NULL CHECK (max_entries_per_user_per_day >= 0),
+    max_entries_per_day INTEGER NOT NULL CHECK (max_entries_per_day >= 0),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO amoe_settings (amount_sc, max_entries_per_user_per_day, max_entries_per_day) VALUES (5, 1, 10000);
+
+-- Single-use request codes issued to players, to be written on a mail-in
+-- entry or typed into the web form
+CREATE TABLE amoe_codes (
+    code VARCHAR(32) PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP
+);
+
+CREATE TABLE amoe_entries (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(32) NOT NULL UNIQUE REFERENCES amoe_codes(code),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    method VARCHAR(8) NOT NULL CHECK (method IN ('mail', 'web')),
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    amount_sc BIGINT NOT NULL,
+    submitted_by VARCHAR(255) NOT NULL,
+    submitted_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    reviewed_by VARCHAR(255),
+    reviewed_at TIMESTAMP,
+    review_note TEXT NOT NULL DEFAULT '',
+    transaction_id INTEGER REFERENCES transactions(id)
+);
+
+CREATE INDEX idx_amoe_entries_status ON amoe_entries(status, submitted_at);
+CREATE INDEX idx_amoe_entries_user_submitted ON amoe_entries(user_id, submitted_at);
+
+-- Append-only audit trail of every entry action and who performed it
+CREATE TABLE amoe_audit (
+    id SERIAL PRIMARY KEY,
+    entry_id INTEGER NOT NULL REFERENCES amoe_entries(id),
+    action VARCHAR(16) NOT NULL CHECK (action IN ('submitted', 'approved', 'rejected')),
+    actor VARCHAR(255) NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_amoe_audit_entry ON amoe_audit(entry_id, id);
+
+-- Audit tables reject updates and deletes
+CREATE OR REPLACE FUNCTION forbid_audit_changes() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION '% is append-only', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER amoe_audit_append_only
+    BEFORE UPDATE OR DELETE ON amoe_audit
+    FOR EACH ROW EXECUTE FUNCTION forbid_audit_changes();
@@ -0,0 +1,35 @@
+-- Promo codes redeemable with a package purchase for extra coins
+ALTER TABLE transactions DROP CONSTRAINT transactions_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_check
+    CHECK (type IN ('purchase', 'wager_gc', 'win_gc', 'wager_sc', 'win_sc', 'redeem_sc', 'refund_gc', 'refund_sc',
+                    'jackpot_gc', 'jackpot_sc', 'tournament_entry', 'tournament_prize', 'bonus_gc', 'bonus_sc',
+                    'amoe_sc', 'promo_gc', 'promo_sc'));
+
+CREATE TABLE promo_codes (
+    code VARCHAR(64) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    kind VARCHAR(16) NOT NULL CHECK (kind IN ('fixed', 'percent')),
+    value_gc BIGINT NOT NULL DEFAULT 0 CHECK (value_gc >= 0),
+    value_sc BIGINT NOT NULL DEFAULT 0 CHECK (value_sc >= 0),
+    package_codes TEXT[] NOT NULL DEFAULT '{}',
+    starts_at TIMESTAMP,
+    ends_at TIMESTAMP,
+    max_redemptions INTEGER NOT NULL DEFAULT 0 CHECK (max_redemptions >= 0),
+    redemptions INTEGER NOT NULL DEFAULT 0,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- One redemption per user per code
+CREATE TABLE promo_redemptions (
+    code VARCHAR(64) NOT NULL REFERENCES promo_codes(code),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    package_code VARCHAR(50) NOT NULL,
+    transaction_ids INTEGER[] NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (code, user_id)
+);
+
+INSERT INTO promo_codes (code, description, kind, value_gc, value_sc)
+VALUES ('WELCOME20', '20% extra coins on any package', 'percent', 20, 20);
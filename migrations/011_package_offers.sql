@@ -0,0 +1,6 @@
+-- VIP tier used to target segmented package offers; 0 means not VIP
+ALTER TABLE users ADD COLUMN vip_tier INTEGER NOT NULL DEFAULT 0 CHECK (vip_tier >= 0);
+
+-- Offer eligibility looks up purchases of a package by the user
+CREATE INDEX idx_transactions_user_package ON transactions(user_id, (metadata->>'package_code'), created_at)
+    WHERE type = 'purchase';
@@ -0,0 +1,42 @@
+-- Responsible-gaming purchase spend limits. Purchases record their price in
+-- metadata (price_cents); purchases made before this migration count as 0.
+
+-- Player-set limits; 0 means no limit. Increases wait in pending_* until
+-- their cooling-off period ends.
+CREATE TABLE spend_limits (
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    period VARCHAR(8) NOT NULL CHECK (period IN ('daily', 'weekly', 'monthly')),
+    limit_cents BIGINT NOT NULL CHECK (limit_cents >= 0),
+    pending_limit_cents BIGINT CHECK (pending_limit_cents >= 0),
+    pending_effective_at TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, period)
+);
+
+-- Append-only history of every requested change
+CREATE TABLE spend_limit_changes (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    period VARCHAR(8) NOT NULL,
+    old_limit_cents BIGINT NOT NULL,
+    new_limit_cents BIGINT NOT NULL,
+    requested_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    effective_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_spend_limit_changes_user ON spend_limit_changes(user_id, id DESC);
+
+CREATE TRIGGER spend_limit_changes_append_only
+    BEFORE UPDATE OR DELETE ON spend_limit_changes
+    FOR EACH ROW EXECUTE FUNCTION forbid_audit_changes();
+
+-- Operator-wide caps applied to every player; 0 means no cap
+CREATE TABLE spend_limit_defaults (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    daily_cents BIGINT NOT NULL CHECK (daily_cents >= 0),
+    weekly_cents BIGINT NOT NULL CHECK (weekly_cents >= 0),
+    monthly_cents BIGINT NOT NULL CHECK (monthly_cents >= 0),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO spend_limit_defaults (daily_cents, weekly_cents, monthly_cents) VALUES (100000, 250000, 500000);
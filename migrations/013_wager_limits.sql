@@ -0,0 +1,53 @@
+-- Responsible-gaming wager and loss limits per currency
+
+-- Player-set limits; 0 means no limit. Increases wait in pending_* until
+-- their cooling-off period ends.
+CREATE TABLE wager_limits (
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    currency VARCHAR(2) NOT NULL CHECK (currency IN ('GC', 'SC')),
+    kind VARCHAR(16) NOT NULL CHECK (kind IN ('max_stake', 'daily_wagered', 'daily_loss')),
+    limit_amount BIGINT NOT NULL CHECK (limit_amount >= 0),
+    pending_limit_amount BIGINT CHECK (pending_limit_amount >= 0),
+    pending_effective_at TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, currency, kind)
+);
+
+-- Append-only history of every requested change
+CREATE TABLE wager_limit_changes (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    currency VARCHAR(2) NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    old_limit_amount BIGINT NOT NULL,
+    new_limit_amount BIGINT NOT NULL,
+    requested_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    effective_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_wager_limit_changes_user ON wager_limit_changes(user_id, id DESC);
+
+CREATE TRIGGER wager_limit_changes_append_only
+    BEFORE UPDATE OR DELETE ON wager_limit_changes
+    FOR EACH ROW EXECUTE FUNCTION forbid_audit_changes();
+
+-- Running totals per UTC day, updated in the same transaction as the wager,
+-- win and refund rows they summarize
+CREATE TABLE wager_daily_totals (
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    currency VARCHAR(2) NOT NULL,
+    day DATE NOT NULL,
+    wagered BIGINT NOT NULL DEFAULT 0,
+    won BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, currency, day)
+);
+
+INSERT INTO wager_daily_totals (user_id, currency, day, wagered, won)
+SELECT user_id, currency, created_at::DATE,
+       SUM(CASE WHEN type IN ('wager_gc', 'wager_sc') THEN amount
+                WHEN type IN ('refund_gc', 'refund_sc') THEN -amount
+                ELSE 0 END),
+       SUM(CASE WHEN type IN ('win_gc', 'win_sc') THEN amount ELSE 0 END)
+FROM transactions
+WHERE type IN ('wager_gc', 'wager_sc', 'win_gc', 'win_sc', 'refund_gc', 'refund_sc')
+GROUP BY user_id, currency, created_at::DATE;
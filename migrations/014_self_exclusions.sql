@@ -0,0 +1,51 @@
+-- Player self-exclusions. An exclusion is enforced from starts_at until
+-- ends_at (never for permanent ones) whether or not the expiry job has run;
+-- the job only stamps expired_at and audits it.
+CREATE TABLE self_exclusions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    period VARCHAR(16) NOT NULL CHECK (period IN ('24h', '7d', '6m', 'permanent')),
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP,
+    expired_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (period = 'permanent' OR ends_at IS NOT NULL)
+);
+
+CREATE INDEX idx_self_exclusions_user ON self_exclusions(user_id, id DESC);
+CREATE INDEX idx_self_exclusions_pending_expiry ON self_exclusions(ends_at) WHERE expired_at IS NULL;
+
+-- Exclusions cannot be shortened or revoked: only expired_at may be set, once
+CREATE OR REPLACE FUNCTION forbid_self_exclusion_changes() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        RAISE EXCEPTION 'self exclusions cannot be deleted';
+    END IF;
+    IF NEW.user_id <> OLD.user_id OR NEW.period <> OLD.period
+        OR NEW.starts_at <> OLD.starts_at OR NEW.ends_at IS DISTINCT FROM OLD.ends_at
+        OR OLD.expired_at IS NOT NULL THEN
+        RAISE EXCEPTION 'self exclusions cannot be changed';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER self_exclusions_immutable
+    BEFORE UPDATE OR DELETE ON self_exclusions
+    FOR EACH ROW EXECUTE FUNCTION forbid_self_exclusion_changes();
+
+-- Append-only audit trail of every exclusion started and expired
+CREATE TABLE self_exclusion_audit (
+    id SERIAL PRIMARY KEY,
+    exclusion_id INTEGER NOT NULL REFERENCES self_exclusions(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    action VARCHAR(16) NOT NULL CHECK (action IN ('started', 'expired')),
+    actor VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_self_exclusion_audit_user ON self_exclusion_audit(user_id, id);
+
+CREATE TRIGGER self_exclusion_audit_append_only
+    BEFORE UPDATE OR DELETE ON self_exclusion_audit
+    FOR EACH ROW EXECUTE FUNCTION forbid_audit_changes();
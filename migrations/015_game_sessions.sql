@@ -0,0 +1,33 @@
+-- Per-player session preferences. They are copied onto each session when it
+-- opens, so a change applies from the next session. 0 disables a setting.
+CREATE TABLE game_session_settings (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id),
+    reality_check_minutes INTEGER NOT NULL DEFAULT 0 CHECK (reality_check_minutes >= 0),
+    loss_cap_gc BIGINT NOT NULL DEFAULT 0 CHECK (loss_cap_gc >= 0),
+    loss_cap_sc BIGINT NOT NULL DEFAULT 0 CHECK (loss_cap_sc >= 0),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Play sessions. A session opens with a user's first wager and closes after a
+-- period of inactivity; ended_at is then the time of its last wager.
+CREATE TABLE game_sessions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    started_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_activity_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    ended_at TIMESTAMP,
+    wagers INTEGER NOT NULL DEFAULT 0,
+    gc_staked BIGINT NOT NULL DEFAULT 0,
+    gc_won BIGINT NOT NULL DEFAULT 0,
+    sc_staked BIGINT NOT NULL DEFAULT 0,
+    sc_won BIGINT NOT NULL DEFAULT 0,
+    loss_cap_gc BIGINT NOT NULL DEFAULT 0,
+    loss_cap_sc BIGINT NOT NULL DEFAULT 0,
+    reality_check_minutes INTEGER NOT NULL DEFAULT 0,
+    reality_check_at TIMESTAMP NOT NULL DEFAULT NOW(), -- session start or last acknowledgement
+    reality_checks INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX idx_game_sessions_open ON game_sessions(user_id) WHERE ended_at IS NULL;
+CREATE INDEX idx_game_sessions_user ON game_sessions(user_id, id DESC);
+CREATE INDEX idx_game_sessions_idle ON game_sessions(last_activity_at) WHERE ended_at IS NULL;
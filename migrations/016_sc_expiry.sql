@@ -0,0 +1,69 @@
+-- Sweeps Coins expire after a period without any account activity
+ALTER TABLE transactions DROP CONSTRAINT transactions_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_check
+    CHECK (type IN ('purchase', 'wager_gc', 'win_gc', 'wager_sc', 'win_sc', 'redeem_sc', 'refund_gc', 'refund_sc',
+                    'jackpot_gc', 'jackpot_sc', 'tournament_entry', 'tournament_prize', 'bonus_gc', 'bonus_sc',
+                    'amoe_sc', 'promo_gc', 'promo_sc', 'expire_sc'));
+
+CREATE TABLE sc_expiry_settings (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    inactivity_days INTEGER NOT NULL CHECK (inactivity_days > 0),
+    warning_days INTEGER NOT NULL CHECK (warning_days >= 0),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (warning_days < inactivity_days)
+);
+
+INSERT INTO sc_expiry_settings (inactivity_days, warning_days) VALUES (90, 7);
+
+-- One run per UTC day. A run that fails part-way is resumed by the next
+-- attempt on the same day and only marked finished once every user succeeded.
+CREATE TABLE sc_expiry_runs (
+    id SERIAL PRIMARY KEY,
+    run_date DATE NOT NULL UNIQUE,
+    inactivity_days INTEGER NOT NULL,
+    cutoff TIMESTAMP NOT NULL,
+    started_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP,
+    warnings_sent INTEGER NOT NULL DEFAULT 0,
+    users_expired INTEGER NOT NULL DEFAULT 0,
+    total_expired_sc BIGINT NOT NULL DEFAULT 0
+);
+
+-- Warnings are sent once per stretch of inactivity, identified by the user's
+-- last transaction before it
+CREATE TABLE sc_expiry_warnings (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    run_id INTEGER NOT NULL REFERENCES sc_expiry_runs(id),
+    last_transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+    last_activity_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    balance_sc BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, last_transaction_id)
+);
+
+CREATE TABLE sc_expirations (
+    run_id INTEGER NOT NULL REFERENCES sc_expiry_runs(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    amount_sc BIGINT NOT NULL CHECK (amount_sc > 0),
+    last_activity_at TIMESTAMP NOT NULL,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (run_id, user_id)
+);
+
+-- Warnings reach connected clients through the same channel as transactions
+CREATE OR REPLACE FUNCTION notify_sc_expiry_warning() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify(
+        'wallet_events',
+        json_build_object('sc_expiry_warning_id', NEW.id, 'user_id', NEW.user_id)::text
+    );
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER sc_expiry_warnings_notify
+    AFTER INSERT ON sc_expiry_warnings
+    FOR EACH ROW EXECUTE FUNCTION notify_sc_expiry_warning();
@@ -0,0 +1,51 @@
+-- Currency registry. Currency columns reference it instead of a hard-coded
+-- CHECK, so a new currency is added by inserting a row.
+CREATE TABLE currencies (
+    code VARCHAR(16) PRIMARY KEY CHECK (code ~ '^[A-Z][A-Z0-9_]{1,15}$'),
+    name VARCHAR(64) NOT NULL,
+    minor_units SMALLINT NOT NULL DEFAULT 0 CHECK (minor_units BETWEEN 0 AND 8),
+    purchasable BOOLEAN NOT NULL DEFAULT FALSE,
+    wagerable BOOLEAN NOT NULL DEFAULT FALSE,
+    redeemable BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Packages are priced for their Gold Coins; Sweeps Coins only come free with
+-- them and are the only currency that can be redeemed
+INSERT INTO currencies (code, name, minor_units, purchasable, wagerable, redeemable) VALUES
+    ('GC', 'Gold Coins', 0, TRUE, TRUE, FALSE),
+    ('SC', 'Sweeps Coins', 0, FALSE, TRUE, TRUE);
+
+ALTER TABLE transactions DROP CONSTRAINT transactions_currency_check;
+ALTER TABLE transactions ALTER COLUMN currency TYPE VARCHAR(16);
+ALTER TABLE transactions ADD CONSTRAINT transactions_currency_fkey
+    FOREIGN KEY (currency) REFERENCES currencies(code);
+
+ALTER TABLE game_currencies DROP CONSTRAINT game_currencies_currency_check;
+ALTER TABLE game_currencies ALTER COLUMN currency TYPE VARCHAR(16);
+ALTER TABLE game_currencies ADD CONSTRAINT game_currencies_currency_fkey
+    FOREIGN KEY (currency) REFERENCES currencies(code);
+
+ALTER TABLE jackpot_pools DROP CONSTRAINT jackpot_pools_currency_check;
+ALTER TABLE jackpot_pools ALTER COLUMN currency TYPE VARCHAR(16);
+ALTER TABLE jackpot_pools ADD CONSTRAINT jackpot_pools_currency_fkey
+    FOREIGN KEY (currency) REFERENCES currencies(code);
+
+ALTER TABLE tournaments DROP CONSTRAINT tournaments_currency_check;
+ALTER TABLE tournaments ALTER COLUMN currency TYPE VARCHAR(16);
+ALTER TABLE tournaments ADD CONSTRAINT tournaments_currency_fkey
+    FOREIGN KEY (currency) REFERENCES currencies(code);
+
+ALTER TABLE wager_limits DROP CONSTRAINT wager_limits_currency_check;
+ALTER TABLE wager_limits ALTER COLUMN currency TYPE VARCHAR(16);
+ALTER TABLE wager_limits ADD CONSTRAINT wager_limits_currency_fkey
+    FOREIGN KEY (currency) REFERENCES currencies(code);
+
+ALTER TABLE wager_limit_changes ALTER COLUMN currency TYPE VARCHAR(16);
+ALTER TABLE wager_limit_changes ADD CONSTRAINT wager_limit_changes_currency_fkey
+    FOREIGN KEY (currency) REFERENCES currencies(code);
+
+ALTER TABLE wager_daily_totals ALTER COLUMN currency TYPE VARCHAR(16);
+ALTER TABLE wager_daily_totals ADD CONSTRAINT wager_daily_totals_currency_fkey
+    FOREIGN KEY (currency) REFERENCES currencies(code);
@@ -0,0 +1,57 @@
+-- Sweeps Coins are redeemed in dollars and cents, so SC amounts are stored in
+-- hundredths from now on. Every stored SC amount is converted from whole coins
+-- to minor units in one go; transaction metadata keeps the values it was
+-- written with.
+UPDATE currencies SET minor_units = 2, updated_at = NOW() WHERE code = 'SC';
+
+UPDATE transactions SET amount = amount * 100, balance_after = balance_after * 100
+WHERE currency = 'SC';
+
+UPDATE game_currencies SET min_stake = min_stake * 100, max_stake = max_stake * 100
+WHERE currency = 'SC';
+
+UPDATE jackpot_pools SET min_stake = min_stake * 100, seed_amount = seed_amount * 100, amount = amount * 100
+WHERE currency = 'SC';
+UPDATE jackpot_contributions SET stake = stake * 100, amount = amount * 100, pool_amount_after = pool_amount_after * 100
+WHERE pool_id IN (SELECT id FROM jackpot_pools WHERE currency = 'SC');
+UPDATE jackpot_wins SET amount = amount * 100
+WHERE pool_id IN (SELECT id FROM jackpot_pools WHERE currency = 'SC');
+
+-- Prizes keep their rank order; biggest-multiplier scores are ratios and stay as they are
+UPDATE tournaments SET entry_fee = entry_fee * 100,
+    prizes = ARRAY(SELECT p * 100 FROM unnest(prizes) WITH ORDINALITY AS u(p, n) ORDER BY n)
+WHERE currency = 'SC';
+UPDATE tournament_entries e SET prize = e.prize * 100,
+    score = CASE WHEN t.scoring = 'total_wagered' THEN e.score * 100 ELSE e.score END
+FROM tournaments t
+WHERE t.id = e.tournament_id AND t.currency = 'SC';
+
+UPDATE bonus_campaigns SET budget_sc = budget_sc * 100, per_user_cap_sc = per_user_cap_sc * 100, issued_sc = issued_sc * 100;
+UPDATE bonus_grants SET amount_sc = amount_sc * 100;
+
+UPDATE daily_bonus_schedule SET amount_sc = amount_sc * 100;
+UPDATE daily_bonus_claims SET amount_sc = amount_sc * 100;
+
+UPDATE amoe_settings SET amount_sc = amount_sc * 100;
+UPDATE amoe_entries SET amount_sc = amount_sc * 100;
+
+-- Percent promo codes hold a percentage, not an amount
+UPDATE promo_codes SET value_sc = value_sc * 100 WHERE kind = 'fixed';
+
+UPDATE wager_limits SET limit_amount = limit_amount * 100, pending_limit_amount = pending_limit_amount * 100
+WHERE currency = 'SC';
+UPDATE wager_daily_totals SET wagered = wagered * 100, won = won * 100
+WHERE currency = 'SC';
+
+-- The limit history is append-only; converting its units is the one exception
+ALTER TABLE wager_limit_changes DISABLE TRIGGER wager_limit_changes_append_only;
+UPDATE wager_limit_changes SET old_limit_amount = old_limit_amount * 100, new_limit_amount = new_limit_amount * 100
+WHERE currency = 'SC';
+ALTER TABLE wager_limit_changes ENABLE TRIGGER wager_limit_changes_append_only;
+
+UPDATE game_session_settings SET loss_cap_sc = loss_cap_sc * 100;
+UPDATE game_sessions SET sc_staked = sc_staked * 100, sc_won = sc_won * 100, loss_cap_sc = loss_cap_sc * 100;
+
+UPDATE sc_expiry_runs SET total_expired_sc = total_expired_sc * 100;
+UPDATE sc_expiry_warnings SET balance_sc = balance_sc * 100;
+UPDATE sc_expirations SET amount_sc = amount_sc * 100;
@@ -0,0 +1,8 @@
+-- Multi-user batch postings are idempotent under one key covering every
+-- user's rows, so they cannot use the per-user idempotency_keys table
+CREATE TABLE posting_batches (
+    key VARCHAR(255) PRIMARY KEY,
+    user_ids INTEGER[] NOT NULL,
+    transaction_ids INTEGER[] NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,28 @@
+-- Manual balance adjustments by support staff. The adjustment transaction
+-- carries a signed amount: positive credits the balance, negative debits it.
+ALTER TABLE transactions DROP CONSTRAINT transactions_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_check
+    CHECK (type IN ('purchase', 'wager_gc', 'win_gc', 'wager_sc', 'win_sc', 'redeem_sc', 'refund_gc', 'refund_sc',
+                    'jackpot_gc', 'jackpot_sc', 'tournament_entry', 'tournament_prize', 'bonus_gc', 'bonus_sc',
+                    'amoe_sc', 'promo_gc', 'promo_sc', 'expire_sc', 'adjustment'));
+
+-- Who adjusted which balance and why, kept apart from player activity
+CREATE TABLE adjustments (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    transaction_id INTEGER NOT NULL UNIQUE REFERENCES transactions(id),
+    currency VARCHAR(16) NOT NULL REFERENCES currencies(code),
+    amount BIGINT NOT NULL CHECK (amount <> 0),
+    reason_code VARCHAR(32) NOT NULL
+        CHECK (reason_code IN ('correction', 'goodwill', 'compensation', 'chargeback', 'fraud', 'other')),
+    note TEXT NOT NULL CHECK (note <> ''),
+    admin VARCHAR(64) NOT NULL CHECK (admin <> ''),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_adjustments_created ON adjustments(created_at DESC, id DESC);
+CREATE INDEX idx_adjustments_user ON adjustments(user_id, created_at DESC, id DESC);
+
+CREATE TRIGGER adjustments_append_only
+    BEFORE UPDATE OR DELETE ON adjustments
+    FOR EACH ROW EXECUTE FUNCTION forbid_audit_changes();
@@ -0,0 +1,57 @@
+-- Dual control: adjustments and redemptions above a threshold wait as pending
+-- requests until a second admin approves them
+CREATE TABLE approval_thresholds (
+    operation VARCHAR(16) NOT NULL CHECK (operation IN ('adjustment', 'redemption')),
+    currency VARCHAR(16) NOT NULL REFERENCES currencies(code),
+    threshold BIGINT NOT NULL CHECK (threshold >= 0),
+    expires_after_hours INTEGER NOT NULL CHECK (expires_after_hours BETWEEN 1 AND 720),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (operation, currency)
+);
+
+-- 1,000,000 GC and 1,000.00 SC per adjustment, 5,000.00 SC per redemption
+INSERT INTO approval_thresholds (operation, currency, threshold, expires_after_hours) VALUES
+    ('adjustment', 'GC', 1000000, 72),
+    ('adjustment', 'SC', 100000, 72),
+    ('redemption', 'SC', 500000, 72);
+
+CREATE TABLE approval_requests (
+    id SERIAL PRIMARY KEY,
+    operation VARCHAR(16) NOT NULL CHECK (operation IN ('adjustment', 'redemption')),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    currency VARCHAR(16) NOT NULL REFERENCES currencies(code),
+    amount BIGINT NOT NULL CHECK (amount <> 0),
+    reason_code VARCHAR(32),
+    note TEXT NOT NULL DEFAULT '',
+    requested_by VARCHAR(64) NOT NULL DEFAULT '', -- empty for redemptions requested by the player
+    idempotency_key VARCHAR(255) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected', 'expired')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    decided_by VARCHAR(64),
+    decided_at TIMESTAMP,
+    decision_note TEXT NOT NULL DEFAULT '',
+    transaction_id INTEGER REFERENCES transactions(id),
+    UNIQUE (user_id, idempotency_key)
+);
+
+CREATE INDEX idx_approval_requests_created ON approval_requests(created_at DESC, id DESC);
+CREATE INDEX idx_approval_requests_pending ON approval_requests(expires_at) WHERE status = 'pending';
+
+CREATE TABLE approval_events (
+    id SERIAL PRIMARY KEY,
+    request_id INTEGER NOT NULL REFERENCES approval_requests(id),
+    action VARCHAR(16) NOT NULL,
+    actor VARCHAR(64) NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_approval_events_request ON approval_events(request_id, id);
+
+CREATE TRIGGER approval_events_append_only
+    BEFORE UPDATE OR DELETE ON approval_events
+    FOR EACH ROW EXECUTE FUNCTION forbid_audit_changes();
+
+-- The second admin of an approved adjustment
+ALTER TABLE adjustments ADD COLUMN approved_by VARCHAR(64);
@@ -0,0 +1,4 @@
+-- A digest of each batch's legs, so a batch key reused for a different batch
+-- is refused instead of replaying the original. Batches posted before this
+-- migration have none and replay unchecked.
+ALTER TABLE posting_batches ADD COLUMN legs_digest CHAR(64);
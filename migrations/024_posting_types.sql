@@ -0,0 +1,8 @@
+-- General-purpose types for multi-leg and batch postings: a signed correction
+-- in any currency, and referral rewards in GC and SC
+ALTER TABLE transactions DROP CONSTRAINT transactions_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_check
+    CHECK (type IN ('purchase', 'wager_gc', 'win_gc', 'wager_sc', 'win_sc', 'redeem_sc', 'refund_gc', 'refund_sc',
+                    'jackpot_gc', 'jackpot_sc', 'tournament_entry', 'tournament_prize', 'bonus_gc', 'bonus_sc',
+                    'amoe_sc', 'promo_gc', 'promo_sc', 'expire_sc', 'adjustment', 'wager', 'win', 'refund',
+                    'jackpot', 'redeem', 'correction', 'referral_gc', 'referral_sc'));
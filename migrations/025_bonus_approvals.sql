@@ -0,0 +1,16 @@
+-- Bonus grants above a threshold wait for a second admin like adjustments.
+-- A held grant records the campaign it is granted under.
+ALTER TABLE approval_thresholds DROP CONSTRAINT approval_thresholds_operation_check;
+ALTER TABLE approval_thresholds ADD CONSTRAINT approval_thresholds_operation_check
+    CHECK (operation IN ('adjustment', 'redemption', 'bonus'));
+
+ALTER TABLE approval_requests DROP CONSTRAINT approval_requests_operation_check;
+ALTER TABLE approval_requests ADD CONSTRAINT approval_requests_operation_check
+    CHECK (operation IN ('adjustment', 'redemption', 'bonus'));
+
+ALTER TABLE approval_requests ADD COLUMN campaign_id VARCHAR(64) REFERENCES bonus_campaigns(id);
+
+-- The same defaults as adjustments: 1,000,000 GC and 1,000.00 SC per grant
+INSERT INTO approval_thresholds (operation, currency, threshold, expires_after_hours) VALUES
+    ('bonus', 'GC', 1000000, 72),
+    ('bonus', 'SC', 100000, 72);
@@ -0,0 +1,869 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ErrInvalidAmount is returned for decimal amounts that are malformed, out of
+// range or more precise than their currency's minor units
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// builtinMinorUnits applies until the currency registry is loaded
+var builtinMinorUnits = map[Currency]int{
+	CurrencyGC: 0,
+	CurrencySC: 2,
+}
+
+var minorUnits atomic.Pointer[map[Currency]int]
+
+// SetMinorUnits replaces the scales amounts are rendered with, keyed by currency
+func SetMinorUnits(scales map[Currency]int) {
+	minorUnits.Store(&scales)
+}
+
+// MinorUnits returns the number of decimal places of the currency's smallest
+// unit; amounts are stored as whole multiples of it
+func (c Currency) MinorUnits() int {
+	scales := builtinMinorUnits
+	if loaded := minorUnits.Load(); loaded != nil {
+		scales = *loaded
+	}
+	return scales[c]
+}
+
+// Decimal is an amount as sent by API clients: a decimal string such as
+// "12.34" or a plain JSON number, kept as written until its currency is known
+type Decimal string
+
+// UnmarshalJSON accepts a JSON string or number
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*d = Decimal(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("amount must be a decimal string or number: %w", err)
+	}
+	*d = Decimal(n)
+	return nil
+}
+
+// ParseAmount converts a non-negative decimal string to minor units without
+// rounding. Amounts with more significant decimal places than minorUnits are
+// rejected; trailing zeros beyond them are allowed.
+func ParseAmount(s string, minorUnits int) (int64, error) {
+	whole, frac, hasPoint := strings.Cut(s, ".")
+	if whole == "" || (hasPoint && frac == "") || !isDigits(whole) || !isDigits(frac) {
+		return 0, fmt.Errorf("%w: %q is not a decimal number", ErrInvalidAmount, s)
+	}
+
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > minorUnits {
+		return 0, fmt.Errorf("%w: %q has more than %d decimal places", ErrInvalidAmount, s, minorUnits)
+	}
+	frac += strings.Repeat("0", minorUnits-len(frac))
+
+	var amount int64
+	for _, r := range whole + frac {
+		digit := int64(r - '0')
+		if amount > (math.MaxInt64-digit)/10 {
+			return 0, fmt.Errorf("%w: %q is too large", ErrInvalidAmount, s)
+		}
+		amount = amount*10 + digit
+	}
+	return amount, nil
+}
+
+// FormatAmount renders an amount in minor units as a decimal string with
+// exactly minorUnits decimal places
+func FormatAmount(amount int64, minorUnits int) string {
+	if minorUnits <= 0 {
+		return fmt.Sprintf("%d", amount)
+	}
+
+	sign := ""
+	// Work in uint64 so the most negative amount does not overflow
+	abs := uint64(amount)
+	if amount < 0 {
+		sign = "-"
+		abs = -abs
+	}
+
+	digits := fmt.Sprintf("%0*d", minorUnits+1, abs)
+	split := len(digits) - minorUnits
+	return sign + digits[:split] + "." + digits[split:]
+}
+
+// WholeToMinor converts an amount in whole coins to minor units, for the
+// deprecated gRPC and provider fields that predate minor units
+func WholeToMinor(whole int64, minorUnits int) (int64, error) {
+	amount := whole
+	for i := 0; i < minorUnits; i++ {
+		if amount > math.MaxInt64/10 || amount < math.MinInt64/10 {
+			return 0, fmt.Errorf("%w: %d is too large", ErrInvalidAmount, whole)
+		}
+		amount *= 10
+	}
+	return amount, nil
+}
+
+// MinorToWhole converts an amount in minor units to whole coins, dropping any
+// fraction
+func MinorToWhole(amount int64, minorUnits int) int64 {
+	for i := 0; i < minorUnits; i++ {
+		amount /= 10
+	}
+	return amount
+}
+
+// MarshalJSON renders the amount and balance as decimal strings
+func (t Transaction) MarshalJSON() ([]byte, error) {
+	type transaction Transaction
+	scale := t.Currency.MinorUnits()
+	return json.Marshal(struct {
+		transaction
+		Amount       string `json:"amount"`
+		BalanceAfter string `json:"balance_after"`
+	}{transaction(t), FormatAmount(t.Amount, scale), FormatAmount(t.BalanceAfter, scale)})
+}
+
+// MarshalJSON renders balances and totals as decimal strings
+func (u UserWithBalances) MarshalJSON() ([]byte, error) {
+	type userWithBalances UserWithBalances
+	gc, sc := CurrencyGC.MinorUnits(), CurrencySC.MinorUnits()
+
+	balances := make(map[Currency]string, len(u.Balances))
+	for currency, balance := range u.Balances {
+		balances[currency] = FormatAmount(balance, currency.MinorUnits())
+	}
+	stats := make(map[Currency]currencyStatsJSON, len(u.Stats))
+	for currency, s := range u.Stats {
+		scale := currency.MinorUnits()
+		stats[currency] = currencyStatsJSON{FormatAmount(s.Wagered, scale), FormatAmount(s.Won, scale), FormatAmount(s.Redeemed, scale)}
+	}
+
+	return json.Marshal(struct {
+		userWithBalances
+		Balances        map[Currency]string            `json:"balances"`
+		Stats           map[Currency]currencyStatsJSON `json:"stats"`
+		GoldBalance     string                         `json:"gold_balance"`
+		SweepsBalance   string                         `json:"sweeps_balance"`
+		TotalGCWagered  string                         `json:"total_gc_wagered"`
+		TotalGCWon      string                         `json:"total_gc_won"`
+		TotalSCWagered  string                         `json:"total_sc_wagered"`
+		TotalSCWon      string                         `json:"total_sc_won"`
+		TotalSCRedeemed string                         `json:"total_sc_redeemed"`
+	}{
+		userWithBalances: userWithBalances(u),
+		Balances:         balances,
+		Stats:            stats,
+		GoldBalance:      FormatAmount(u.GoldBalance, gc),
+		SweepsBalance:    FormatAmount(u.SweepsBalance, sc),
+		TotalGCWagered:   FormatAmount(u.TotalGCWagered, gc),
+		TotalGCWon:       FormatAmount(u.TotalGCWon, gc),
+		TotalSCWagered:   FormatAmount(u.TotalSCWagered, sc),
+		TotalSCWon:       FormatAmount(u.TotalSCWon, sc),
+		TotalSCRedeemed:  FormatAmount(u.TotalSCRedeemed, sc),
+	})
+}
+
+// currencyStatsJSON are a user's totals in one currency as decimal strings
+type currencyStatsJSON struct {
+	Wagered  string `json:"wagered"`
+	Won      string `json:"won"`
+	Redeemed string `json:"redeemed"`
+}
+
+// MarshalJSON renders the coin amounts as decimal strings
+func (p Package) MarshalJSON() ([]byte, error) {
+	type pkg Package
+	var coins map[Currency]string
+	if len(p.Coins) > 0 {
+		coins = make(map[Currency]string, len(p.Coins))
+		for currency, amount := range p.Coins {
+			coins[currency] = FormatAmount(amount, currency.MinorUnits())
+		}
+	}
+	return json.Marshal(struct {
+		pkg
+		GoldCoins  string              `json:"gold_coins"`
+		SweepCoins string              `json:"sweep_coins"`
+		Coins      map[Currency]string `json:"coins,omitempty"`
+	}{pkg(p), FormatAmount(p.GoldCoins, CurrencyGC.MinorUnits()), FormatAmount(p.SweepCoins, CurrencySC.MinorUnits()), coins})
+}
+
+// MarshalJSON renders the limits as decimal strings
+func (l WagerLimit) MarshalJSON() ([]byte, error) {
+	type wagerLimit WagerLimit
+	scale := l.Currency.MinorUnits()
+	return json.Marshal(struct {
+		wagerLimit
+		Limit        string  `json:"limit"`
+		PendingLimit *string `json:"pending_limit,omitempty"`
+	}{wagerLimit(l), FormatAmount(l.Limit, scale), formatAmountPtr(l.PendingLimit, scale)})
+}
+
+// MarshalJSON renders the limits and usage as decimal strings
+func (s WagerLimitStatus) MarshalJSON() ([]byte, error) {
+	type wagerLimit WagerLimit
+	scale := s.Currency.MinorUnits()
+	return json.Marshal(struct {
+		wagerLimit
+		Limit        string  `json:"limit"`
+		PendingLimit *string `json:"pending_limit,omitempty"`
+		Used         string  `json:"used"`
+		Remaining    *string `json:"remaining,omitempty"`
+	}{
+		wagerLimit(s.WagerLimit),
+		FormatAmount(s.Limit, scale),
+		formatAmountPtr(s.PendingLimit, scale),
+		FormatAmount(s.Used, scale),
+		formatAmountPtr(s.Remaining, scale),
+	})
+}
+
+// MarshalJSON renders the old and new limits as decimal strings
+func (c WagerLimitChange) MarshalJSON() ([]byte, error) {
+	type wagerLimitChange WagerLimitChange
+	scale := c.Currency.MinorUnits()
+	return json.Marshal(struct {
+		wagerLimitChange
+		OldLimit string `json:"old_limit"`
+		NewLimit string `json:"new_limit"`
+	}{wagerLimitChange(c), FormatAmount(c.OldLimit, scale), FormatAmount(c.NewLimit, scale)})
+}
+
+// formatAmountPtr renders an optional amount, keeping nil as nil
+func formatAmountPtr(amount *int64, minorUnits int) *string {
+	if amount == nil {
+		return nil
+	}
+	s := FormatAmount(*amount, minorUnits)
+	return &s
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalJSON renders the signed amount as a decimal string
+func (a Adjustment) MarshalJSON() ([]byte, error) {
+	type adjustment Adjustment
+	return json.Marshal(struct {
+		adjustment
+		Amount string `json:"amount"`
+	}{adjustment(a), FormatAmount(a.Amount, a.Currency.MinorUnits())})
+}
+
+// MarshalJSON renders the amount as a decimal string
+func (r ApprovalRequest) MarshalJSON() ([]byte, error) {
+	type approvalRequest ApprovalRequest
+	return json.Marshal(struct {
+		approvalRequest
+		Amount string `json:"amount"`
+	}{approvalRequest(r), FormatAmount(r.Amount, r.Currency.MinorUnits())})
+}
+
+// USDMinorUnits is the scale of US dollar amounts: package prices and spend limits
+const USDMinorUnits = 2
+
+// amountParser converts decoded decimal fields to minor units, keeping the
+// first error so a decoder can check it once at the end
+type amountParser struct {
+	err error
+}
+
+// parse converts a decimal field; an empty field is 0
+func (p *amountParser) parse(field string, d Decimal, minorUnits int) int64 {
+	if p.err != nil || d == "" {
+		return 0
+	}
+	amount, err := ParseAmount(string(d), minorUnits)
+	if err != nil {
+		p.err = fmt.Errorf("%s: %w", field, err)
+	}
+	return amount
+}
+
+// MarshalJSON renders the loss caps as decimal strings
+func (s GameSessionSettings) MarshalJSON() ([]byte, error) {
+	type gameSessionSettings GameSessionSettings
+	return json.Marshal(struct {
+		gameSessionSettings
+		LossCapGC string `json:"loss_cap_gc"`
+		LossCapSC string `json:"loss_cap_sc"`
+	}{gameSessionSettings(s), FormatAmount(s.LossCapGC, CurrencyGC.MinorUnits()), FormatAmount(s.LossCapSC, CurrencySC.MinorUnits())})
+}
+
+// UnmarshalJSON accepts the loss caps as decimal amounts
+func (s *GameSessionSettings) UnmarshalJSON(data []byte) error {
+	type gameSessionSettings GameSessionSettings
+	var aux struct {
+		gameSessionSettings
+		LossCapGC Decimal `json:"loss_cap_gc"`
+		LossCapSC Decimal `json:"loss_cap_sc"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var p amountParser
+	*s = GameSessionSettings(aux.gameSessionSettings)
+	s.LossCapGC = p.parse("loss_cap_gc", aux.LossCapGC, CurrencyGC.MinorUnits())
+	s.LossCapSC = p.parse("loss_cap_sc", aux.LossCapSC, CurrencySC.MinorUnits())
+	return p.err
+}
+
+// gameSessionAmounts are the amounts of a session as decimal strings
+type gameSessionAmounts struct {
+	GCStaked  string `json:"gc_staked"`
+	GCWon     string `json:"gc_won"`
+	SCStaked  string `json:"sc_staked"`
+	SCWon     string `json:"sc_won"`
+	LossCapGC string `json:"loss_cap_gc"`
+	LossCapSC string `json:"loss_cap_sc"`
+}
+
+func (s GameSession) amounts() gameSessionAmounts {
+	gc, sc := CurrencyGC.MinorUnits(), CurrencySC.MinorUnits()
+	return gameSessionAmounts{
+		GCStaked:  FormatAmount(s.GCStaked, gc),
+		GCWon:     FormatAmount(s.GCWon, gc),
+		SCStaked:  FormatAmount(s.SCStaked, sc),
+		SCWon:     FormatAmount(s.SCWon, sc),
+		LossCapGC: FormatAmount(s.LossCapGC, gc),
+		LossCapSC: FormatAmount(s.LossCapSC, sc),
+	}
+}
+
+// MarshalJSON renders the stakes, wins and loss caps as decimal strings
+func (s GameSession) MarshalJSON() ([]byte, error) {
+	type gameSession GameSession
+	a := s.amounts()
+	return json.Marshal(struct {
+		gameSession
+		GCStaked  string `json:"gc_staked"`
+		GCWon     string `json:"gc_won"`
+		SCStaked  string `json:"sc_staked"`
+		SCWon     string `json:"sc_won"`
+		LossCapGC string `json:"loss_cap_gc"`
+		LossCapSC string `json:"loss_cap_sc"`
+	}{gameSession(s), a.GCStaked, a.GCWon, a.SCStaked, a.SCWon, a.LossCapGC, a.LossCapSC})
+}
+
+// MarshalJSON renders the session's amounts and net results as decimal strings
+func (s GameSessionSummary) MarshalJSON() ([]byte, error) {
+	type gameSession GameSession
+	type gameSessionSummary struct {
+		gameSession
+		DurationSeconds    int64      `json:"duration_seconds"`
+		RealityCheckDue    bool       `json:"reality_check_due"`
+		NextRealityCheckAt *time.Time `json:"next_reality_check_at,omitempty"`
+	}
+	a := s.amounts()
+	return json.Marshal(struct {
+		gameSessionSummary
+		GCStaked  string `json:"gc_staked"`
+		GCWon     string `json:"gc_won"`
+		SCStaked  string `json:"sc_staked"`
+		SCWon     string `json:"sc_won"`
+		LossCapGC string `json:"loss_cap_gc"`
+		LossCapSC string `json:"loss_cap_sc"`
+		NetGC     string `json:"net_gc"`
+		NetSC     string `json:"net_sc"`
+	}{
+		gameSessionSummary{gameSession(s.GameSession), s.DurationSeconds, s.RealityCheckDue, s.NextRealityCheckAt},
+		a.GCStaked, a.GCWon, a.SCStaked, a.SCWon, a.LossCapGC, a.LossCapSC,
+		FormatAmount(s.NetGC, CurrencyGC.MinorUnits()),
+		FormatAmount(s.NetSC, CurrencySC.MinorUnits()),
+	})
+}
+
+// MarshalJSON renders the stake limits as decimal strings
+func (c GameCurrency) MarshalJSON() ([]byte, error) {
+	type gameCurrency GameCurrency
+	scale := c.Currency.MinorUnits()
+	return json.Marshal(struct {
+		gameCurrency
+		MinStake string `json:"min_stake"`
+		MaxStake string `json:"max_stake"`
+	}{gameCurrency(c), FormatAmount(c.MinStake, scale), FormatAmount(c.MaxStake, scale)})
+}
+
+// UnmarshalJSON accepts the stake limits as decimal amounts of the currency
+func (c *GameCurrency) UnmarshalJSON(data []byte) error {
+	type gameCurrency GameCurrency
+	var aux struct {
+		gameCurrency
+		MinStake Decimal `json:"min_stake"`
+		MaxStake Decimal `json:"max_stake"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var p amountParser
+	*c = GameCurrency(aux.gameCurrency)
+	scale := c.Currency.MinorUnits()
+	c.MinStake = p.parse("min_stake", aux.MinStake, scale)
+	c.MaxStake = p.parse("max_stake", aux.MaxStake, scale)
+	return p.err
+}
+
+// MarshalJSON renders the pool's amounts as decimal strings
+func (p JackpotPool) MarshalJSON() ([]byte, error) {
+	type jackpotPool JackpotPool
+	scale := p.Currency.MinorUnits()
+	return json.Marshal(struct {
+		jackpotPool
+		MinStake   string `json:"min_stake"`
+		SeedAmount string `json:"seed_amount"`
+		Amount     string `json:"amount"`
+	}{jackpotPool(p), FormatAmount(p.MinStake, scale), FormatAmount(p.SeedAmount, scale), FormatAmount(p.Amount, scale)})
+}
+
+// UnmarshalJSON accepts the pool's amounts as decimal amounts of its currency
+func (p *JackpotPool) UnmarshalJSON(data []byte) error {
+	type jackpotPool JackpotPool
+	var aux struct {
+		jackpotPool
+		MinStake   Decimal `json:"min_stake"`
+		SeedAmount Decimal `json:"seed_amount"`
+		Amount     Decimal `json:"amount"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var ap amountParser
+	*p = JackpotPool(aux.jackpotPool)
+	scale := p.Currency.MinorUnits()
+	p.MinStake = ap.parse("min_stake", aux.MinStake, scale)
+	p.SeedAmount = ap.parse("seed_amount", aux.SeedAmount, scale)
+	p.Amount = ap.parse("amount", aux.Amount, scale)
+	return ap.err
+}
+
+// MarshalJSON renders the stake and amounts as decimal strings
+func (c JackpotContribution) MarshalJSON() ([]byte, error) {
+	type jackpotContribution JackpotContribution
+	scale := c.Currency.MinorUnits()
+	return json.Marshal(struct {
+		jackpotContribution
+		Stake           string `json:"stake"`
+		Amount          string `json:"amount"`
+		PoolAmountAfter string `json:"pool_amount_after"`
+	}{jackpotContribution(c), FormatAmount(c.Stake, scale), FormatAmount(c.Amount, scale), FormatAmount(c.PoolAmountAfter, scale)})
+}
+
+// MarshalJSON renders the entry fee and prizes as decimal strings
+func (t Tournament) MarshalJSON() ([]byte, error) {
+	type tournament Tournament
+	scale := t.Currency.MinorUnits()
+	prizes := make([]string, len(t.Prizes))
+	for i, prize := range t.Prizes {
+		prizes[i] = FormatAmount(prize, scale)
+	}
+	return json.Marshal(struct {
+		tournament
+		EntryFee string   `json:"entry_fee"`
+		Prizes   []string `json:"prizes"`
+	}{tournament(t), FormatAmount(t.EntryFee, scale), prizes})
+}
+
+// UnmarshalJSON accepts the entry fee and prizes as decimal amounts of the
+// tournament's currency
+func (t *Tournament) UnmarshalJSON(data []byte) error {
+	type tournament Tournament
+	var aux struct {
+		tournament
+		EntryFee Decimal   `json:"entry_fee"`
+		Prizes   []Decimal `json:"prizes"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var p amountParser
+	*t = Tournament(aux.tournament)
+	scale := t.Currency.MinorUnits()
+	t.EntryFee = p.parse("entry_fee", aux.EntryFee, scale)
+	t.Prizes = nil
+	if aux.Prizes != nil {
+		t.Prizes = make([]int64, len(aux.Prizes))
+	}
+	for i, prize := range aux.Prizes {
+		t.Prizes[i] = p.parse(fmt.Sprintf("prizes[%d]", i), prize, scale)
+	}
+	return p.err
+}
+
+// MarshalJSON renders the prize as a decimal string
+func (e TournamentEntry) MarshalJSON() ([]byte, error) {
+	type tournamentEntry TournamentEntry
+	var prize *string
+	if e.Prize != 0 {
+		prize = formatAmountPtr(&e.Prize, e.Currency.MinorUnits())
+	}
+	return json.Marshal(struct {
+		tournamentEntry
+		Prize *string `json:"prize,omitempty"`
+	}{tournamentEntry(e), prize})
+}
+
+// MarshalJSON renders the budgets, caps and issued totals as decimal strings
+func (c BonusCampaign) MarshalJSON() ([]byte, error) {
+	type bonusCampaign BonusCampaign
+	gc, sc := CurrencyGC.MinorUnits(), CurrencySC.MinorUnits()
+	return json.Marshal(struct {
+		bonusCampaign
+		BudgetGC     string `json:"budget_gc"`
+		BudgetSC     string `json:"budget_sc"`
+		PerUserCapGC string `json:"per_user_cap_gc"`
+		PerUserCapSC string `json:"per_user_cap_sc"`
+		IssuedGC     string `json:"issued_gc"`
+		IssuedSC     string `json:"issued_sc"`
+	}{
+		bonusCampaign(c),
+		FormatAmount(c.BudgetGC, gc), FormatAmount(c.BudgetSC, sc),
+		FormatAmount(c.PerUserCapGC, gc), FormatAmount(c.PerUserCapSC, sc),
+		FormatAmount(c.IssuedGC, gc), FormatAmount(c.IssuedSC, sc),
+	})
+}
+
+// UnmarshalJSON accepts the budgets and caps as decimal amounts. Issued totals
+// are kept by the ledger, so they are not read.
+func (c *BonusCampaign) UnmarshalJSON(data []byte) error {
+	type bonusCampaign BonusCampaign
+	var aux struct {
+		bonusCampaign
+		BudgetGC     Decimal `json:"budget_gc"`
+		BudgetSC     Decimal `json:"budget_sc"`
+		PerUserCapGC Decimal `json:"per_user_cap_gc"`
+		PerUserCapSC Decimal `json:"per_user_cap_sc"`
+		IssuedGC     Decimal `json:"issued_gc"`
+		IssuedSC     Decimal `json:"issued_sc"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var p amountParser
+	gc, sc := CurrencyGC.MinorUnits(), CurrencySC.MinorUnits()
+	*c = BonusCampaign(aux.bonusCampaign)
+	c.BudgetGC = p.parse("budget_gc", aux.BudgetGC, gc)
+	c.BudgetSC = p.parse("budget_sc", aux.BudgetSC, sc)
+	c.PerUserCapGC = p.parse("per_user_cap_gc", aux.PerUserCapGC, gc)
+	c.PerUserCapSC = p.parse("per_user_cap_sc", aux.PerUserCapSC, sc)
+	return p.err
+}
+
+// MarshalJSON renders the remaining budgets as decimal strings
+func (r CampaignReport) MarshalJSON() ([]byte, error) {
+	type campaignReport CampaignReport
+	return json.Marshal(struct {
+		campaignReport
+		RemainingGC *string `json:"remaining_gc,omitempty"`
+		RemainingSC *string `json:"remaining_sc,omitempty"`
+	}{campaignReport(r), formatAmountPtr(r.RemainingGC, CurrencyGC.MinorUnits()), formatAmountPtr(r.RemainingSC, CurrencySC.MinorUnits())})
+}
+
+// MarshalJSON renders the day's reward as decimal strings
+func (d DailyBonusDay) MarshalJSON() ([]byte, error) {
+	type dailyBonusDay DailyBonusDay
+	return json.Marshal(struct {
+		dailyBonusDay
+		AmountGC string `json:"amount_gc"`
+		AmountSC string `json:"amount_sc"`
+	}{dailyBonusDay(d), FormatAmount(d.AmountGC, CurrencyGC.MinorUnits()), FormatAmount(d.AmountSC, CurrencySC.MinorUnits())})
+}
+
+// UnmarshalJSON accepts the day's reward as decimal amounts
+func (d *DailyBonusDay) UnmarshalJSON(data []byte) error {
+	type dailyBonusDay DailyBonusDay
+	var aux struct {
+		dailyBonusDay
+		AmountGC Decimal `json:"amount_gc"`
+		AmountSC Decimal `json:"amount_sc"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var p amountParser
+	*d = DailyBonusDay(aux.dailyBonusDay)
+	d.AmountGC = p.parse("amount_gc", aux.AmountGC, CurrencyGC.MinorUnits())
+	d.AmountSC = p.parse("amount_sc", aux.AmountSC, CurrencySC.MinorUnits())
+	return p.err
+}
+
+// MarshalJSON renders the claimed amounts as decimal strings
+func (c DailyBonusClaim) MarshalJSON() ([]byte, error) {
+	type dailyBonusClaim DailyBonusClaim
+	return json.Marshal(struct {
+		dailyBonusClaim
+		AmountGC string `json:"amount_gc"`
+		AmountSC string `json:"amount_sc"`
+	}{dailyBonusClaim(c), FormatAmount(c.AmountGC, CurrencyGC.MinorUnits()), FormatAmount(c.AmountSC, CurrencySC.MinorUnits())})
+}
+
+// promoValueScales returns the scales of a promo code's GC and SC values:
+// coin amounts for a fixed code, whole percentages for a percent code
+func promoValueScales(kind PromoKind) (int, int) {
+	if kind == PromoKindPercent {
+		return 0, 0
+	}
+	return CurrencyGC.MinorUnits(), CurrencySC.MinorUnits()
+}
+
+// MarshalJSON renders the values as decimal strings
+func (p PromoCode) MarshalJSON() ([]byte, error) {
+	type promoCode PromoCode
+	gc, sc := promoValueScales(p.Kind)
+	return json.Marshal(struct {
+		promoCode
+		ValueGC string `json:"value_gc"`
+		ValueSC string `json:"value_sc"`
+	}{promoCode(p), FormatAmount(p.ValueGC, gc), FormatAmount(p.ValueSC, sc)})
+}
+
+// UnmarshalJSON accepts the values as decimal coin amounts for a fixed code
+// and whole percentages for a percent code
+func (p *PromoCode) UnmarshalJSON(data []byte) error {
+	type promoCode PromoCode
+	var aux struct {
+		promoCode
+		ValueGC Decimal `json:"value_gc"`
+		ValueSC Decimal `json:"value_sc"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var ap amountParser
+	*p = PromoCode(aux.promoCode)
+	gc, sc := promoValueScales(p.Kind)
+	p.ValueGC = ap.parse("value_gc", aux.ValueGC, gc)
+	p.ValueSC = ap.parse("value_sc", aux.ValueSC, sc)
+	return ap.err
+}
+
+// MarshalJSON renders the total expired as a decimal string
+func (r SCExpiryRun) MarshalJSON() ([]byte, error) {
+	type scExpiryRun SCExpiryRun
+	return json.Marshal(struct {
+		scExpiryRun
+		TotalExpiredSC string `json:"total_expired_sc"`
+	}{scExpiryRun(r), FormatAmount(r.TotalExpiredSC, CurrencySC.MinorUnits())})
+}
+
+// MarshalJSON renders the expired amount as a decimal string
+func (e SCExpiration) MarshalJSON() ([]byte, error) {
+	type scExpiration SCExpiration
+	return json.Marshal(struct {
+		scExpiration
+		AmountSC string `json:"amount_sc"`
+	}{scExpiration(e), FormatAmount(e.AmountSC, CurrencySC.MinorUnits())})
+}
+
+// MarshalJSON renders the balance as a decimal string
+func (w SCExpiryWarning) MarshalJSON() ([]byte, error) {
+	type scExpiryWarning SCExpiryWarning
+	return json.Marshal(struct {
+		scExpiryWarning
+		BalanceSC string `json:"balance_sc"`
+	}{scExpiryWarning(w), FormatAmount(w.BalanceSC, CurrencySC.MinorUnits())})
+}
+
+// MarshalJSON renders the entry amount as a decimal string
+func (s AMOESettings) MarshalJSON() ([]byte, error) {
+	type amoeSettings AMOESettings
+	return json.Marshal(struct {
+		amoeSettings
+		AmountSC string `json:"amount_sc"`
+	}{amoeSettings(s), FormatAmount(s.AmountSC, CurrencySC.MinorUnits())})
+}
+
+// UnmarshalJSON accepts the entry amount as a decimal amount
+func (s *AMOESettings) UnmarshalJSON(data []byte) error {
+	type amoeSettings AMOESettings
+	var aux struct {
+		amoeSettings
+		AmountSC Decimal `json:"amount_sc"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var p amountParser
+	*s = AMOESettings(aux.amoeSettings)
+	s.AmountSC = p.parse("amount_sc", aux.AmountSC, CurrencySC.MinorUnits())
+	return p.err
+}
+
+// MarshalJSON renders the amount as a decimal string
+func (e AMOEEntry) MarshalJSON() ([]byte, error) {
+	type amoeEntry AMOEEntry
+	return json.Marshal(struct {
+		amoeEntry
+		AmountSC string `json:"amount_sc"`
+	}{amoeEntry(e), FormatAmount(e.AmountSC, CurrencySC.MinorUnits())})
+}
+
+// MarshalJSON renders the threshold as a decimal string
+func (t ApprovalThreshold) MarshalJSON() ([]byte, error) {
+	type approvalThreshold ApprovalThreshold
+	return json.Marshal(struct {
+		approvalThreshold
+		Threshold string `json:"threshold"`
+	}{approvalThreshold(t), FormatAmount(t.Threshold, t.Currency.MinorUnits())})
+}
+
+// MarshalJSON renders the expected and actual amounts as decimal strings
+func (d LedgerDiscrepancy) MarshalJSON() ([]byte, error) {
+	type ledgerDiscrepancy LedgerDiscrepancy
+	scale := d.Currency.MinorUnits()
+	var expected, actual *string
+	if d.Expected != 0 {
+		expected = formatAmountPtr(&d.Expected, scale)
+	}
+	if d.Actual != 0 {
+		actual = formatAmountPtr(&d.Actual, scale)
+	}
+	return json.Marshal(struct {
+		ledgerDiscrepancy
+		Expected *string `json:"expected,omitempty"`
+		Actual   *string `json:"actual,omitempty"`
+	}{ledgerDiscrepancy(d), expected, actual})
+}
+
+// MarshalJSON renders the balances as decimal strings
+func (v LedgerVerification) MarshalJSON() ([]byte, error) {
+	type ledgerVerification LedgerVerification
+	balances := make(map[Currency]string, len(v.Balances))
+	for currency, balance := range v.Balances {
+		balances[currency] = FormatAmount(balance, currency.MinorUnits())
+	}
+	return json.Marshal(struct {
+		ledgerVerification
+		Balances map[Currency]string `json:"balances"`
+	}{ledgerVerification(v), balances})
+}
+
+// spendLimitAmounts are a spend limit's dollar amounts as decimal strings
+type spendLimitAmounts struct {
+	Limit        string  `json:"limit"`
+	PendingLimit *string `json:"pending_limit,omitempty"`
+}
+
+func (l SpendLimit) amounts() spendLimitAmounts {
+	return spendLimitAmounts{FormatAmount(l.LimitCents, USDMinorUnits), formatAmountPtr(l.PendingLimitCents, USDMinorUnits)}
+}
+
+// MarshalJSON adds the limits in dollars, as decimal strings, next to the cents
+func (l SpendLimit) MarshalJSON() ([]byte, error) {
+	type spendLimit SpendLimit
+	return json.Marshal(struct {
+		spendLimit
+		spendLimitAmounts
+	}{spendLimit(l), l.amounts()})
+}
+
+// MarshalJSON adds the limits, cap and spend in dollars, as decimal strings,
+// next to the cents
+func (s SpendLimitStatus) MarshalJSON() ([]byte, error) {
+	type spendLimit SpendLimit
+	type spendLimitStatus struct {
+		spendLimit
+		OperatorCapCents    int64  `json:"operator_cap_cents"`
+		EffectiveLimitCents int64  `json:"effective_limit_cents"`
+		SpentCents          int64  `json:"spent_cents"`
+		RemainingCents      *int64 `json:"remaining_cents,omitempty"`
+	}
+	return json.Marshal(struct {
+		spendLimitStatus
+		spendLimitAmounts
+		OperatorCap    string  `json:"operator_cap"`
+		EffectiveLimit string  `json:"effective_limit"`
+		Spent          string  `json:"spent"`
+		Remaining      *string `json:"remaining,omitempty"`
+	}{
+		spendLimitStatus{spendLimit(s.SpendLimit), s.OperatorCapCents, s.EffectiveLimitCents, s.SpentCents, s.RemainingCents},
+		s.SpendLimit.amounts(),
+		FormatAmount(s.OperatorCapCents, USDMinorUnits),
+		FormatAmount(s.EffectiveLimitCents, USDMinorUnits),
+		FormatAmount(s.SpentCents, USDMinorUnits),
+		formatAmountPtr(s.RemainingCents, USDMinorUnits),
+	})
+}
+
+// MarshalJSON adds the old and new limits in dollars, as decimal strings, next
+// to the cents
+func (c SpendLimitChange) MarshalJSON() ([]byte, error) {
+	type spendLimitChange SpendLimitChange
+	return json.Marshal(struct {
+		spendLimitChange
+		OldLimit string `json:"old_limit"`
+		NewLimit string `json:"new_limit"`
+	}{spendLimitChange(c), FormatAmount(c.OldLimitCents, USDMinorUnits), FormatAmount(c.NewLimitCents, USDMinorUnits)})
+}
+
+// MarshalJSON adds the caps in dollars, as decimal strings, next to the cents
+func (d SpendLimitDefaults) MarshalJSON() ([]byte, error) {
+	type spendLimitDefaults SpendLimitDefaults
+	return json.Marshal(struct {
+		spendLimitDefaults
+		Daily   string `json:"daily"`
+		Weekly  string `json:"weekly"`
+		Monthly string `json:"monthly"`
+	}{
+		spendLimitDefaults(d),
+		FormatAmount(d.DailyCents, USDMinorUnits),
+		FormatAmount(d.WeeklyCents, USDMinorUnits),
+		FormatAmount(d.MonthlyCents, USDMinorUnits),
+	})
+}
+
+// UnmarshalJSON accepts the caps as decimal dollar amounts. The deprecated
+// integer cents fields are still read for a cap given only in cents.
+func (d *SpendLimitDefaults) UnmarshalJSON(data []byte) error {
+	type spendLimitDefaults SpendLimitDefaults
+	var aux struct {
+		spendLimitDefaults
+		Daily   *Decimal `json:"daily"`
+		Weekly  *Decimal `json:"weekly"`
+		Monthly *Decimal `json:"monthly"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var p amountParser
+	*d = SpendLimitDefaults(aux.spendLimitDefaults)
+	if aux.Daily != nil {
+		d.DailyCents = p.parse("daily", *aux.Daily, USDMinorUnits)
+	}
+	if aux.Weekly != nil {
+		d.WeeklyCents = p.parse("weekly", *aux.Weekly, USDMinorUnits)
+	}
+	if aux.Monthly != nil {
+		d.MonthlyCents = p.parse("monthly", *aux.Monthly, USDMinorUnits)
+	}
+	return p.err
+}
@@ -0,0 +1,211 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Test decimal amounts convert losslessly to minor units
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		name       string
+		amount     string
+		minorUnits int
+		expected   int64
+		wantErr    bool
+	}{
+		{"whole coins", "12", 2, 1200, false},
+		{"cents", "12.34", 2, 1234, false},
+		{"half coin", "0.50", 2, 50, false},
+		{"single decimal", "0.5", 2, 50, false},
+		{"trailing zeros beyond scale", "1.500", 2, 150, false},
+		{"no minor units", "10000", 0, 10000, false},
+		{"too precise", "12.345", 2, 0, true},
+		{"fraction without minor units", "1.5", 0, 0, true},
+		{"negative", "-1.00", 2, 0, true},
+		{"exponent", "1e2", 2, 0, true},
+		{"missing whole part", ".5", 2, 0, true},
+		{"missing fraction", "1.", 2, 0, true},
+		{"empty", "", 2, 0, true},
+		{"overflow", "92233720368547758.08", 2, 0, true},
+		{"largest amount", "92233720368547758.07", 2, 9223372036854775807, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAmount(tt.amount, tt.minorUnits)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidAmount) {
+					t.Errorf("expected ErrInvalidAmount, got %d, %v", got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.expected {
+				t.Errorf("expected %d, got %d, %v", tt.expected, got, err)
+			}
+		})
+	}
+}
+
+// Test minor units render back as decimal strings
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		amount     int64
+		minorUnits int
+		expected   string
+	}{
+		{1234, 2, "12.34"},
+		{50, 2, "0.50"},
+		{5, 2, "0.05"},
+		{0, 2, "0.00"},
+		{-150, 2, "-1.50"},
+		{10000, 0, "10000"},
+		{-9223372036854775808, 2, "-92233720368547758.08"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatAmount(tt.amount, tt.minorUnits); got != tt.expected {
+			t.Errorf("FormatAmount(%d, %d): expected %s, got %s", tt.amount, tt.minorUnits, tt.expected, got)
+		}
+	}
+}
+
+// Test decimals are accepted as JSON strings or numbers, and transactions
+// render their amounts with their currency's scale
+func TestWholeToMinor(t *testing.T) {
+	tests := []struct {
+		whole      int64
+		minorUnits int
+		expected   int64
+		wantErr    bool
+	}{
+		{12, 2, 1200, false},
+		{-3, 2, -300, false},
+		{10000, 0, 10000, false},
+		{math.MaxInt64 / 10, 2, 0, true},
+		{math.MaxInt64, 0, math.MaxInt64, false},
+	}
+
+	for _, tt := range tests {
+		got, err := WholeToMinor(tt.whole, tt.minorUnits)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidAmount) {
+				t.Errorf("WholeToMinor(%d, %d): expected ErrInvalidAmount, got %v", tt.whole, tt.minorUnits, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.expected {
+			t.Errorf("WholeToMinor(%d, %d): expected %d, got %d (%v)", tt.whole, tt.minorUnits, tt.expected, got, err)
+		}
+	}
+
+	if got := MinorToWhole(1299, 2); got != 12 {
+		t.Errorf("MinorToWhole(1299, 2): expected 12, got %d", got)
+	}
+	if got := MinorToWhole(-150, 2); got != -1 {
+		t.Errorf("MinorToWhole(-150, 2): expected -1, got %d", got)
+	}
+}
+
+func TestAmountJSON(t *testing.T) {
+	var req struct {
+		A Decimal `json:"a"`
+		B Decimal `json:"b"`
+	}
+	if err := json.Unmarshal([]byte(`{"a": "0.50", "b": 12.34}`), &req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.A != "0.50" || req.B != "12.34" {
+		t.Errorf("expected 0.50 and 12.34, got %s and %s", req.A, req.B)
+	}
+	if err := json.Unmarshal([]byte(`{"a": true}`), &req); err == nil {
+		t.Error("expected a boolean amount to be rejected")
+	}
+
+	data, err := json.Marshal(&Transaction{Currency: CurrencySC, Type: TransactionTypeWinSC, Amount: 50, BalanceAfter: 1250})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), `"amount":"0.50"`) || !strings.Contains(string(data), `"balance_after":"12.50"`) {
+		t.Errorf("expected decimal amounts, got %s", data)
+	}
+}
+
+// Test configuration amounts are read and rendered as decimals of their currency
+func TestConfigAmountJSON(t *testing.T) {
+	var pool JackpotPool
+	if err := json.Unmarshal([]byte(`{"name": "Mini", "currency": "SC", "min_stake": "0.50", "seed_amount": "100", "enabled": true}`), &pool); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pool.Name != "Mini" || !pool.Enabled || pool.MinStake != 50 || pool.SeedAmount != 10000 {
+		t.Errorf("unexpected pool %+v", pool)
+	}
+	data, err := json.Marshal(pool)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), `"min_stake":"0.50"`) || !strings.Contains(string(data), `"seed_amount":"100.00"`) {
+		t.Errorf("expected decimal amounts, got %s", data)
+	}
+
+	err = json.Unmarshal([]byte(`{"currency": "SC", "entry_fee": "1", "prizes": ["50", "25.005"]}`), &Tournament{})
+	if !errors.Is(err, ErrInvalidAmount) || !strings.Contains(err.Error(), "prizes[1]") {
+		t.Errorf("expected a too precise prize to be rejected by name, got %v", err)
+	}
+
+	var campaign BonusCampaign
+	if err := json.Unmarshal([]byte(`{"budget_sc": "10000", "per_user_cap_sc": 5, "issued_sc": "999"}`), &campaign); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if campaign.BudgetSC != 1000000 || campaign.PerUserCapSC != 500 || campaign.IssuedSC != 0 {
+		t.Errorf("expected SC budget and cap in minor units and issued ignored, got %+v", campaign)
+	}
+
+	// Percent promo codes hold whole percentages, not coin amounts
+	var promo PromoCode
+	if err := json.Unmarshal([]byte(`{"kind": "percent", "value_gc": "20", "value_sc": "10"}`), &promo); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if promo.ValueGC != 20 || promo.ValueSC != 10 {
+		t.Errorf("expected percentages 20 and 10, got %d and %d", promo.ValueGC, promo.ValueSC)
+	}
+	if err := json.Unmarshal([]byte(`{"kind": "fixed", "value_sc": "2.50"}`), &promo); err != nil || promo.ValueSC != 250 {
+		t.Errorf("expected 250 minor units, got %d, %v", promo.ValueSC, err)
+	}
+
+	// A summary keeps the session's fields and renders every amount
+	session := GameSession{ID: 3, SCStaked: 150, SCWon: 25}
+	summary := session.Summary(time.Now())
+	data, err = json.Marshal(summary)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{`"id":3`, `"sc_staked":"1.50"`, `"net_sc":"-1.25"`, `"duration_seconds":`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %s in %s", want, data)
+		}
+	}
+}
+
+// Test spend caps are read as dollars, falling back to the deprecated cents
+func TestSpendLimitDefaultsJSON(t *testing.T) {
+	var d SpendLimitDefaults
+	if err := json.Unmarshal([]byte(`{"daily": "1000.00", "weekly_cents": 250000}`), &d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.DailyCents != 100000 || d.WeeklyCents != 250000 || d.MonthlyCents != 0 {
+		t.Errorf("unexpected defaults %+v", d)
+	}
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), `"daily":"1000.00"`) || !strings.Contains(string(data), `"daily_cents":100000`) {
+		t.Errorf("expected dollars next to cents, got %s", data)
+	}
+}
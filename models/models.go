@@ -5,30 +5,72 @@ import (
 	"time"
 )
 
-// Currency represents the type of currency
+// Currency is the code of a currency in the currency registry
 type Currency string
 
+// Built-in currencies, which have dedicated transaction types
 const (
 	CurrencyGC Currency = "GC" // Gold Coins
 	CurrencySC Currency = "SC" // Sweeps Coins
 )
 
+// CurrencyInfo is a currency registry entry
+type CurrencyInfo struct {
+	Code        Currency  `json:"code"`
+	Name        string    `json:"name"`
+	MinorUnits  int       `json:"minor_units"` // decimal places of the smallest unit
+	Purchasable bool      `json:"purchasable"` // can be bought in packages
+	Wagerable   bool      `json:"wagerable"`   // can be staked in games
+	Redeemable  bool      `json:"redeemable"`  // can be redeemed for prizes
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
 // TransactionType represents the type of transaction
 type TransactionType string
 
 const (
-	TransactionTypePurchase TransactionType = "purchase"
-	TransactionTypeWagerGC  TransactionType = "wager_gc"
-	TransactionTypeWinGC    TransactionType = "win_gc"
-	TransactionTypeWagerSC  TransactionType = "wager_sc"
-	TransactionTypeWinSC    TransactionType = "win_sc"
-	TransactionTypeRedeemSC TransactionType = "redeem_sc"
+	TransactionTypePurchase        TransactionType = "purchase"
+	TransactionTypeWagerGC         TransactionType = "wager_gc"
+	TransactionTypeWinGC           TransactionType = "win_gc"
+	TransactionTypeWagerSC         TransactionType = "wager_sc"
+	TransactionTypeWinSC           TransactionType = "win_sc"
+	TransactionTypeRedeemSC        TransactionType = "redeem_sc"
+	TransactionTypeRefundGC        TransactionType = "refund_gc"
+	TransactionTypeRefundSC        TransactionType = "refund_sc"
+	TransactionTypeJackpotGC       TransactionType = "jackpot_gc"
+	TransactionTypeJackpotSC       TransactionType = "jackpot_sc"
+	TransactionTypeTournamentEntry TransactionType = "tournament_entry"
+	TransactionTypeTournamentPrize TransactionType = "tournament_prize"
+	TransactionTypeBonusGC         TransactionType = "bonus_gc"
+	TransactionTypeBonusSC         TransactionType = "bonus_sc"
+	TransactionTypeAMOESC          TransactionType = "amoe_sc"
+	TransactionTypePromoGC         TransactionType = "promo_gc"
+	TransactionTypePromoSC         TransactionType = "promo_sc"
+	TransactionTypeExpireSC        TransactionType = "expire_sc"
+	TransactionTypeAdjustment      TransactionType = "adjustment"
+	TransactionTypeCorrection      TransactionType = "correction"
+	TransactionTypeReferralGC      TransactionType = "referral_gc"
+	TransactionTypeReferralSC      TransactionType = "referral_sc"
+	TransactionTypeWager           TransactionType = "wager"
+	TransactionTypeWin             TransactionType = "win"
+	TransactionTypeRefund          TransactionType = "refund"
+	TransactionTypeJackpot         TransactionType = "jackpot"
+	TransactionTypeRedeem          TransactionType = "redeem"
 )
 
+// IsValid reports whether the transaction type has a posting rule
+func (t TransactionType) IsValid() bool {
+	_, ok := PostingRules[t]
+	return ok
+}
+
 // User represents a user account
 type User struct {
 	ID        int       `json:"id"`
 	Username  string    `json:"username"`
+	TimeZone  string    `json:"time_zone"` // IANA name, used for calendar-day rules
+	VIPTier   int       `json:"vip_tier"`  // 0 means not VIP
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -38,8 +80,8 @@ type Transaction struct {
 	UserID       int             `json:"user_id"`
 	Currency     Currency        `json:"currency"`
 	Type         TransactionType `json:"type"`
-	Amount       int64           `json:"amount"`
-	BalanceAfter int64           `json:"balance_after"`
+	Amount       int64           `json:"amount"`        // in the currency's minor units
+	BalanceAfter int64           `json:"balance_after"` // in the currency's minor units
 	Metadata     json.RawMessage `json:"metadata,omitempty"`
 	CreatedAt    time.Time       `json:"created_at"`
 }
@@ -47,20 +89,398 @@ type Transaction struct {
 // UserWithBalances represents a user with their current balances and stats
 type UserWithBalances struct {
 	User
-	GoldBalance     int64 `json:"gold_balance"`
-	SweepsBalance   int64 `json:"sweeps_balance"`
-	TotalGCWagered  int64 `json:"total_gc_wagered"`
-	TotalGCWon      int64 `json:"total_gc_won"`
-	TotalSCWagered  int64 `json:"total_sc_wagered"`
-	TotalSCWon      int64 `json:"total_sc_won"`
-	TotalSCRedeemed int64 `json:"total_sc_redeemed"`
+	Balances        map[Currency]int64         `json:"balances"` // every registered currency
+	Stats           map[Currency]CurrencyStats `json:"stats"`    // every registered currency
+	GoldBalance     int64                      `json:"gold_balance"`
+	SweepsBalance   int64                      `json:"sweeps_balance"`
+	TotalGCWagered  int64                      `json:"total_gc_wagered"`
+	TotalGCWon      int64                      `json:"total_gc_won"`
+	TotalSCWagered  int64                      `json:"total_sc_wagered"`
+	TotalSCWon      int64                      `json:"total_sc_won"`
+	TotalSCRedeemed int64                      `json:"total_sc_redeemed"`
 }
 
+// CurrencyStats are a user's lifetime totals in one currency, in its minor units
+type CurrencyStats struct {
+	Wagered  int64 `json:"wagered"`
+	Won      int64 `json:"won"`
+	Redeemed int64 `json:"redeemed"`
+}
+
+// PackageOffer restricts a package to a segment of users
+type PackageOffer string
+
+const (
+	OfferFirstPurchase PackageOffer = "first_purchase" // users who never purchased
+	OfferDaily         PackageOffer = "daily"          // once per calendar day in the user's time zone
+	OfferWinBack       PackageOffer = "win_back"       // users inactive for 30 days
+	OfferVIP           PackageOffer = "vip"            // users at or above MinVIPTier
+)
+
 // Package represents a purchasable package
 type Package struct {
-	Code       string `json:"code"`
-	GoldCoins  int64  `json:"gold_coins"`
-	SweepCoins int64  `json:"sweep_coins"`
+	Code       string             `json:"code"`
+	GoldCoins  int64              `json:"gold_coins"`
+	SweepCoins int64              `json:"sweep_coins"`
+	Coins      map[Currency]int64 `json:"coins,omitempty"` // further registered currencies, in minor units
+	PriceCents int64              `json:"price_cents"`     // USD
+	Offer      PackageOffer       `json:"offer,omitempty"` // empty for packages everyone can buy
+	MinVIPTier int                `json:"min_vip_tier,omitempty"`
+}
+
+// Contents returns the amount of every currency the package includes
+func (p Package) Contents() map[Currency]int64 {
+	contents := make(map[Currency]int64, len(p.Coins)+2)
+	for currency, amount := range p.Coins {
+		if amount != 0 {
+			contents[currency] = amount
+		}
+	}
+	if p.GoldCoins != 0 {
+		contents[CurrencyGC] = p.GoldCoins
+	}
+	if p.SweepCoins != 0 {
+		contents[CurrencySC] = p.SweepCoins
+	}
+	return contents
+}
+
+// SpendLimitPeriod is the rolling window a purchase spend limit covers
+type SpendLimitPeriod string
+
+const (
+	SpendLimitDaily   SpendLimitPeriod = "daily"   // last 24 hours
+	SpendLimitWeekly  SpendLimitPeriod = "weekly"  // last 7 days
+	SpendLimitMonthly SpendLimitPeriod = "monthly" // last 30 days
+)
+
+// SpendLimitPeriods lists every period in ascending length
+var SpendLimitPeriods = []SpendLimitPeriod{SpendLimitDaily, SpendLimitWeekly, SpendLimitMonthly}
+
+// Window returns the length of the period's rolling window, or 0 if the period is unknown
+func (p SpendLimitPeriod) Window() time.Duration {
+	switch p {
+	case SpendLimitDaily:
+		return 24 * time.Hour
+	case SpendLimitWeekly:
+		return 7 * 24 * time.Hour
+	case SpendLimitMonthly:
+		return 30 * 24 * time.Hour
+	}
+	return 0
+}
+
+// SpendLimit is a player's own purchase spend limit for one period. Amounts are
+// USD cents and 0 means no limit. An increase waits out a cooling-off period
+// as the pending limit.
+type SpendLimit struct {
+	UserID             int              `json:"user_id"`
+	Period             SpendLimitPeriod `json:"period"`
+	LimitCents         int64            `json:"limit_cents"`
+	PendingLimitCents  *int64           `json:"pending_limit_cents,omitempty"`
+	PendingEffectiveAt *time.Time       `json:"pending_effective_at,omitempty"`
+	UpdatedAt          time.Time        `json:"updated_at"`
+}
+
+// ApplyDue promotes the pending limit once its cooling-off period has passed
+// and reports whether it did
+func (l *SpendLimit) ApplyDue(now time.Time) bool {
+	if l.PendingEffectiveAt == nil || now.Before(*l.PendingEffectiveAt) {
+		return false
+	}
+	l.LimitCents = *l.PendingLimitCents
+	l.PendingLimitCents = nil
+	l.PendingEffectiveAt = nil
+	return true
+}
+
+// SpendLimitDefaults are operator-wide caps applied to every player on top of
+// their own limits; 0 means no cap
+type SpendLimitDefaults struct {
+	DailyCents   int64     `json:"daily_cents"`
+	WeeklyCents  int64     `json:"weekly_cents"`
+	MonthlyCents int64     `json:"monthly_cents"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Cap returns the operator cap for a period
+func (d *SpendLimitDefaults) Cap(period SpendLimitPeriod) int64 {
+	switch period {
+	case SpendLimitDaily:
+		return d.DailyCents
+	case SpendLimitWeekly:
+		return d.WeeklyCents
+	case SpendLimitMonthly:
+		return d.MonthlyCents
+	}
+	return 0
+}
+
+// SpendLimitStatus is a period's limits together with what was spent under them
+type SpendLimitStatus struct {
+	SpendLimit
+	OperatorCapCents    int64  `json:"operator_cap_cents"`
+	EffectiveLimitCents int64  `json:"effective_limit_cents"` // the lower of the player limit and the operator cap
+	SpentCents          int64  `json:"spent_cents"`
+	RemainingCents      *int64 `json:"remaining_cents,omitempty"` // nil when there is no effective limit
+}
+
+// SpendLimitChange records a requested change of a player's spend limit
+type SpendLimitChange struct {
+	ID            int              `json:"id"`
+	UserID        int              `json:"user_id"`
+	Period        SpendLimitPeriod `json:"period"`
+	OldLimitCents int64            `json:"old_limit_cents"`
+	NewLimitCents int64            `json:"new_limit_cents"`
+	RequestedAt   time.Time        `json:"requested_at"`
+	EffectiveAt   time.Time        `json:"effective_at"`
+}
+
+// WagerLimitKind is what a wager limit caps
+type WagerLimitKind string
+
+const (
+	WagerLimitMaxStake     WagerLimitKind = "max_stake"     // stake of a single wager
+	WagerLimitDailyWagered WagerLimitKind = "daily_wagered" // total staked per UTC day
+	WagerLimitDailyLoss    WagerLimitKind = "daily_loss"    // staked minus won per UTC day
+)
+
+// WagerLimitKinds lists every wager limit kind
+var WagerLimitKinds = []WagerLimitKind{WagerLimitMaxStake, WagerLimitDailyWagered, WagerLimitDailyLoss}
+
+// IsValid checks if the wager limit kind is valid
+func (k WagerLimitKind) IsValid() bool {
+	return k == WagerLimitMaxStake || k == WagerLimitDailyWagered || k == WagerLimitDailyLoss
+}
+
+// WagerLimit is a player's own wager or loss limit for one currency. 0 means
+// no limit. An increase waits out a cooling-off period as the pending limit.
+type WagerLimit struct {
+	UserID             int            `json:"user_id"`
+	Currency           Currency       `json:"currency"`
+	Kind               WagerLimitKind `json:"kind"`
+	Limit              int64          `json:"limit"`
+	PendingLimit       *int64         `json:"pending_limit,omitempty"`
+	PendingEffectiveAt *time.Time     `json:"pending_effective_at,omitempty"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+}
+
+// ApplyDue promotes the pending limit once its cooling-off period has passed
+// and reports whether it did
+func (l *WagerLimit) ApplyDue(now time.Time) bool {
+	if l.PendingEffectiveAt == nil || now.Before(*l.PendingEffectiveAt) {
+		return false
+	}
+	l.Limit = *l.PendingLimit
+	l.PendingLimit = nil
+	l.PendingEffectiveAt = nil
+	return true
+}
+
+// WagerTotals are a user's running wager totals in one currency for one day
+type WagerTotals struct {
+	Wagered int64 `json:"wagered"` // stakes less refunds
+	Won     int64 `json:"won"`
+}
+
+// NetLoss returns how much more was staked than won, or 0 if the user is ahead
+func (t WagerTotals) NetLoss() int64 {
+	return max(t.Wagered-t.Won, 0)
+}
+
+// WagerLimitStatus is a wager limit together with today's usage under it
+type WagerLimitStatus struct {
+	WagerLimit
+	Used      int64  `json:"used"`                // today's total for daily limits; 0 for max_stake
+	Remaining *int64 `json:"remaining,omitempty"` // nil when there is no limit
+}
+
+// WagerLimitChange records a requested change of a player's wager limit
+type WagerLimitChange struct {
+	ID          int            `json:"id"`
+	UserID      int            `json:"user_id"`
+	Currency    Currency       `json:"currency"`
+	Kind        WagerLimitKind `json:"kind"`
+	OldLimit    int64          `json:"old_limit"`
+	NewLimit    int64          `json:"new_limit"`
+	RequestedAt time.Time      `json:"requested_at"`
+	EffectiveAt time.Time      `json:"effective_at"`
+}
+
+// SelfExclusionPeriod is how long a player excludes themselves for
+type SelfExclusionPeriod string
+
+const (
+	SelfExclusion24Hours   SelfExclusionPeriod = "24h"
+	SelfExclusion7Days     SelfExclusionPeriod = "7d"
+	SelfExclusion6Months   SelfExclusionPeriod = "6m"
+	SelfExclusionPermanent SelfExclusionPeriod = "permanent"
+)
+
+// IsValid checks if the self-exclusion period is valid
+func (p SelfExclusionPeriod) IsValid() bool {
+	switch p {
+	case SelfExclusion24Hours, SelfExclusion7Days, SelfExclusion6Months, SelfExclusionPermanent:
+		return true
+	}
+	return false
+}
+
+// EndsAt returns when an exclusion starting at start ends, or nil if it never does
+func (p SelfExclusionPeriod) EndsAt(start time.Time) *time.Time {
+	var end time.Time
+	switch p {
+	case SelfExclusion24Hours:
+		end = start.Add(24 * time.Hour)
+	case SelfExclusion7Days:
+		end = start.AddDate(0, 0, 7)
+	case SelfExclusion6Months:
+		end = start.AddDate(0, 6, 0)
+	default:
+		return nil
+	}
+	return &end
+}
+
+// SelfExclusion blocks a player from purchasing and wagering until it ends
+type SelfExclusion struct {
+	ID        int                 `json:"id"`
+	UserID    int                 `json:"user_id"`
+	Period    SelfExclusionPeriod `json:"period"`
+	StartsAt  time.Time           `json:"starts_at"`
+	EndsAt    *time.Time          `json:"ends_at,omitempty"`    // nil for permanent exclusions
+	ExpiredAt *time.Time          `json:"expired_at,omitempty"` // set by the expiry job
+	CreatedAt time.Time           `json:"created_at"`
+}
+
+// IsActive reports whether the exclusion is in force at now
+func (e *SelfExclusion) IsActive(now time.Time) bool {
+	return !now.Before(e.StartsAt) && (e.EndsAt == nil || now.Before(*e.EndsAt))
+}
+
+// OutlastedBy reports whether other ends later than e
+func (e *SelfExclusion) OutlastedBy(other *SelfExclusion) bool {
+	if e.EndsAt == nil {
+		return false
+	}
+	return other.EndsAt == nil || other.EndsAt.After(*e.EndsAt)
+}
+
+// SelfExclusionStatus is a player's current exclusion, if any, and their history
+type SelfExclusionStatus struct {
+	Excluded bool                  `json:"excluded"`
+	Current  *SelfExclusion        `json:"current,omitempty"` // the active exclusion ending last
+	History  []SelfExclusion       `json:"history"`
+	Audit    []SelfExclusionAction `json:"audit"`
+}
+
+// SelfExclusionAction is an audit record of an exclusion starting or expiring
+type SelfExclusionAction struct {
+	ID          int       `json:"id"`
+	ExclusionID int       `json:"exclusion_id"`
+	UserID      int       `json:"user_id"`
+	Action      string    `json:"action"`
+	Actor       string    `json:"actor"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// GameSessionSettings are a player's preferences for their play sessions
+type GameSessionSettings struct {
+	UserID              int       `json:"user_id"`
+	RealityCheckMinutes int       `json:"reality_check_minutes"` // 0 disables reality checks
+	LossCapGC           int64     `json:"loss_cap_gc"`           // 0 means no cap
+	LossCapSC           int64     `json:"loss_cap_sc"`           // 0 means no cap
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// GameSession is a run of wagers without a long break, with the settings that
+// were in effect when it opened
+type GameSession struct {
+	ID                  int        `json:"id"`
+	UserID              int        `json:"user_id"`
+	StartedAt           time.Time  `json:"started_at"`
+	LastActivityAt      time.Time  `json:"last_activity_at"`
+	EndedAt             *time.Time `json:"ended_at,omitempty"` // nil while the session is open
+	Wagers              int        `json:"wagers"`
+	GCStaked            int64      `json:"gc_staked"`
+	GCWon               int64      `json:"gc_won"`
+	SCStaked            int64      `json:"sc_staked"`
+	SCWon               int64      `json:"sc_won"`
+	LossCapGC           int64      `json:"loss_cap_gc"`
+	LossCapSC           int64      `json:"loss_cap_sc"`
+	RealityCheckMinutes int        `json:"reality_check_minutes"`
+	RealityCheckAt      time.Time  `json:"reality_check_at"` // session start or last acknowledgement
+	RealityChecks       int        `json:"reality_checks"`   // acknowledgements so far
+}
+
+// Totals returns what was staked and won in currency during the session
+func (s *GameSession) Totals(currency Currency) WagerTotals {
+	if currency == CurrencyGC {
+		return WagerTotals{Wagered: s.GCStaked, Won: s.GCWon}
+	}
+	return WagerTotals{Wagered: s.SCStaked, Won: s.SCWon}
+}
+
+// LossCap returns the session's loss cap in currency, 0 if there is none
+func (s *GameSession) LossCap(currency Currency) int64 {
+	if currency == CurrencyGC {
+		return s.LossCapGC
+	}
+	return s.LossCapSC
+}
+
+// NextRealityCheckAt returns when the next reality check falls due, or nil if
+// reality checks are disabled
+func (s *GameSession) NextRealityCheckAt() *time.Time {
+	if s.RealityCheckMinutes <= 0 {
+		return nil
+	}
+	next := s.RealityCheckAt.Add(time.Duration(s.RealityCheckMinutes) * time.Minute)
+	return &next
+}
+
+// RealityCheckDue reports whether the player must acknowledge a reality check
+// before wagering again
+func (s *GameSession) RealityCheckDue(now time.Time) bool {
+	next := s.NextRealityCheckAt()
+	return next != nil && !now.Before(*next)
+}
+
+// Summary returns the session as shown to the player at now
+func (s *GameSession) Summary(now time.Time) GameSessionSummary {
+	end := now
+	if s.EndedAt != nil {
+		end = *s.EndedAt
+	}
+
+	summary := GameSessionSummary{
+		GameSession:     *s,
+		DurationSeconds: int64(end.Sub(s.StartedAt).Seconds()),
+		NetGC:           s.GCWon - s.GCStaked,
+		NetSC:           s.SCWon - s.SCStaked,
+	}
+	if s.EndedAt == nil {
+		summary.RealityCheckDue = s.RealityCheckDue(now)
+		summary.NextRealityCheckAt = s.NextRealityCheckAt()
+	}
+	return summary
+}
+
+// GameSessionSummary is a session with its duration and net results
+type GameSessionSummary struct {
+	GameSession
+	DurationSeconds    int64      `json:"duration_seconds"`
+	NetGC              int64      `json:"net_gc"` // won minus staked; negative is a loss
+	NetSC              int64      `json:"net_sc"`
+	RealityCheckDue    bool       `json:"reality_check_due"`
+	NextRealityCheckAt *time.Time `json:"next_reality_check_at,omitempty"`
+}
+
+// PurchaseHistory summarizes what offer eligibility depends on
+type PurchaseHistory struct {
+	HasPurchased   bool       // any purchase ever
+	LastActivityAt *time.Time // latest transaction of any type
+	BoughtToday    []string   // package codes purchased since the start of the user's day
 }
 
 // Available packages
@@ -68,22 +488,611 @@ var Packages = map[string]Package{
 	"starter_10k": {
 		Code:       "starter_10k",
 		GoldCoins:  10000,
-		SweepCoins: 10,
+		SweepCoins: 1000,
+		PriceCents: 999,
 	},
 	"grinder_50k": {
 		Code:       "grinder_50k",
 		GoldCoins:  50000,
-		SweepCoins: 50,
+		SweepCoins: 5000,
+		PriceCents: 4999,
 	},
 	"highroller_250k": {
 		Code:       "highroller_250k",
 		GoldCoins:  250000,
-		SweepCoins: 250,
+		SweepCoins: 25000,
+		PriceCents: 24999,
+	},
+	"first_purchase_25k": {
+		Code:       "first_purchase_25k",
+		GoldCoins:  25000,
+		SweepCoins: 2500,
+		PriceCents: 999,
+		Offer:      OfferFirstPurchase,
+	},
+	"daily_deal_20k": {
+		Code:       "daily_deal_20k",
+		GoldCoins:  20000,
+		SweepCoins: 1500,
+		PriceCents: 999,
+		Offer:      OfferDaily,
+	},
+	"welcome_back_30k": {
+		Code:       "welcome_back_30k",
+		GoldCoins:  30000,
+		SweepCoins: 3000,
+		PriceCents: 1499,
+		Offer:      OfferWinBack,
+	},
+	"vip_500k": {
+		Code:       "vip_500k",
+		GoldCoins:  500000,
+		SweepCoins: 60000,
+		PriceCents: 49999,
+		Offer:      OfferVIP,
+		MinVIPTier: 1,
 	},
 }
 
+// Game represents a registered game that wagers can be placed on
+type Game struct {
+	ID         string         `json:"id"`
+	Provider   string         `json:"provider"`
+	Name       string         `json:"name"`
+	Currencies []GameCurrency `json:"currencies"`
+	RTP        float64        `json:"rtp"` // theoretical return to player, in percent
+	Enabled    bool           `json:"enabled"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+// GameCurrency is a currency a game can be played in, with its stake limits
+type GameCurrency struct {
+	Currency Currency `json:"currency"`
+	MinStake int64    `json:"min_stake"`
+	MaxStake int64    `json:"max_stake"` // 0 means no upper limit
+}
+
+// CurrencyLimits returns the stake limits for a currency, if the game allows it
+func (g *Game) CurrencyLimits(currency Currency) (GameCurrency, bool) {
+	for _, c := range g.Currencies {
+		if c.Currency == currency {
+			return c, true
+		}
+	}
+	return GameCurrency{}, false
+}
+
+// JackpotPool is a progressive jackpot funded by a share of qualifying stakes
+type JackpotPool struct {
+	ID              string    `json:"id"`
+	Name            string    `json:"name"`
+	Currency        Currency  `json:"currency"`
+	ContributionBPS int64     `json:"contribution_bps"` // share of each stake, in basis points (100 = 1%)
+	MinStake        int64     `json:"min_stake"`        // smallest stake that contributes
+	SeedAmount      int64     `json:"seed_amount"`      // value the pool resets to after a win
+	Amount          int64     `json:"amount"`           // current pool value
+	Enabled         bool      `json:"enabled"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// JackpotContribution is the share of one stake added to a jackpot pool
+type JackpotContribution struct {
+	ID              int       `json:"id"`
+	PoolID          string    `json:"pool_id"`
+	UserID          int       `json:"user_id"`
+	TransactionID   int       `json:"transaction_id"`
+	Currency        Currency  `json:"currency"` // the pool's currency
+	Stake           int64     `json:"stake"`
+	Amount          int64     `json:"amount"`
+	PoolAmountAfter int64     `json:"pool_amount_after"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// JackpotContributionList represents a paginated list of jackpot contributions
+type JackpotContributionList struct {
+	Items      []JackpotContribution `json:"items"`
+	NextCursor *string               `json:"next_cursor,omitempty"`
+}
+
+// TournamentScoring is how tournament entries are ranked
+type TournamentScoring string
+
+const (
+	// TournamentScoringTotalWagered scores the sum of tagged stakes
+	TournamentScoringTotalWagered TournamentScoring = "total_wagered"
+	// TournamentScoringBiggestMultiplier scores the best payout/stake ratio of a
+	// single tagged wager, in hundredths (a 12.5x win scores 1250)
+	TournamentScoringBiggestMultiplier TournamentScoring = "biggest_multiplier"
+)
+
+// IsValid reports whether the scoring rule is supported
+func (s TournamentScoring) IsValid() bool {
+	return s == TournamentScoringTotalWagered || s == TournamentScoringBiggestMultiplier
+}
+
+// Tournament is a timed competition scored from wagers tagged with its ID
+type Tournament struct {
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	Currency  Currency          `json:"currency"`  // currency of the entry fee, scored wagers and prizes
+	EntryFee  int64             `json:"entry_fee"` // 0 for a free tournament
+	Scoring   TournamentScoring `json:"scoring"`
+	Prizes    []int64           `json:"prizes"` // prize by rank, first place first
+	StartsAt  time.Time         `json:"starts_at"`
+	EndsAt    time.Time         `json:"ends_at"`
+	SettledAt *time.Time        `json:"settled_at,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// IsRunning reports whether wagers placed at now count towards the tournament
+func (t *Tournament) IsRunning(now time.Time) bool {
+	return t.SettledAt == nil && !now.Before(t.StartsAt) && now.Before(t.EndsAt)
+}
+
+// Prize returns the prize for a 1-based rank, or 0 if the rank is not paid
+func (t *Tournament) Prize(rank int) int64 {
+	if rank < 1 || rank > len(t.Prizes) {
+		return 0
+	}
+	return t.Prizes[rank-1]
+}
+
+// TournamentEntry is a user's participation and score in a tournament
+type TournamentEntry struct {
+	TournamentID       string    `json:"tournament_id"`
+	UserID             int       `json:"user_id"`
+	Currency           Currency  `json:"currency"` // the tournament's currency
+	Score              int64     `json:"score"`
+	Rank               int       `json:"rank,omitempty"`
+	Prize              int64     `json:"prize,omitempty"` // projected until the tournament is settled
+	EntryTransactionID *int      `json:"entry_transaction_id,omitempty"`
+	PrizeTransactionID *int      `json:"prize_transaction_id,omitempty"`
+	JoinedAt           time.Time `json:"joined_at"`
+	ScoreUpdatedAt     time.Time `json:"score_updated_at"`
+}
+
+// Leaderboard ranks the entries of a tournament
+type Leaderboard struct {
+	Tournament *Tournament       `json:"tournament"`
+	Entries    []TournamentEntry `json:"entries"`
+}
+
+// BonusCampaign is a marketing campaign that bonus coins are granted under
+type BonusCampaign struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	BudgetGC     int64      `json:"budget_gc"`       // 0 means no budget limit
+	BudgetSC     int64      `json:"budget_sc"`       // 0 means no budget limit
+	PerUserCapGC int64      `json:"per_user_cap_gc"` // 0 means no cap
+	PerUserCapSC int64      `json:"per_user_cap_sc"` // 0 means no cap
+	IssuedGC     int64      `json:"issued_gc"`
+	IssuedSC     int64      `json:"issued_sc"`
+	StartsAt     *time.Time `json:"starts_at,omitempty"`
+	EndsAt       *time.Time `json:"ends_at,omitempty"`
+	Enabled      bool       `json:"enabled"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// IsActive reports whether bonuses can be granted under the campaign at now
+func (c *BonusCampaign) IsActive(now time.Time) bool {
+	if !c.Enabled {
+		return false
+	}
+	if c.StartsAt != nil && now.Before(*c.StartsAt) {
+		return false
+	}
+	return c.EndsAt == nil || now.Before(*c.EndsAt)
+}
+
+// CampaignReport summarizes what a campaign has issued
+type CampaignReport struct {
+	Campaign    *BonusCampaign `json:"campaign"`
+	Grants      int            `json:"grants"`
+	Users       int            `json:"users"`
+	RemainingGC *int64         `json:"remaining_gc,omitempty"` // omitted when the budget is unlimited
+	RemainingSC *int64         `json:"remaining_sc,omitempty"` // omitted when the budget is unlimited
+}
+
+// DailyBonusDay is the reward for one day of a daily login streak
+type DailyBonusDay struct {
+	Day      int   `json:"day"`
+	AmountGC int64 `json:"amount_gc"`
+	AmountSC int64 `json:"amount_sc"`
+}
+
+// DailyBonusClaim is a user's daily login bonus for one local calendar day
+type DailyBonusClaim struct {
+	UserID         int            `json:"user_id"`
+	ClaimDate      string         `json:"claim_date"` // YYYY-MM-DD in the user's time zone
+	Streak         int            `json:"streak"`
+	AmountGC       int64          `json:"amount_gc"`
+	AmountSC       int64          `json:"amount_sc"`
+	AlreadyClaimed bool           `json:"already_claimed"`
+	NextClaimAt    time.Time      `json:"next_claim_at"`
+	ClaimedAt      time.Time      `json:"claimed_at"`
+	Transactions   []*Transaction `json:"transactions"`
+}
+
+// PromoKind is how a promo code's extra coins are calculated
+type PromoKind string
+
+const (
+	PromoKindFixed   PromoKind = "fixed"   // values are coin amounts
+	PromoKindPercent PromoKind = "percent" // values are percentages of the package
+)
+
+// PromoCode grants extra coins when quoted with a package purchase
+type PromoCode struct {
+	Code           string     `json:"code"`
+	Description    string     `json:"description,omitempty"`
+	Kind           PromoKind  `json:"kind"`
+	ValueGC        int64      `json:"value_gc"`
+	ValueSC        int64      `json:"value_sc"`
+	PackageCodes   []string   `json:"package_codes,omitempty"` // empty means any package
+	StartsAt       *time.Time `json:"starts_at,omitempty"`
+	EndsAt         *time.Time `json:"ends_at,omitempty"`
+	MaxRedemptions int        `json:"max_redemptions"` // 0 means unlimited
+	Redemptions    int        `json:"redemptions"`
+	Enabled        bool       `json:"enabled"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// AppliesTo reports whether the code can be used with a package
+func (p *PromoCode) AppliesTo(packageCode string) bool {
+	if len(p.PackageCodes) == 0 {
+		return true
+	}
+	for _, code := range p.PackageCodes {
+		if code == packageCode {
+			return true
+		}
+	}
+	return false
+}
+
+// Bonus returns the extra GC and SC the code grants on a package; percentages
+// round down
+func (p *PromoCode) Bonus(pkg Package) (int64, int64) {
+	if p.Kind == PromoKindPercent {
+		return pkg.GoldCoins * p.ValueGC / 100, pkg.SweepCoins * p.ValueSC / 100
+	}
+	return p.ValueGC, p.ValueSC
+}
+
+// SCExpirySettings configures the expiry of Sweeps Coins on inactive accounts
+type SCExpirySettings struct {
+	InactivityDays int       `json:"inactivity_days"` // days without a transaction before SC expires
+	WarningDays    int       `json:"warning_days"`    // days before expiry the player is warned; 0 disables warnings
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// ExpiryCutoff returns the last-activity time at or before which SC expires at now
+func (s *SCExpirySettings) ExpiryCutoff(now time.Time) time.Time {
+	return now.AddDate(0, 0, -s.InactivityDays)
+}
+
+// WarningCutoff returns the last-activity time at or before which players are
+// warned at now
+func (s *SCExpirySettings) WarningCutoff(now time.Time) time.Time {
+	return s.ExpiryCutoff(now).AddDate(0, 0, s.WarningDays)
+}
+
+// SCExpiryRun is one day's run of the SC expiry job
+type SCExpiryRun struct {
+	ID             int            `json:"id"`
+	RunDate        time.Time      `json:"run_date"`
+	InactivityDays int            `json:"inactivity_days"`
+	Cutoff         time.Time      `json:"cutoff"` // SC of users inactive since this time expires
+	StartedAt      time.Time      `json:"started_at"`
+	FinishedAt     *time.Time     `json:"finished_at,omitempty"` // nil until every user was processed
+	WarningsSent   int            `json:"warnings_sent"`
+	UsersExpired   int            `json:"users_expired"`
+	TotalExpiredSC int64          `json:"total_expired_sc"`
+	Expirations    []SCExpiration `json:"expirations,omitempty"`
+}
+
+// SCExpiration records the SC balance of one user expired by a run
+type SCExpiration struct {
+	RunID          int       `json:"run_id"`
+	UserID         int       `json:"user_id"`
+	AmountSC       int64     `json:"amount_sc"`
+	LastActivityAt time.Time `json:"last_activity_at"`
+	TransactionID  int       `json:"transaction_id"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// SCExpiryWarning tells a player their SC is about to expire
+type SCExpiryWarning struct {
+	ID                int       `json:"id"`
+	UserID            int       `json:"user_id"`
+	RunID             int       `json:"run_id"`
+	LastTransactionID int       `json:"last_transaction_id"`
+	LastActivityAt    time.Time `json:"last_activity_at"`
+	ExpiresAt         time.Time `json:"expires_at"`
+	BalanceSC         int64     `json:"balance_sc"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// InactiveSCBalance is a user holding SC whose last transaction is old
+type InactiveSCBalance struct {
+	UserID            int
+	LastTransactionID int
+	LastActivityAt    time.Time
+	BalanceSC         int64
+	WarnedExpiresAt   *time.Time // expiry announced for this stretch of inactivity, nil if not warned
+}
+
+// AMOESettings configures Alternative Method of Entry requests
+type AMOESettings struct {
+	AmountSC                int64     `json:"amount_sc"`                    // SC credited per approved entry
+	MaxEntriesPerUserPerDay int       `json:"max_entries_per_user_per_day"` // 0 means no limit
+	MaxEntriesPerDay        int       `json:"max_entries_per_day"`          // across all users; 0 means no limit
+	UpdatedAt               time.Time `json:"updated_at"`
+}
+
+// AMOECode is a single-use code a player quotes on an AMOE entry
+type AMOECode struct {
+	Code      string     `json:"code"`
+	UserID    int        `json:"user_id"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+}
+
+// AMOEMethod is how an AMOE entry was received
+type AMOEMethod string
+
+const (
+	AMOEMethodMail AMOEMethod = "mail"
+	AMOEMethodWeb  AMOEMethod = "web"
+)
+
+// AMOEStatus is the review state of an AMOE entry
+type AMOEStatus string
+
+const (
+	AMOEStatusPending  AMOEStatus = "pending"
+	AMOEStatusApproved AMOEStatus = "approved"
+	AMOEStatusRejected AMOEStatus = "rejected"
+)
+
+// AMOEEntry is a free SC request awaiting or past review
+type AMOEEntry struct {
+	ID            int              `json:"id"`
+	Code          string           `json:"code"`
+	UserID        int              `json:"user_id"`
+	Method        AMOEMethod       `json:"method"`
+	Status        AMOEStatus       `json:"status"`
+	AmountSC      int64            `json:"amount_sc"`
+	SubmittedBy   string           `json:"submitted_by"`
+	SubmittedAt   time.Time        `json:"submitted_at"`
+	ReviewedBy    *string          `json:"reviewed_by,omitempty"`
+	ReviewedAt    *time.Time       `json:"reviewed_at,omitempty"`
+	ReviewNote    string           `json:"review_note,omitempty"`
+	TransactionID *int             `json:"transaction_id,omitempty"`
+	Audit         []AMOEAuditEvent `json:"audit,omitempty"`
+}
+
+// AMOEAuditEvent records an action taken on an AMOE entry and who took it
+type AMOEAuditEvent struct {
+	ID        int       `json:"id"`
+	EntryID   int       `json:"entry_id"`
+	Action    string    `json:"action"`
+	Actor     string    `json:"actor"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AMOEEntryList represents a paginated list of AMOE entries
+type AMOEEntryList struct {
+	Items      []AMOEEntry `json:"items"`
+	NextCursor *string     `json:"next_cursor,omitempty"`
+}
+
 // TransactionList represents a paginated list of transactions
 type TransactionList struct {
 	Items      []Transaction `json:"items"`
 	NextCursor *string       `json:"next_cursor,omitempty"`
 }
+
+// WagerLeg is the stake and payout of a wager in one currency, in its minor units
+type WagerLeg struct {
+	Currency Currency `json:"currency"`
+	Stake    int64    `json:"stake,omitempty"`
+	Payout   int64    `json:"payout,omitempty"`
+}
+
+// CoinWagerLegs returns the legs of a wager given as Gold Coin and Sweeps Coin
+// amounts, leaving out a currency with neither a stake nor a payout
+func CoinWagerLegs(stakeGC, payoutGC, stakeSC, payoutSC int64) []WagerLeg {
+	var legs []WagerLeg
+	if stakeGC != 0 || payoutGC != 0 {
+		legs = append(legs, WagerLeg{Currency: CurrencyGC, Stake: stakeGC, Payout: payoutGC})
+	}
+	if stakeSC != 0 || payoutSC != 0 {
+		legs = append(legs, WagerLeg{Currency: CurrencySC, Stake: stakeSC, Payout: payoutSC})
+	}
+	return legs
+}
+
+// WagerBatchItem is a single wager within a batch, possibly for any user
+type WagerBatchItem struct {
+	UserID         int        `json:"user_id"`
+	GameID         string     `json:"game_id"`
+	RoundID        string     `json:"round_id,omitempty"`
+	TournamentID   string     `json:"tournament_id,omitempty"`
+	Legs           []WagerLeg `json:"legs"`
+	IdempotencyKey string     `json:"idempotency_key"`
+}
+
+// WagerBatchStatus is the outcome of one item in a wager batch
+type WagerBatchStatus string
+
+const (
+	WagerBatchStatusSuccess           WagerBatchStatus = "success"
+	WagerBatchStatusDuplicate         WagerBatchStatus = "duplicate"
+	WagerBatchStatusInsufficientFunds WagerBatchStatus = "insufficient_funds"
+	WagerBatchStatusInvalid           WagerBatchStatus = "invalid"
+	WagerBatchStatusUserNotFound      WagerBatchStatus = "user_not_found"
+	WagerBatchStatusError             WagerBatchStatus = "error"
+)
+
+// WagerBatchResult reports the outcome of one item, in request order
+type WagerBatchResult struct {
+	Index          int              `json:"index"`
+	UserID         int              `json:"user_id"`
+	IdempotencyKey string           `json:"idempotency_key"`
+	Status         WagerBatchStatus `json:"status"`
+	Error          string           `json:"error,omitempty"`
+	Transactions   []*Transaction   `json:"transactions,omitempty"`
+}
+
+// WagerBatchSummary counts batch outcomes
+type WagerBatchSummary struct {
+	Total     int `json:"total"`
+	Succeeded int `json:"succeeded"`
+	Duplicate int `json:"duplicate"`
+	Failed    int `json:"failed"`
+}
+
+// WagerBatchResponse is the result of a wager batch
+type WagerBatchResponse struct {
+	Results []WagerBatchResult `json:"results"`
+	Summary WagerBatchSummary  `json:"summary"`
+}
+
+// AdjustmentReason is the mandatory reason code of a manual balance adjustment
+type AdjustmentReason string
+
+const (
+	AdjustmentReasonCorrection   AdjustmentReason = "correction"   // undo a posting mistake
+	AdjustmentReasonGoodwill     AdjustmentReason = "goodwill"     // customer service gesture
+	AdjustmentReasonCompensation AdjustmentReason = "compensation" // make good an outage or game fault
+	AdjustmentReasonChargeback   AdjustmentReason = "chargeback"   // claw back coins of a reversed payment
+	AdjustmentReasonFraud        AdjustmentReason = "fraud"        // remove fraudulently obtained coins
+	AdjustmentReasonOther        AdjustmentReason = "other"
+)
+
+// IsValid checks if the reason code is known
+func (r AdjustmentReason) IsValid() bool {
+	switch r {
+	case AdjustmentReasonCorrection, AdjustmentReasonGoodwill, AdjustmentReasonCompensation,
+		AdjustmentReasonChargeback, AdjustmentReasonFraud, AdjustmentReasonOther:
+		return true
+	}
+	return false
+}
+
+// Adjustment is a manual credit or debit of a player's balance by an admin
+type Adjustment struct {
+	ID            int              `json:"id"`
+	UserID        int              `json:"user_id"`
+	TransactionID int              `json:"transaction_id"`
+	Currency      Currency         `json:"currency"`
+	Amount        int64            `json:"amount"` // positive credits, negative debits
+	Reason        AdjustmentReason `json:"reason_code"`
+	Note          string           `json:"note"`
+	Admin         string           `json:"admin"`
+	ApprovedBy    *string          `json:"approved_by,omitempty"` // second admin, for adjustments above the approval threshold
+	CreatedAt     time.Time        `json:"created_at"`
+}
+
+// AdjustmentFilter narrows an adjustment listing; zero fields match everything
+type AdjustmentFilter struct {
+	UserID *int
+	Reason *AdjustmentReason
+	Admin  string
+}
+
+// AdjustmentList represents a paginated list of adjustments
+type AdjustmentList struct {
+	Items      []Adjustment `json:"items"`
+	NextCursor *string      `json:"next_cursor,omitempty"`
+}
+
+// ApprovalOperation is an operation that needs a second admin's approval above a threshold
+type ApprovalOperation string
+
+const (
+	ApprovalOperationAdjustment ApprovalOperation = "adjustment"
+	ApprovalOperationRedemption ApprovalOperation = "redemption"
+	ApprovalOperationBonus      ApprovalOperation = "bonus"
+)
+
+// IsValid checks if the approval operation is valid
+func (o ApprovalOperation) IsValid() bool {
+	return o == ApprovalOperationAdjustment || o == ApprovalOperationRedemption || o == ApprovalOperationBonus
+}
+
+// ApprovalThreshold makes operations in a currency with an amount above
+// Threshold wait for approval. 0 means no approval is needed.
+type ApprovalThreshold struct {
+	Operation         ApprovalOperation `json:"operation"`
+	Currency          Currency          `json:"currency"`
+	Threshold         int64             `json:"threshold"` // minor units, compared with the absolute amount
+	ExpiresAfterHours int               `json:"expires_after_hours"`
+	UpdatedAt         time.Time         `json:"updated_at"`
+}
+
+// ApprovalStatus is the state of an approval request
+type ApprovalStatus string
+
+const (
+	ApprovalStatusPending  ApprovalStatus = "pending"
+	ApprovalStatusApproved ApprovalStatus = "approved"
+	ApprovalStatusRejected ApprovalStatus = "rejected"
+	ApprovalStatusExpired  ApprovalStatus = "expired"
+)
+
+// IsValid checks if the approval status is valid
+func (s ApprovalStatus) IsValid() bool {
+	switch s {
+	case ApprovalStatusPending, ApprovalStatusApproved, ApprovalStatusRejected, ApprovalStatusExpired:
+		return true
+	}
+	return false
+}
+
+// ApprovalRequest is an adjustment, redemption or bonus grant held for a second
+// admin. It posts to the ledger only once approved.
+type ApprovalRequest struct {
+	ID             int               `json:"id"`
+	Operation      ApprovalOperation `json:"operation"`
+	UserID         int               `json:"user_id"`
+	Currency       Currency          `json:"currency"`
+	Amount         int64             `json:"amount"` // signed for adjustments, positive for redemptions and bonuses
+	Reason         AdjustmentReason  `json:"reason_code,omitempty"`
+	CampaignID     string            `json:"campaign_id,omitempty"` // bonuses only
+	Note           string            `json:"note,omitempty"`
+	RequestedBy    string            `json:"requested_by,omitempty"` // empty for redemptions requested by the player
+	IdempotencyKey string            `json:"idempotency_key"`
+	Status         ApprovalStatus    `json:"status"`
+	CreatedAt      time.Time         `json:"created_at"`
+	ExpiresAt      time.Time         `json:"expires_at"`
+	DecidedBy      *string           `json:"decided_by,omitempty"`
+	DecidedAt      *time.Time        `json:"decided_at,omitempty"`
+	DecisionNote   string            `json:"decision_note,omitempty"`
+	TransactionID  *int              `json:"transaction_id,omitempty"`
+	History        []ApprovalEvent   `json:"history,omitempty"`
+}
+
+// ApprovalEvent records an action taken on an approval request and who took it
+type ApprovalEvent struct {
+	ID        int       `json:"id"`
+	RequestID int       `json:"request_id"`
+	Action    string    `json:"action"`
+	Actor     string    `json:"actor"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ApprovalRequestList represents a paginated list of approval requests
+type ApprovalRequestList struct {
+	Items      []ApprovalRequest `json:"items"`
+	NextCursor *string           `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,181 @@
+package models
+
+import "encoding/json"
+
+// Direction is how a transaction type moves its currency's balance
+type Direction int64
+
+const (
+	Credit Direction = 1
+	Debit  Direction = -1
+)
+
+// Stat is a user statistic a transaction type is counted in
+type Stat string
+
+const (
+	StatNone     Stat = ""
+	StatWagered  Stat = "wagered"
+	StatWon      Stat = "won"
+	StatRedeemed Stat = "redeemed"
+)
+
+// PostingRule describes how a transaction type is posted: which currency it
+// may be posted in, which way it moves the balance and which statistic it
+// counts towards. Balances, statistics and the ledger integrity check are all
+// derived from these rules, so a new transaction type only needs a rule here
+// (and the matching entry in the transactions type check).
+type PostingRule struct {
+	Currency  Currency  // the only currency the type may be posted in; empty for any
+	Direction Direction // effect on the balance
+	Stat      Stat      // statistic the amount is counted in, if any
+	StatSign  int64     // 1 adds to the statistic, -1 takes away from it (refunds)
+	Reserved  bool      // only posted by its own flow, which enforces its rules; never by a generic posting
+	Signed    bool      // the amount carries its own sign: positive moves the balance in Direction, negative against it
+}
+
+// PostingRules holds the rule of every supported transaction type
+var PostingRules = map[TransactionType]PostingRule{
+	TransactionTypePurchase:        {Direction: Credit, Reserved: true},
+	TransactionTypeWagerGC:         {Currency: CurrencyGC, Direction: Debit, Stat: StatWagered, StatSign: 1, Reserved: true},
+	TransactionTypeWinGC:           {Currency: CurrencyGC, Direction: Credit, Stat: StatWon, StatSign: 1, Reserved: true},
+	TransactionTypeWagerSC:         {Currency: CurrencySC, Direction: Debit, Stat: StatWagered, StatSign: 1, Reserved: true},
+	TransactionTypeWinSC:           {Currency: CurrencySC, Direction: Credit, Stat: StatWon, StatSign: 1, Reserved: true},
+	TransactionTypeRedeemSC:        {Currency: CurrencySC, Direction: Debit, Stat: StatRedeemed, StatSign: 1, Reserved: true},
+	TransactionTypeRefundGC:        {Currency: CurrencyGC, Direction: Credit, Stat: StatWagered, StatSign: -1, Reserved: true},
+	TransactionTypeRefundSC:        {Currency: CurrencySC, Direction: Credit, Stat: StatWagered, StatSign: -1, Reserved: true},
+	TransactionTypeJackpotGC:       {Currency: CurrencyGC, Direction: Credit, Stat: StatWon, StatSign: 1, Reserved: true},
+	TransactionTypeJackpotSC:       {Currency: CurrencySC, Direction: Credit, Stat: StatWon, StatSign: 1, Reserved: true},
+	TransactionTypeTournamentEntry: {Direction: Debit, Reserved: true},
+	TransactionTypeTournamentPrize: {Direction: Credit},
+	TransactionTypeBonusGC:         {Currency: CurrencyGC, Direction: Credit, Reserved: true},
+	TransactionTypeBonusSC:         {Currency: CurrencySC, Direction: Credit, Reserved: true},
+	TransactionTypeAMOESC:          {Currency: CurrencySC, Direction: Credit, Reserved: true},
+	TransactionTypePromoGC:         {Currency: CurrencyGC, Direction: Credit, Reserved: true},
+	TransactionTypePromoSC:         {Currency: CurrencySC, Direction: Credit, Reserved: true},
+	TransactionTypeExpireSC:        {Currency: CurrencySC, Direction: Debit, Reserved: true},
+	TransactionTypeAdjustment:      {Direction: Credit, Reserved: true, Signed: true},
+	TransactionTypeCorrection:      {Direction: Credit, Signed: true},
+	TransactionTypeReferralGC:      {Currency: CurrencyGC, Direction: Credit},
+	TransactionTypeReferralSC:      {Currency: CurrencySC, Direction: Credit},
+	TransactionTypeWager:           {Direction: Debit, Stat: StatWagered, StatSign: 1, Reserved: true},
+	TransactionTypeWin:             {Direction: Credit, Stat: StatWon, StatSign: 1, Reserved: true},
+	TransactionTypeRefund:          {Direction: Credit, Stat: StatWagered, StatSign: -1, Reserved: true},
+	TransactionTypeJackpot:         {Direction: Credit, Stat: StatWon, StatSign: 1, Reserved: true},
+	TransactionTypeRedeem:          {Direction: Debit, Stat: StatRedeemed, StatSign: 1, Reserved: true},
+}
+
+// FlowTypes are the transaction types the wager, refund, jackpot and
+// redemption flows post in a currency
+type FlowTypes struct {
+	Wager   TransactionType
+	Win     TransactionType
+	Refund  TransactionType
+	Jackpot TransactionType
+	Redeem  TransactionType
+}
+
+// coinFlowTypes keeps the currency-specific types Gold Coins and Sweeps Coins
+// were posted with before the flows covered every registered currency
+var coinFlowTypes = map[Currency]FlowTypes{
+	CurrencyGC: {TransactionTypeWagerGC, TransactionTypeWinGC, TransactionTypeRefundGC, TransactionTypeJackpotGC, TransactionTypeRedeem},
+	CurrencySC: {TransactionTypeWagerSC, TransactionTypeWinSC, TransactionTypeRefundSC, TransactionTypeJackpotSC, TransactionTypeRedeemSC},
+}
+
+// FlowTypesOf returns the types the flows post in a currency: the GC and SC
+// specific types for those, the generic types for any other currency
+func FlowTypesOf(c Currency) FlowTypes {
+	if types, ok := coinFlowTypes[c]; ok {
+		return types
+	}
+	return FlowTypes{TransactionTypeWager, TransactionTypeWin, TransactionTypeRefund, TransactionTypeJackpot, TransactionTypeRedeem}
+}
+
+// IsStake reports whether the type is the stake of a wager
+func (t TransactionType) IsStake() bool {
+	return t == TransactionTypeWagerGC || t == TransactionTypeWagerSC || t == TransactionTypeWager
+}
+
+// IsPayout reports whether the type is the payout of a wager
+func (t TransactionType) IsPayout() bool {
+	return t == TransactionTypeWinGC || t == TransactionTypeWinSC || t == TransactionTypeWin
+}
+
+// PostingLeg is one row of a multi-leg posting. Legs are applied in order, so
+// a debit may spend what an earlier leg credited.
+type PostingLeg struct {
+	Currency Currency        `json:"currency"`
+	Type     TransactionType `json:"type"`
+	Amount   int64           `json:"amount"` // in the currency's minor units; positive unless the type is signed
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+}
+
+// BatchPostingLeg is a posting leg for one of the users of a batch posting
+type BatchPostingLeg struct {
+	UserID int `json:"user_id"`
+	PostingLeg
+}
+
+// Rule returns the posting rule of the transaction type
+func (t TransactionType) Rule() (PostingRule, bool) {
+	rule, ok := PostingRules[t]
+	return rule, ok
+}
+
+// Apply returns the balance after posting amount with this type. Unknown
+// types leave the balance unchanged.
+func (t TransactionType) Apply(balance, amount int64) int64 {
+	return balance + t.SignedAmount(amount)
+}
+
+// SignedAmount returns amount with the sign the type applies to the balance
+func (t TransactionType) SignedAmount(amount int64) int64 {
+	return int64(PostingRules[t].Direction) * amount
+}
+
+// AllowsCurrency reports whether the type may be posted in the currency
+func (r PostingRule) AllowsCurrency(c Currency) bool {
+	return r.Currency == "" || r.Currency == c
+}
+
+// TransactionTypesWith lists the types posted in the given direction
+func TransactionTypesWith(direction Direction) []TransactionType {
+	var types []TransactionType
+	for t, rule := range PostingRules {
+		if rule.Direction == direction {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// TransactionTypesCounting lists the types counted in a statistic with the given sign
+func TransactionTypesCounting(stat Stat, sign int64) []TransactionType {
+	var types []TransactionType
+	for t, rule := range PostingRules {
+		if rule.Stat == stat && rule.StatSign == sign {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// LedgerDiscrepancy is a place where a user's ledger breaks the posting rules
+type LedgerDiscrepancy struct {
+	TransactionID int      `json:"transaction_id,omitempty"` // omitted for a currency-wide mismatch
+	Currency      Currency `json:"currency"`
+	Problem       string   `json:"problem"`
+	Expected      int64    `json:"expected,omitempty"`
+	Actual        int64    `json:"actual,omitempty"`
+}
+
+// LedgerVerification is the result of checking a user's ledger: every
+// transaction is replayed through the posting rules and the running balances
+// compared with the stored balance_after values and the summed balances
+type LedgerVerification struct {
+	UserID              int                 `json:"user_id"`
+	TransactionsChecked int                 `json:"transactions_checked"`
+	Balances            map[Currency]int64  `json:"balances"` // latest balance_after per currency, in minor units
+	Consistent          bool                `json:"consistent"`
+	Discrepancies       []LedgerDiscrepancy `json:"discrepancies"`
+}
@@ -0,0 +1,65 @@
+package models
+
+import "testing"
+
+// Test every transaction type has a posting rule and debits are the types
+// that take coins away from the player
+func TestPostingRules(t *testing.T) {
+	types := []TransactionType{
+		TransactionTypePurchase, TransactionTypeWagerGC, TransactionTypeWinGC, TransactionTypeWagerSC,
+		TransactionTypeWinSC, TransactionTypeRedeemSC, TransactionTypeRefundGC, TransactionTypeRefundSC,
+		TransactionTypeJackpotGC, TransactionTypeJackpotSC, TransactionTypeTournamentEntry,
+		TransactionTypeTournamentPrize, TransactionTypeBonusGC, TransactionTypeBonusSC, TransactionTypeAMOESC,
+		TransactionTypePromoGC, TransactionTypePromoSC, TransactionTypeExpireSC, TransactionTypeAdjustment,
+		TransactionTypeCorrection, TransactionTypeReferralGC, TransactionTypeReferralSC, TransactionTypeWager,
+		TransactionTypeWin, TransactionTypeRefund, TransactionTypeJackpot, TransactionTypeRedeem,
+	}
+	debits := map[TransactionType]bool{
+		TransactionTypeWagerGC: true, TransactionTypeWagerSC: true, TransactionTypeRedeemSC: true,
+		TransactionTypeTournamentEntry: true, TransactionTypeExpireSC: true, TransactionTypeWager: true,
+		TransactionTypeRedeem: true,
+	}
+
+	if len(PostingRules) != len(types) {
+		t.Errorf("expected %d posting rules, got %d", len(types), len(PostingRules))
+	}
+	for _, typ := range types {
+		rule, ok := typ.Rule()
+		if !ok {
+			t.Errorf("%s has no posting rule", typ)
+			continue
+		}
+		if got := rule.Direction == Debit; got != debits[typ] {
+			t.Errorf("%s: expected debit %v, got %v", typ, debits[typ], got)
+		}
+		if rule.Stat != StatNone && rule.StatSign != 1 && rule.StatSign != -1 {
+			t.Errorf("%s: stat sign must be 1 or -1, got %d", typ, rule.StatSign)
+		}
+	}
+
+	if got := TransactionTypeWagerSC.Apply(1000, 250); got != 750 {
+		t.Errorf("expected wager to debit 250 from 1000, got %d", got)
+	}
+	if got := TransactionTypeRefundSC.Apply(750, 250); got != 1000 {
+		t.Errorf("expected refund to credit 250 to 750, got %d", got)
+	}
+	if got := TransactionTypeAdjustment.Apply(1000, -250); got != 750 {
+		t.Errorf("expected a negative adjustment to debit 250 from 1000, got %d", got)
+	}
+	for _, typ := range []TransactionType{TransactionTypeBonusGC, TransactionTypeBonusSC, TransactionTypePromoGC, TransactionTypePromoSC} {
+		if rule, _ := typ.Rule(); !rule.Reserved {
+			t.Errorf("%s must be reserved to the flows that enforce its budgets", typ)
+		}
+	}
+	for _, typ := range []TransactionType{TransactionTypeCorrection, TransactionTypeReferralGC, TransactionTypeReferralSC} {
+		if rule, _ := typ.Rule(); rule.Reserved {
+			t.Errorf("%s must be postable by generic postings", typ)
+		}
+	}
+	if got := TransactionTypeCorrection.Apply(1000, -250); got != 750 {
+		t.Errorf("expected a negative correction to debit 250 from 1000, got %d", got)
+	}
+	if TransactionType("bogus").IsValid() {
+		t.Error("expected a type without a posting rule to be invalid")
+	}
+}
@@ -0,0 +1,155 @@
+package providers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"wallet-ledger/models"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of the request body
+const SignatureHeader = "X-Signature"
+
+// maxCallbackBody limits the size of a callback request body
+const maxCallbackBody = 64 << 10
+
+// GenericRequest is the body of a generic JSON protocol callback. The amount is
+// given either in whole coins or in minor units, not both.
+type GenericRequest struct {
+	Action                 Action `json:"action"`
+	PlayerID               int    `json:"player_id"`
+	Currency               string `json:"currency"`
+	Amount                 int64  `json:"amount,omitempty"`       // Deprecated: whole coins, cannot carry fractions; use AmountMinor
+	AmountMinor            int64  `json:"amount_minor,omitempty"` // in the currency's minor units
+	TransactionID          string `json:"transaction_id,omitempty"`
+	ReferenceTransactionID string `json:"reference_transaction_id,omitempty"`
+	RoundID                string `json:"round_id,omitempty"`
+	GameID                 string `json:"game_id,omitempty"`
+}
+
+// GenericResponse is the body of a generic JSON protocol response
+type GenericResponse struct {
+	Status         string    `json:"status"`
+	PlayerID       int       `json:"player_id,omitempty"`
+	Currency       string    `json:"currency,omitempty"`
+	Balance        *int64    `json:"balance,omitempty"` // Deprecated: whole coins, rounded toward zero; use BalanceMinor
+	BalanceMinor   *int64    `json:"balance_minor,omitempty"`
+	TransactionIDs []int     `json:"transaction_ids,omitempty"`
+	ErrorCode      ErrorCode `json:"error_code,omitempty"`
+	Message        string    `json:"message,omitempty"`
+}
+
+// GenericProtocol is a simple JSON seamless-wallet protocol: every action is a
+// POST with a GenericRequest body, signed with a shared secret
+type GenericProtocol struct {
+	name   string
+	secret []byte
+}
+
+// NewGenericProtocol creates a generic protocol. Callbacks move balances, so
+// the secret is required; there is no unsigned mode.
+func NewGenericProtocol(name, secret string) (*GenericProtocol, error) {
+	if name == "" {
+		return nil, errors.New("provider name is required")
+	}
+	if secret == "" {
+		return nil, fmt.Errorf("provider %s: signing secret is required", name)
+	}
+	return &GenericProtocol{name: name, secret: []byte(secret)}, nil
+}
+
+func (p *GenericProtocol) Name() string {
+	return p.name
+}
+
+// Decode verifies the signature and parses the callback
+func (p *GenericProtocol) Decode(r *http.Request) (*Callback, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxCallbackBody))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+	}
+
+	signature, err := hex.DecodeString(r.Header.Get(SignatureHeader))
+	if err != nil || len(signature) == 0 || !hmac.Equal(signature, p.sign(body)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var req GenericRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, fmt.Errorf("%w: invalid JSON body", ErrInvalidRequest)
+	}
+
+	currency := models.Currency(req.Currency)
+	amount := req.AmountMinor
+	if req.Amount != 0 {
+		if req.AmountMinor != 0 {
+			return nil, fmt.Errorf("%w: set amount or amount_minor, not both", ErrInvalidRequest)
+		}
+		if amount, err = models.WholeToMinor(req.Amount, currency.MinorUnits()); err != nil {
+			return nil, fmt.Errorf("%w: amount: %v", ErrInvalidRequest, err)
+		}
+	}
+
+	return &Callback{
+		Action:                 req.Action,
+		UserID:                 req.PlayerID,
+		Currency:               currency,
+		Amount:                 amount,
+		TransactionID:          req.TransactionID,
+		ReferenceTransactionID: req.ReferenceTransactionID,
+		RoundID:                req.RoundID,
+		GameID:                 req.GameID,
+	}, nil
+}
+
+// Encode writes a GenericResponse. Business errors are reported with 200 and
+// status ERROR; internal errors use 500 so the provider retries.
+func (p *GenericProtocol) Encode(w http.ResponseWriter, result *Result, err error) {
+	if err != nil {
+		code := Classify(err)
+		status := http.StatusOK
+		message := err.Error()
+		switch code {
+		case CodeUnauthorized:
+			status = http.StatusUnauthorized
+		case CodeInternal:
+			log.Printf("Error handling %s callback: %v", p.name, err)
+			status = http.StatusInternalServerError
+			message = "internal error"
+		}
+		writeJSON(w, status, GenericResponse{Status: "ERROR", ErrorCode: code, Message: message})
+		return
+	}
+
+	balance := models.MinorToWhole(result.Balance, result.Currency.MinorUnits())
+	resp := GenericResponse{
+		Status:       "OK",
+		PlayerID:     result.UserID,
+		Currency:     string(result.Currency),
+		Balance:      &balance,
+		BalanceMinor: &result.Balance,
+	}
+	for _, t := range result.Transactions {
+		resp.TransactionIDs = append(resp.TransactionIDs, t.ID)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// sign computes the HMAC-SHA256 of body with the shared secret
+func (p *GenericProtocol) sign(body []byte) []byte {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+func writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
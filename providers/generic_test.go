@@ -0,0 +1,170 @@
+package providers
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"wallet-ledger/models"
+	"wallet-ledger/service"
+)
+
+// Test Decode accepts correctly signed callbacks and rejects tampered ones
+func TestGenericDecode_Signature(t *testing.T) {
+	p, err := NewGenericProtocol("generic", "secret")
+	if err != nil {
+		t.Fatalf("NewGenericProtocol: %v", err)
+	}
+	body := `{"action":"debit","player_id":1,"currency":"SC","amount":100,"transaction_id":"tx-1","round_id":"r-1"}`
+
+	req := httptest.NewRequest("POST", "/providers/generic/callback", strings.NewReader(body))
+	req.Header.Set(SignatureHeader, hex.EncodeToString(p.sign([]byte(body))))
+
+	cb, err := p.Decode(req)
+	if err != nil {
+		t.Fatalf("expected valid callback, got %v", err)
+	}
+	if cb.Action != ActionDebit || cb.UserID != 1 || cb.Currency != models.CurrencySC || cb.Amount != 10000 || cb.RoundID != "r-1" {
+		t.Errorf("unexpected callback: %+v", cb)
+	}
+
+	tampered := httptest.NewRequest("POST", "/providers/generic/callback", strings.NewReader(strings.Replace(body, "100", "1", 1)))
+	tampered.Header.Set(SignatureHeader, hex.EncodeToString(p.sign([]byte(body))))
+
+	if _, err := p.Decode(tampered); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+// Test amounts are read in whole coins from the deprecated amount field or in
+// minor units from amount_minor, and balances are written both ways
+func TestGenericAmounts(t *testing.T) {
+	p, err := NewGenericProtocol("generic", "secret")
+	if err != nil {
+		t.Fatalf("NewGenericProtocol: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		amount   string
+		expected int64
+		wantErr  bool
+	}{
+		{"whole coins", `"amount":2`, 200, false},
+		{"minor units", `"amount_minor":150`, 150, false},
+		{"both", `"amount":2,"amount_minor":200`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := `{"action":"debit","player_id":1,"currency":"SC",` + tt.amount + `,"transaction_id":"tx-1","game_id":"starburst"}`
+			req := httptest.NewRequest("POST", "/providers/generic/callback", strings.NewReader(body))
+			req.Header.Set(SignatureHeader, hex.EncodeToString(p.sign([]byte(body))))
+
+			cb, err := p.Decode(req)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRequest) {
+					t.Errorf("expected ErrInvalidRequest, got %v", err)
+				}
+				return
+			}
+			if err != nil || cb.Amount != tt.expected {
+				t.Errorf("expected amount %d, got %+v (%v)", tt.expected, cb, err)
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	p.Encode(rec, &Result{UserID: 1, Currency: models.CurrencySC, Balance: 4550}, nil)
+	if body := rec.Body.String(); !strings.Contains(body, `"balance":45,`) || !strings.Contains(body, `"balance_minor":4550`) {
+		t.Errorf("expected balance 45 and balance_minor 4550, got %s", body)
+	}
+}
+
+// Test unsigned callbacks are answered with 401 before anything is executed
+func TestGenericCallback_UnsignedUnauthorized(t *testing.T) {
+	p, err := NewGenericProtocol("generic", "secret")
+	if err != nil {
+		t.Fatalf("NewGenericProtocol: %v", err)
+	}
+	body := `{"action":"credit","player_id":1,"currency":"SC","amount":100000,"transaction_id":"tx-1","game_id":"starburst"}`
+
+	for _, signature := range []string{"", "not-hex", hex.EncodeToString([]byte("wrong"))} {
+		req := httptest.NewRequest("POST", "/providers/generic/callback", strings.NewReader(body))
+		if signature != "" {
+			req.Header.Set(SignatureHeader, signature)
+		}
+
+		_, err := p.Decode(req)
+		if !errors.Is(err, ErrInvalidSignature) {
+			t.Fatalf("signature %q: expected ErrInvalidSignature, got %v", signature, err)
+		}
+
+		rec := httptest.NewRecorder()
+		p.Encode(rec, nil, err)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("signature %q: expected 401, got %d", signature, rec.Code)
+		}
+		if !strings.Contains(rec.Body.String(), string(CodeUnauthorized)) {
+			t.Errorf("signature %q: expected %s, got %s", signature, CodeUnauthorized, rec.Body.String())
+		}
+	}
+}
+
+// Test a generic protocol cannot be created without a signing secret
+func TestNewGenericProtocol_RequiresSecret(t *testing.T) {
+	if _, err := NewGenericProtocol("generic", ""); err == nil {
+		t.Error("expected error for empty secret")
+	}
+}
+
+// Test callbacks are validated per action before reaching the service
+func TestValidateCallback(t *testing.T) {
+	tests := []struct {
+		name  string
+		cb    Callback
+		valid bool
+	}{
+		{"balance", Callback{Action: ActionBalance, Currency: models.CurrencyGC}, true},
+		{"debit", Callback{Action: ActionDebit, Currency: models.CurrencyGC, Amount: 10, TransactionID: "tx-1", GameID: "starburst"}, true},
+		{"debit without transaction id", Callback{Action: ActionDebit, Currency: models.CurrencyGC, Amount: 10, GameID: "starburst"}, false},
+		{"debit without game id", Callback{Action: ActionDebit, Currency: models.CurrencyGC, Amount: 10, TransactionID: "tx-1"}, false},
+		{"credit with zero amount", Callback{Action: ActionCredit, Currency: models.CurrencySC, TransactionID: "tx-2", GameID: "starburst"}, false},
+		{"rollback without reference", Callback{Action: ActionRollback, Currency: models.CurrencySC, TransactionID: "tx-3"}, false},
+		{"unknown currency", Callback{Action: ActionBalance, Currency: "EUR"}, false},
+		{"unknown action", Callback{Action: "refund", Currency: models.CurrencyGC}, false},
+	}
+
+	registry := NewRegistry(service.New(nil))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := registry.validateCallback(&tt.cb)
+			if tt.valid && err != nil {
+				t.Errorf("expected valid callback, got %v", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidRequest) {
+				t.Errorf("expected ErrInvalidRequest, got %v", err)
+			}
+		})
+	}
+}
+
+// Test a debit refused after its rollback is reported as a business error, not retried
+func TestGenericEncode_RolledBack(t *testing.T) {
+	p, err := NewGenericProtocol("generic", "secret")
+	if err != nil {
+		t.Fatalf("NewGenericProtocol: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	p.Encode(rec, nil, fmt.Errorf("%w: %s", service.ErrWagerRolledBack, IdempotencyKey("generic", ActionDebit, "tx-1")))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), string(CodeTransactionRolledBack)) {
+		t.Errorf("expected %s, got %s", CodeTransactionRolledBack, rec.Body.String())
+	}
+}
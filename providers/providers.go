@@ -0,0 +1,228 @@
+// Package providers adapts game provider seamless-wallet callbacks (balance,
+// debit, credit, rollback) to the wallet service. Each provider's wire format
+// is a Protocol; adding a provider means implementing and registering one,
+// without touching service.WalletService.
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"wallet-ledger/models"
+	"wallet-ledger/service"
+)
+
+// Action is a seamless-wallet callback type
+type Action string
+
+const (
+	ActionBalance  Action = "balance"  // authenticate / get balance
+	ActionDebit    Action = "debit"    // place a bet
+	ActionCredit   Action = "credit"   // pay a win
+	ActionRollback Action = "rollback" // cancel a previous debit
+)
+
+// Callback is a provider request translated into wallet terms
+type Callback struct {
+	Action   Action
+	UserID   int
+	Currency models.Currency
+	Amount   int64
+
+	// TransactionID is the provider's unique ID for a debit or credit
+	TransactionID string
+	// ReferenceTransactionID is the provider ID of the debit a rollback cancels
+	ReferenceTransactionID string
+
+	RoundID string
+	GameID  string
+}
+
+// Result is what a protocol encodes back to the provider
+type Result struct {
+	UserID       int
+	Currency     models.Currency
+	Balance      int64
+	Transactions []*models.Transaction
+}
+
+// Protocol translates one provider's wire format to and from callbacks
+type Protocol interface {
+	// Name is the provider name used in the callback URL
+	Name() string
+	// Decode authenticates and parses a callback request
+	Decode(r *http.Request) (*Callback, error)
+	// Encode writes the provider-specific response for a result or error
+	Encode(w http.ResponseWriter, result *Result, err error)
+}
+
+// Errors returned by protocols while decoding
+var (
+	ErrInvalidRequest   = errors.New("invalid provider request")
+	ErrInvalidSignature = errors.New("invalid provider signature")
+)
+
+// ErrorCode classifies a callback failure in provider-neutral terms
+type ErrorCode string
+
+const (
+	CodeInsufficientFunds     ErrorCode = "INSUFFICIENT_FUNDS"
+	CodePlayerNotFound        ErrorCode = "PLAYER_NOT_FOUND"
+	CodeTransactionNotFound   ErrorCode = "TRANSACTION_NOT_FOUND"
+	CodeTransactionRolledBack ErrorCode = "TRANSACTION_ROLLED_BACK"
+	CodeGameUnavailable       ErrorCode = "GAME_UNAVAILABLE"
+	CodeLimitExceeded         ErrorCode = "LIMIT_EXCEEDED"
+	CodePlayerExcluded        ErrorCode = "PLAYER_EXCLUDED"
+	CodeRealityCheck          ErrorCode = "REALITY_CHECK_REQUIRED"
+	CodeInvalidRequest        ErrorCode = "INVALID_REQUEST"
+	CodeUnauthorized          ErrorCode = "UNAUTHORIZED"
+	CodeInternal              ErrorCode = "INTERNAL_ERROR"
+)
+
+// Classify maps service and decoding errors to an ErrorCode
+func Classify(err error) ErrorCode {
+	switch {
+	case errors.Is(err, service.ErrInsufficientFunds):
+		return CodeInsufficientFunds
+	case errors.Is(err, service.ErrUserNotFound):
+		return CodePlayerNotFound
+	case errors.Is(err, service.ErrWagerNotFound):
+		return CodeTransactionNotFound
+	case errors.Is(err, service.ErrWagerRolledBack):
+		return CodeTransactionRolledBack
+	case errors.Is(err, ErrInvalidSignature):
+		return CodeUnauthorized
+	case errors.Is(err, service.ErrGameDisabled):
+		return CodeGameUnavailable
+	case errors.Is(err, service.ErrWagerLimitExceeded), errors.Is(err, service.ErrSessionLossCapReached):
+		return CodeLimitExceeded
+	case errors.Is(err, service.ErrRealityCheckDue):
+		return CodeRealityCheck
+	case errors.Is(err, service.ErrSelfExcluded):
+		return CodePlayerExcluded
+	case errors.Is(err, ErrInvalidRequest), errors.Is(err, service.ErrInvalidInput),
+		errors.Is(err, service.ErrInvalidGame), errors.Is(err, service.ErrCurrencyNotAllowed),
+		errors.Is(err, service.ErrStakeOutOfRange):
+		return CodeInvalidRequest
+	}
+	return CodeInternal
+}
+
+// IdempotencyKey derives the ledger idempotency key for a provider transaction.
+// Rollbacks are keyed by the debit they cancel so a debit is refunded at most
+// once. A rollback that arrives first leaves the debit key recorded without
+// transactions, so the late debit is refused rather than posted.
+func IdempotencyKey(provider string, action Action, providerTransactionID string) string {
+	return fmt.Sprintf("%s:%s:%s", provider, action, providerTransactionID)
+}
+
+// Registry holds the registered protocols and executes their callbacks
+type Registry struct {
+	service *service.WalletService
+
+	mu        sync.RWMutex
+	protocols map[string]Protocol
+}
+
+func NewRegistry(svc *service.WalletService) *Registry {
+	return &Registry{
+		service:   svc,
+		protocols: make(map[string]Protocol),
+	}
+}
+
+// Register adds a protocol, replacing any existing one with the same name
+func (r *Registry) Register(p Protocol) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.protocols[p.Name()] = p
+}
+
+// Get returns the protocol registered under name
+func (r *Registry) Get(name string) (Protocol, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.protocols[name]
+	return p, ok
+}
+
+// Handle executes a decoded callback for the named provider
+func (r *Registry) Handle(provider string, cb *Callback) (*Result, error) {
+	if err := r.validateCallback(cb); err != nil {
+		return nil, err
+	}
+
+	// Game, round and game provider are recorded by the wallet service itself
+	metadata := map[string]interface{}{
+		"integration": provider,
+	}
+	if cb.TransactionID != "" {
+		metadata["provider_transaction_id"] = cb.TransactionID
+	}
+
+	var transactions []*models.Transaction
+	var err error
+
+	switch cb.Action {
+	case ActionDebit:
+		legs := []models.WagerLeg{{Currency: cb.Currency, Stake: cb.Amount}}
+		key := IdempotencyKey(provider, ActionDebit, cb.TransactionID)
+		transactions, err = r.service.WagerWithMetadata(cb.UserID, cb.GameID, cb.RoundID, legs, key, metadata)
+	case ActionCredit:
+		legs := []models.WagerLeg{{Currency: cb.Currency, Payout: cb.Amount}}
+		key := IdempotencyKey(provider, ActionCredit, cb.TransactionID)
+		transactions, err = r.service.WagerWithMetadata(cb.UserID, cb.GameID, cb.RoundID, legs, key, metadata)
+	case ActionRollback:
+		metadata["rolled_back_transaction_id"] = cb.ReferenceTransactionID
+		debitKey := IdempotencyKey(provider, ActionDebit, cb.ReferenceTransactionID)
+		key := IdempotencyKey(provider, ActionRollback, cb.ReferenceTransactionID)
+		transactions, err = r.service.RollbackWager(cb.UserID, debitKey, key, metadata)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Always report the current balance, including for replayed duplicates
+	user, err := r.service.GetUserWithBalances(cb.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		UserID:       cb.UserID,
+		Currency:     cb.Currency,
+		Balance:      user.Balances[cb.Currency],
+		Transactions: transactions,
+	}, nil
+}
+
+// validateCallback checks the currency is registered and the fields each
+// action requires are set
+func (r *Registry) validateCallback(cb *Callback) error {
+	if _, err := r.service.GetCurrency(cb.Currency); err != nil {
+		return fmt.Errorf("%w: unsupported currency %q", ErrInvalidRequest, cb.Currency)
+	}
+
+	switch cb.Action {
+	case ActionBalance:
+		return nil
+	case ActionDebit, ActionCredit:
+		if cb.TransactionID == "" {
+			return fmt.Errorf("%w: transaction id is required", ErrInvalidRequest)
+		}
+		if cb.GameID == "" {
+			return fmt.Errorf("%w: game id is required", ErrInvalidRequest)
+		}
+		if cb.Amount <= 0 {
+			return fmt.Errorf("%w: amount must be positive", ErrInvalidRequest)
+		}
+		return nil
+	case ActionRollback:
+		if cb.ReferenceTransactionID == "" {
+			return fmt.Errorf("%w: reference transaction id is required", ErrInvalidRequest)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: unknown action %q", ErrInvalidRequest, cb.Action)
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"wallet-ledger/models"
+)
+
+const adjustmentColumns = `id, user_id, transaction_id, currency, amount, reason_code, note, admin, approved_by, created_at`
+
+func scanAdjustment(row interface{ Scan(...interface{}) error }) (*models.Adjustment, error) {
+	var a models.Adjustment
+	var approvedBy sql.NullString
+	err := row.Scan(&a.ID, &a.UserID, &a.TransactionID, &a.Currency, &a.Amount, &a.Reason, &a.Note, &a.Admin, &approvedBy, &a.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if approvedBy.Valid {
+		a.ApprovedBy = &approvedBy.String
+	}
+	return &a, nil
+}
+
+// CreateAdjustment records who made a manual adjustment and why
+func (r *Repository) CreateAdjustment(tx *sql.Tx, a *models.Adjustment) error {
+	return tx.QueryRow(`
+		INSERT INTO adjustments (user_id, transaction_id, currency, amount, reason_code, note, admin, approved_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`, a.UserID, a.TransactionID, a.Currency, a.Amount, a.Reason, a.Note, a.Admin, a.ApprovedBy, a.CreatedAt).Scan(&a.ID)
+}
+
+// GetAdjustmentByTransactionTx retrieves the adjustment that posted a transaction
+func (r *Repository) GetAdjustmentByTransactionTx(tx *sql.Tx, transactionID int) (*models.Adjustment, error) {
+	return scanAdjustment(tx.QueryRow(`SELECT `+adjustmentColumns+` FROM adjustments WHERE transaction_id = $1`, transactionID))
+}
+
+// ListAdjustments retrieves adjustments newest first with cursor-based pagination
+func (r *Repository) ListAdjustments(filter models.AdjustmentFilter, cursor *string, limit int) (*models.AdjustmentList, error) {
+	query := `SELECT ` + adjustmentColumns + ` FROM adjustments WHERE 1=1`
+	args := []interface{}{}
+	argPos := 1
+
+	if filter.UserID != nil {
+		query += fmt.Sprintf(" AND user_id = $%d", argPos)
+		args = append(args, *filter.UserID)
+		argPos++
+	}
+	if filter.Reason != nil {
+		query += fmt.Sprintf(" AND reason_code = $%d", argPos)
+		args = append(args, *filter.Reason)
+		argPos++
+	}
+	if filter.Admin != "" {
+		query += fmt.Sprintf(" AND admin = $%d", argPos)
+		args = append(args, filter.Admin)
+		argPos++
+	}
+
+	// Same (timestamp, id) cursor scheme as ListTransactions
+	if cursor != nil && *cursor != "" {
+		cursorID, cursorTime, err := decodeCursor(*cursor)
+		if err == nil {
+			query += fmt.Sprintf(" AND (created_at < $%d OR (created_at = $%d AND id < $%d))", argPos, argPos, argPos+1)
+			args = append(args, cursorTime, cursorID)
+			argPos += 2
+		}
+	}
+
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", argPos)
+	args = append(args, limit+1) // Fetch one extra to determine if there's a next page
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	adjustments := []models.Adjustment{}
+	for rows.Next() {
+		a, err := scanAdjustment(rows)
+		if err != nil {
+			return nil, err
+		}
+		adjustments = append(adjustments, *a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var nextCursor *string
+	if len(adjustments) > limit {
+		last := adjustments[limit-1]
+		cursorStr := encodeCursor(last.ID, last.CreatedAt)
+		nextCursor = &cursorStr
+		adjustments = adjustments[:limit]
+	}
+
+	return &models.AdjustmentList{
+		Items:      adjustments,
+		NextCursor: nextCursor,
+	}, nil
+}
@@ -0,0 +1,282 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+	"wallet-ledger/models"
+)
+
+var (
+	// ErrAMOECodeNotFound is returned when an AMOE request code does not exist
+	ErrAMOECodeNotFound = errors.New("amoe code not found")
+	// ErrAMOEEntryNotFound is returned when an AMOE entry ID does not exist
+	ErrAMOEEntryNotFound = errors.New("amoe entry not found")
+)
+
+const amoeEntryColumns = `id, code, user_id, method, status, amount_sc, submitted_by, submitted_at, reviewed_by, reviewed_at, review_note, transaction_id`
+
+func scanAMOEEntry(row interface{ Scan(...interface{}) error }) (*models.AMOEEntry, error) {
+	var e models.AMOEEntry
+	var reviewedBy sql.NullString
+	var reviewedAt sql.NullTime
+	var transactionID sql.NullInt64
+
+	err := row.Scan(&e.ID, &e.Code, &e.UserID, &e.Method, &e.Status, &e.AmountSC, &e.SubmittedBy, &e.SubmittedAt,
+		&reviewedBy, &reviewedAt, &e.ReviewNote, &transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reviewedBy.Valid {
+		e.ReviewedBy = &reviewedBy.String
+	}
+	if reviewedAt.Valid {
+		e.ReviewedAt = &reviewedAt.Time
+	}
+	if transactionID.Valid {
+		id := int(transactionID.Int64)
+		e.TransactionID = &id
+	}
+	return &e, nil
+}
+
+// GetAMOESettings retrieves the AMOE amount and limits
+func (r *Repository) GetAMOESettings() (*models.AMOESettings, error) {
+	var s models.AMOESettings
+	err := r.db.QueryRow(`
+		SELECT amount_sc, max_entries_per_user_per_day, max_entries_per_day, updated_at
+		FROM amoe_settings
+	`).Scan(&s.AmountSC, &s.MaxEntriesPerUserPerDay, &s.MaxEntriesPerDay, &s.UpdatedAt)
+	return &s, err
+}
+
+// LockAMOESettingsTx retrieves and locks the AMOE settings until tx ends
+func (r *Repository) LockAMOESettingsTx(tx *sql.Tx) (*models.AMOESettings, error) {
+	var s models.AMOESettings
+	err := tx.QueryRow(`
+		SELECT amount_sc, max_entries_per_user_per_day, max_entries_per_day, updated_at
+		FROM amoe_settings
+		FOR UPDATE
+	`).Scan(&s.AmountSC, &s.MaxEntriesPerUserPerDay, &s.MaxEntriesPerDay, &s.UpdatedAt)
+	return &s, err
+}
+
+// SaveAMOESettings updates the AMOE amount and limits
+func (r *Repository) SaveAMOESettings(s *models.AMOESettings) error {
+	return r.db.QueryRow(`
+		UPDATE amoe_settings
+		SET amount_sc = $1, max_entries_per_user_per_day = $2, max_entries_per_day = $3, updated_at = NOW()
+		RETURNING updated_at
+	`, s.AmountSC, s.MaxEntriesPerUserPerDay, s.MaxEntriesPerDay).Scan(&s.UpdatedAt)
+}
+
+// CreateAMOECode stores a newly issued request code
+func (r *Repository) CreateAMOECode(c *models.AMOECode) error {
+	return r.db.QueryRow(`
+		INSERT INTO amoe_codes (code, user_id, expires_at)
+		VALUES ($1, $2, $3)
+		RETURNING created_at
+	`, c.Code, c.UserID, c.ExpiresAt).Scan(&c.CreatedAt)
+}
+
+// GetAMOECode retrieves a request code without locking it
+func (r *Repository) GetAMOECode(code string) (*models.AMOECode, error) {
+	return scanAMOECode(r.db.QueryRow(`
+		SELECT code, user_id, created_at, expires_at, used_at
+		FROM amoe_codes
+		WHERE code = $1
+	`, code))
+}
+
+// LockAMOECodeTx retrieves and locks a request code until tx ends
+func (r *Repository) LockAMOECodeTx(tx *sql.Tx, code string) (*models.AMOECode, error) {
+	return scanAMOECode(tx.QueryRow(`
+		SELECT code, user_id, created_at, expires_at, used_at
+		FROM amoe_codes
+		WHERE code = $1
+		FOR UPDATE
+	`, code))
+}
+
+func scanAMOECode(row *sql.Row) (*models.AMOECode, error) {
+	var c models.AMOECode
+	var usedAt sql.NullTime
+
+	err := row.Scan(&c.Code, &c.UserID, &c.CreatedAt, &c.ExpiresAt, &usedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrAMOECodeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if usedAt.Valid {
+		c.UsedAt = &usedAt.Time
+	}
+	return &c, nil
+}
+
+// CountAMOEEntriesSinceTx counts entries submitted at or after since, for one
+// user or, if userID is nil, for everyone
+func (r *Repository) CountAMOEEntriesSinceTx(tx *sql.Tx, userID *int, since time.Time) (int, error) {
+	var count int
+	err := tx.QueryRow(`
+		SELECT COUNT(*)
+		FROM amoe_entries
+		WHERE submitted_at >= $1 AND ($2::INTEGER IS NULL OR user_id = $2)
+	`, since, userID).Scan(&count)
+	return count, err
+}
+
+// CreateAMOEEntry marks the entry's code used and stores the entry
+func (r *Repository) CreateAMOEEntry(tx *sql.Tx, e *models.AMOEEntry) error {
+	_, err := tx.Exec(`UPDATE amoe_codes SET used_at = NOW() WHERE code = $1`, e.Code)
+	if err != nil {
+		return err
+	}
+
+	return tx.QueryRow(`
+		INSERT INTO amoe_entries (code, user_id, method, status, amount_sc, submitted_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, submitted_at
+	`, e.Code, e.UserID, e.Method, e.Status, e.AmountSC, e.SubmittedBy).Scan(&e.ID, &e.SubmittedAt)
+}
+
+// GetAMOEEntry retrieves an AMOE entry by ID
+func (r *Repository) GetAMOEEntry(entryID int) (*models.AMOEEntry, error) {
+	e, err := scanAMOEEntry(r.db.QueryRow(`
+		SELECT `+amoeEntryColumns+`
+		FROM amoe_entries
+		WHERE id = $1
+	`, entryID))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrAMOEEntryNotFound
+	}
+	return e, err
+}
+
+// LockAMOEEntryTx retrieves and locks an AMOE entry until tx ends
+func (r *Repository) LockAMOEEntryTx(tx *sql.Tx, entryID int) (*models.AMOEEntry, error) {
+	e, err := scanAMOEEntry(tx.QueryRow(`
+		SELECT `+amoeEntryColumns+`
+		FROM amoe_entries
+		WHERE id = $1
+		FOR UPDATE
+	`, entryID))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrAMOEEntryNotFound
+	}
+	return e, err
+}
+
+// ListAMOEEntries retrieves AMOE entries newest first, optionally filtered by status
+func (r *Repository) ListAMOEEntries(status *models.AMOEStatus, cursor *string, limit int) (*models.AMOEEntryList, error) {
+	query := `SELECT ` + amoeEntryColumns + ` FROM amoe_entries WHERE 1=1`
+	args := []interface{}{}
+	argPos := 1
+
+	if status != nil {
+		query += fmt.Sprintf(" AND status = $%d", argPos)
+		args = append(args, *status)
+		argPos++
+	}
+
+	// Same (timestamp, id) cursor scheme as ListTransactions
+	if cursor != nil && *cursor != "" {
+		cursorID, cursorTime, err := decodeCursor(*cursor)
+		if err == nil {
+			query += fmt.Sprintf(" AND (submitted_at < $%d OR (submitted_at = $%d AND id < $%d))", argPos, argPos, argPos+1)
+			args = append(args, cursorTime, cursorID)
+			argPos += 2
+		}
+	}
+
+	query += fmt.Sprintf(" ORDER BY submitted_at DESC, id DESC LIMIT $%d", argPos)
+	args = append(args, limit+1) // Fetch one extra to determine if there's a next page
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []models.AMOEEntry{}
+	for rows.Next() {
+		e, err := scanAMOEEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var nextCursor *string
+	if len(entries) > limit {
+		last := entries[limit-1]
+		cursorStr := encodeCursor(last.ID, last.SubmittedAt)
+		nextCursor = &cursorStr
+		entries = entries[:limit]
+	}
+
+	return &models.AMOEEntryList{
+		Items:      entries,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// ReviewAMOEEntry records the outcome of a review
+func (r *Repository) ReviewAMOEEntry(tx *sql.Tx, e *models.AMOEEntry) error {
+	var reviewedAt time.Time
+	err := tx.QueryRow(`
+		UPDATE amoe_entries
+		SET status = $2, reviewed_by = $3, reviewed_at = NOW(), review_note = $4, transaction_id = $5
+		WHERE id = $1
+		RETURNING reviewed_at
+	`, e.ID, e.Status, e.ReviewedBy, e.ReviewNote, e.TransactionID).Scan(&reviewedAt)
+	if err != nil {
+		return err
+	}
+
+	e.ReviewedAt = &reviewedAt
+	return nil
+}
+
+// AddAMOEAuditEvent appends to an entry's audit trail
+func (r *Repository) AddAMOEAuditEvent(tx *sql.Tx, ev *models.AMOEAuditEvent) error {
+	return tx.QueryRow(`
+		INSERT INTO amoe_audit (entry_id, action, actor, note)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`, ev.EntryID, ev.Action, ev.Actor, ev.Note).Scan(&ev.ID, &ev.CreatedAt)
+}
+
+// ListAMOEAuditEvents retrieves an entry's audit trail oldest first
+func (r *Repository) ListAMOEAuditEvents(entryID int) ([]models.AMOEAuditEvent, error) {
+	rows, err := r.db.Query(`
+		SELECT id, entry_id, action, actor, note, created_at
+		FROM amoe_audit
+		WHERE entry_id = $1
+		ORDER BY id
+	`, entryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []models.AMOEAuditEvent{}
+	for rows.Next() {
+		var ev models.AMOEAuditEvent
+		if err := rows.Scan(&ev.ID, &ev.EntryID, &ev.Action, &ev.Actor, &ev.Note, &ev.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, ev)
+	}
+
+	return events, rows.Err()
+}
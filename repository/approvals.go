@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+	"wallet-ledger/models"
+)
+
+var (
+	// ErrApprovalNotFound is returned when an approval request ID does not exist
+	ErrApprovalNotFound = errors.New("approval request not found")
+)
+
+const approvalRequestColumns = `id, operation, user_id, currency, amount, reason_code, campaign_id, note, requested_by,
+	idempotency_key, status, created_at, expires_at, decided_by, decided_at, decision_note, transaction_id`
+
+func scanApprovalRequest(row interface{ Scan(...interface{}) error }) (*models.ApprovalRequest, error) {
+	var a models.ApprovalRequest
+	var reason, campaignID, decidedBy sql.NullString
+	var decidedAt sql.NullTime
+	var transactionID sql.NullInt64
+
+	err := row.Scan(&a.ID, &a.Operation, &a.UserID, &a.Currency, &a.Amount, &reason, &campaignID, &a.Note, &a.RequestedBy,
+		&a.IdempotencyKey, &a.Status, &a.CreatedAt, &a.ExpiresAt, &decidedBy, &decidedAt, &a.DecisionNote, &transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.Reason = models.AdjustmentReason(reason.String)
+	a.CampaignID = campaignID.String
+	if decidedBy.Valid {
+		a.DecidedBy = &decidedBy.String
+	}
+	if decidedAt.Valid {
+		a.DecidedAt = &decidedAt.Time
+	}
+	if transactionID.Valid {
+		id := int(transactionID.Int64)
+		a.TransactionID = &id
+	}
+	return &a, nil
+}
+
+// ListApprovalThresholds retrieves every approval threshold
+func (r *Repository) ListApprovalThresholds() ([]models.ApprovalThreshold, error) {
+	rows, err := r.db.Query(`
+		SELECT operation, currency, threshold, expires_after_hours, updated_at
+		FROM approval_thresholds
+		ORDER BY operation, currency
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	thresholds := []models.ApprovalThreshold{}
+	for rows.Next() {
+		var t models.ApprovalThreshold
+		if err := rows.Scan(&t.Operation, &t.Currency, &t.Threshold, &t.ExpiresAfterHours, &t.UpdatedAt); err != nil {
+			return nil, err
+		}
+		thresholds = append(thresholds, t)
+	}
+
+	return thresholds, rows.Err()
+}
+
+// GetApprovalThreshold retrieves the threshold of an operation in a currency,
+// or nil if none is configured
+func (r *Repository) GetApprovalThreshold(operation models.ApprovalOperation, currency models.Currency) (*models.ApprovalThreshold, error) {
+	t := models.ApprovalThreshold{Operation: operation, Currency: currency}
+	err := r.db.QueryRow(`
+		SELECT threshold, expires_after_hours, updated_at
+		FROM approval_thresholds
+		WHERE operation = $1 AND currency = $2
+	`, operation, currency).Scan(&t.Threshold, &t.ExpiresAfterHours, &t.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// SaveApprovalThreshold creates or updates the threshold of an operation in a currency
+func (r *Repository) SaveApprovalThreshold(t *models.ApprovalThreshold) error {
+	return r.db.QueryRow(`
+		INSERT INTO approval_thresholds (operation, currency, threshold, expires_after_hours)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (operation, currency) DO UPDATE SET
+			threshold = EXCLUDED.threshold,
+			expires_after_hours = EXCLUDED.expires_after_hours,
+			updated_at = NOW()
+		RETURNING updated_at
+	`, t.Operation, t.Currency, t.Threshold, t.ExpiresAfterHours).Scan(&t.UpdatedAt)
+}
+
+// CreateApprovalRequest records a pending approval request
+func (r *Repository) CreateApprovalRequest(tx *sql.Tx, a *models.ApprovalRequest) error {
+	var reason, campaignID sql.NullString
+	if a.Reason != "" {
+		reason = sql.NullString{String: string(a.Reason), Valid: true}
+	}
+	if a.CampaignID != "" {
+		campaignID = sql.NullString{String: a.CampaignID, Valid: true}
+	}
+
+	return tx.QueryRow(`
+		INSERT INTO approval_requests (operation, user_id, currency, amount, reason_code, campaign_id, note, requested_by,
+			idempotency_key, status, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id
+	`, a.Operation, a.UserID, a.Currency, a.Amount, reason, campaignID, a.Note, a.RequestedBy,
+		a.IdempotencyKey, a.Status, a.CreatedAt, a.ExpiresAt).Scan(&a.ID)
+}
+
+// GetApprovalRequestByKeyTx retrieves the approval request a user's operation
+// was held under, or nil if the idempotency key has none
+func (r *Repository) GetApprovalRequestByKeyTx(tx *sql.Tx, userID int, key string) (*models.ApprovalRequest, error) {
+	a, err := scanApprovalRequest(tx.QueryRow(`
+		SELECT `+approvalRequestColumns+`
+		FROM approval_requests
+		WHERE user_id = $1 AND idempotency_key = $2
+	`, userID, key))
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return a, err
+}
+
+// GetApprovalRequest retrieves an approval request by ID
+func (r *Repository) GetApprovalRequest(requestID int) (*models.ApprovalRequest, error) {
+	a, err := scanApprovalRequest(r.db.QueryRow(`
+		SELECT `+approvalRequestColumns+`
+		FROM approval_requests
+		WHERE id = $1
+	`, requestID))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrApprovalNotFound
+	}
+	return a, err
+}
+
+// LockApprovalRequestTx retrieves and locks an approval request until tx ends
+func (r *Repository) LockApprovalRequestTx(tx *sql.Tx, requestID int) (*models.ApprovalRequest, error) {
+	a, err := scanApprovalRequest(tx.QueryRow(`
+		SELECT `+approvalRequestColumns+`
+		FROM approval_requests
+		WHERE id = $1
+		FOR UPDATE
+	`, requestID))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrApprovalNotFound
+	}
+	return a, err
+}
+
+// ListApprovalRequests retrieves approval requests newest first with cursor-based pagination
+func (r *Repository) ListApprovalRequests(status *models.ApprovalStatus, operation *models.ApprovalOperation, cursor *string, limit int) (*models.ApprovalRequestList, error) {
+	query := `SELECT ` + approvalRequestColumns + ` FROM approval_requests WHERE 1=1`
+	args := []interface{}{}
+	argPos := 1
+
+	if status != nil {
+		query += fmt.Sprintf(" AND status = $%d", argPos)
+		args = append(args, *status)
+		argPos++
+	}
+	if operation != nil {
+		query += fmt.Sprintf(" AND operation = $%d", argPos)
+		args = append(args, *operation)
+		argPos++
+	}
+
+	// Same (timestamp, id) cursor scheme as ListTransactions
+	if cursor != nil && *cursor != "" {
+		cursorID, cursorTime, err := decodeCursor(*cursor)
+		if err == nil {
+			query += fmt.Sprintf(" AND (created_at < $%d OR (created_at = $%d AND id < $%d))", argPos, argPos, argPos+1)
+			args = append(args, cursorTime, cursorID)
+			argPos += 2
+		}
+	}
+
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", argPos)
+	args = append(args, limit+1) // Fetch one extra to determine if there's a next page
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	requests := []models.ApprovalRequest{}
+	for rows.Next() {
+		a, err := scanApprovalRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, *a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var nextCursor *string
+	if len(requests) > limit {
+		last := requests[limit-1]
+		cursorStr := encodeCursor(last.ID, last.CreatedAt)
+		nextCursor = &cursorStr
+		requests = requests[:limit]
+	}
+
+	return &models.ApprovalRequestList{
+		Items:      requests,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// DecideApprovalRequest records the approval, rejection or expiry of a request
+func (r *Repository) DecideApprovalRequest(tx *sql.Tx, a *models.ApprovalRequest) error {
+	var decidedAt time.Time
+	err := tx.QueryRow(`
+		UPDATE approval_requests
+		SET status = $2, decided_by = $3, decided_at = NOW(), decision_note = $4, transaction_id = $5
+		WHERE id = $1
+		RETURNING decided_at
+	`, a.ID, a.Status, a.DecidedBy, a.DecisionNote, a.TransactionID).Scan(&decidedAt)
+	if err != nil {
+		return err
+	}
+
+	a.DecidedAt = &decidedAt
+	return nil
+}
+
+// ExpireApprovalRequestsTx marks pending requests whose expiry has passed as
+// expired and returns their IDs
+func (r *Repository) ExpireApprovalRequestsTx(tx *sql.Tx, now time.Time) ([]int, error) {
+	rows, err := tx.Query(`
+		UPDATE approval_requests
+		SET status = 'expired', decided_at = NOW()
+		WHERE status = 'pending' AND expires_at <= $1
+		RETURNING id
+	`, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// AddApprovalEvent appends to a request's approval history
+func (r *Repository) AddApprovalEvent(tx *sql.Tx, ev *models.ApprovalEvent) error {
+	return tx.QueryRow(`
+		INSERT INTO approval_events (request_id, action, actor, note)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`, ev.RequestID, ev.Action, ev.Actor, ev.Note).Scan(&ev.ID, &ev.CreatedAt)
+}
+
+// ListApprovalEvents retrieves a request's approval history oldest first
+func (r *Repository) ListApprovalEvents(requestID int) ([]models.ApprovalEvent, error) {
+	rows, err := r.db.Query(`
+		SELECT id, request_id, action, actor, note, created_at
+		FROM approval_events
+		WHERE request_id = $1
+		ORDER BY id
+	`, requestID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []models.ApprovalEvent{}
+	for rows.Next() {
+		var ev models.ApprovalEvent
+		if err := rows.Scan(&ev.ID, &ev.RequestID, &ev.Action, &ev.Actor, &ev.Note, &ev.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, ev)
+	}
+
+	return events, rows.Err()
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"wallet-ledger/models"
+
+	"github.com/lib/pq"
+)
+
+// ErrCampaignNotFound is returned when a bonus campaign ID does not exist
+var ErrCampaignNotFound = errors.New("campaign not found")
+
+const campaignColumns = `id, name, budget_gc, budget_sc, per_user_cap_gc, per_user_cap_sc, issued_gc, issued_sc, starts_at, ends_at, enabled, created_at, updated_at`
+
+func scanCampaign(row interface{ Scan(...interface{}) error }) (*models.BonusCampaign, error) {
+	var c models.BonusCampaign
+	var startsAt, endsAt sql.NullTime
+
+	err := row.Scan(&c.ID, &c.Name, &c.BudgetGC, &c.BudgetSC, &c.PerUserCapGC, &c.PerUserCapSC,
+		&c.IssuedGC, &c.IssuedSC, &startsAt, &endsAt, &c.Enabled, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if startsAt.Valid {
+		c.StartsAt = &startsAt.Time
+	}
+	if endsAt.Valid {
+		c.EndsAt = &endsAt.Time
+	}
+	return &c, nil
+}
+
+// GetCampaign retrieves a bonus campaign by ID
+func (r *Repository) GetCampaign(campaignID string) (*models.BonusCampaign, error) {
+	c, err := scanCampaign(r.db.QueryRow(`
+		SELECT `+campaignColumns+`
+		FROM bonus_campaigns
+		WHERE id = $1
+	`, campaignID))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrCampaignNotFound
+	}
+	return c, err
+}
+
+// LockCampaignTx retrieves and locks a bonus campaign until tx ends
+func (r *Repository) LockCampaignTx(tx *sql.Tx, campaignID string) (*models.BonusCampaign, error) {
+	c, err := scanCampaign(tx.QueryRow(`
+		SELECT `+campaignColumns+`
+		FROM bonus_campaigns
+		WHERE id = $1
+		FOR UPDATE
+	`, campaignID))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrCampaignNotFound
+	}
+	return c, err
+}
+
+// ListCampaigns retrieves all bonus campaigns with their issued totals
+func (r *Repository) ListCampaigns() ([]models.BonusCampaign, error) {
+	rows, err := r.db.Query(`
+		SELECT ` + campaignColumns + `
+		FROM bonus_campaigns
+		ORDER BY created_at DESC, id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	campaigns := []models.BonusCampaign{}
+	for rows.Next() {
+		c, err := scanCampaign(rows)
+		if err != nil {
+			return nil, err
+		}
+		campaigns = append(campaigns, *c)
+	}
+
+	return campaigns, rows.Err()
+}
+
+// SaveCampaign creates or reconfigures a bonus campaign, keeping its issued totals
+func (r *Repository) SaveCampaign(c *models.BonusCampaign) error {
+	return r.db.QueryRow(`
+		INSERT INTO bonus_campaigns (id, name, budget_gc, budget_sc, per_user_cap_gc, per_user_cap_sc, starts_at, ends_at, enabled)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			budget_gc = EXCLUDED.budget_gc,
+			budget_sc = EXCLUDED.budget_sc,
+			per_user_cap_gc = EXCLUDED.per_user_cap_gc,
+			per_user_cap_sc = EXCLUDED.per_user_cap_sc,
+			starts_at = EXCLUDED.starts_at,
+			ends_at = EXCLUDED.ends_at,
+			enabled = EXCLUDED.enabled,
+			updated_at = NOW()
+		RETURNING issued_gc, issued_sc, created_at, updated_at
+	`, c.ID, c.Name, c.BudgetGC, c.BudgetSC, c.PerUserCapGC, c.PerUserCapSC, c.StartsAt, c.EndsAt, c.Enabled).
+		Scan(&c.IssuedGC, &c.IssuedSC, &c.CreatedAt, &c.UpdatedAt)
+}
+
+// GetUserCampaignTotalsTx returns how much GC and SC a user has been granted under a campaign
+func (r *Repository) GetUserCampaignTotalsTx(tx *sql.Tx, campaignID string, userID int) (int64, int64, error) {
+	var gc, sc int64
+	err := tx.QueryRow(`
+		SELECT COALESCE(SUM(amount_gc), 0), COALESCE(SUM(amount_sc), 0)
+		FROM bonus_grants
+		WHERE campaign_id = $1 AND user_id = $2
+	`, campaignID, userID).Scan(&gc, &sc)
+	return gc, sc, err
+}
+
+// RecordBonusGrant adds a grant to its campaign's issued totals and records it
+func (r *Repository) RecordBonusGrant(tx *sql.Tx, campaignID string, userID int, amountGC, amountSC int64, transactionIDs []int) error {
+	_, err := tx.Exec(`
+		UPDATE bonus_campaigns
+		SET issued_gc = issued_gc + $2, issued_sc = issued_sc + $3, updated_at = NOW()
+		WHERE id = $1
+	`, campaignID, amountGC, amountSC)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO bonus_grants (campaign_id, user_id, amount_gc, amount_sc, transaction_ids)
+		VALUES ($1, $2, $3, $4, $5)
+	`, campaignID, userID, amountGC, amountSC, pq.Array(transactionIDs))
+	return err
+}
+
+// GetCampaignGrantCounts returns the number of grants and distinct users of a campaign
+func (r *Repository) GetCampaignGrantCounts(campaignID string) (int, int, error) {
+	var grants, users int
+	err := r.db.QueryRow(`
+		SELECT COUNT(*), COUNT(DISTINCT user_id)
+		FROM bonus_grants
+		WHERE campaign_id = $1
+	`, campaignID).Scan(&grants, &users)
+	return grants, users, err
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"wallet-ledger/models"
+)
+
+// ListCurrencies retrieves the currency registry ordered by code
+func (r *Repository) ListCurrencies() ([]models.CurrencyInfo, error) {
+	rows, err := r.db.Query(`
+		SELECT code, name, minor_units, purchasable, wagerable, redeemable, created_at, updated_at
+		FROM currencies
+		ORDER BY code
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var currencies []models.CurrencyInfo
+	for rows.Next() {
+		var c models.CurrencyInfo
+		err := rows.Scan(&c.Code, &c.Name, &c.MinorUnits, &c.Purchasable, &c.Wagerable, &c.Redeemable, &c.CreatedAt, &c.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		currencies = append(currencies, c)
+	}
+
+	return currencies, rows.Err()
+}
+
+// SaveCurrency creates or updates a currency registry entry
+func (r *Repository) SaveCurrency(c *models.CurrencyInfo) error {
+	return r.db.QueryRow(`
+		INSERT INTO currencies (code, name, minor_units, purchasable, wagerable, redeemable)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (code) DO UPDATE SET
+			name = EXCLUDED.name,
+			minor_units = EXCLUDED.minor_units,
+			purchasable = EXCLUDED.purchasable,
+			wagerable = EXCLUDED.wagerable,
+			redeemable = EXCLUDED.redeemable,
+			updated_at = NOW()
+		RETURNING created_at, updated_at
+	`, c.Code, c.Name, c.MinorUnits, c.Purchasable, c.Wagerable, c.Redeemable).Scan(&c.CreatedAt, &c.UpdatedAt)
+}
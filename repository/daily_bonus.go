@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"database/sql"
+	"wallet-ledger/models"
+
+	"github.com/lib/pq"
+)
+
+// GetDailyBonusSchedule retrieves the streak schedule ordered by day
+func (r *Repository) GetDailyBonusSchedule() ([]models.DailyBonusDay, error) {
+	rows, err := r.db.Query(`
+		SELECT day, amount_gc, amount_sc
+		FROM daily_bonus_schedule
+		ORDER BY day
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	schedule := []models.DailyBonusDay{}
+	for rows.Next() {
+		var d models.DailyBonusDay
+		if err := rows.Scan(&d.Day, &d.AmountGC, &d.AmountSC); err != nil {
+			return nil, err
+		}
+		schedule = append(schedule, d)
+	}
+
+	return schedule, rows.Err()
+}
+
+// SaveDailyBonusSchedule replaces the streak schedule
+func (r *Repository) SaveDailyBonusSchedule(schedule []models.DailyBonusDay) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM daily_bonus_schedule`); err != nil {
+		return err
+	}
+
+	for _, d := range schedule {
+		_, err := tx.Exec(`
+			INSERT INTO daily_bonus_schedule (day, amount_gc, amount_sc)
+			VALUES ($1, $2, $3)
+		`, d.Day, d.AmountGC, d.AmountSC)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetLastDailyBonusClaimTx retrieves a user's most recent daily bonus claim and
+// the IDs of its transactions, or nil if the user never claimed one
+func (r *Repository) GetLastDailyBonusClaimTx(tx *sql.Tx, userID int) (*models.DailyBonusClaim, []int, error) {
+	var c models.DailyBonusClaim
+	var claimDate sql.NullTime
+	var transactionIDs pq.Int64Array
+
+	err := tx.QueryRow(`
+		SELECT user_id, claim_date, streak, amount_gc, amount_sc, transaction_ids, created_at
+		FROM daily_bonus_claims
+		WHERE user_id = $1
+		ORDER BY claim_date DESC
+		LIMIT 1
+	`, userID).Scan(&c.UserID, &claimDate, &c.Streak, &c.AmountGC, &c.AmountSC, &transactionIDs, &c.ClaimedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.ClaimDate = claimDate.Time.Format("2006-01-02")
+	ids := make([]int, len(transactionIDs))
+	for i, v := range transactionIDs {
+		ids[i] = int(v)
+	}
+	return &c, ids, nil
+}
+
+// CreateDailyBonusClaim records a daily bonus claim
+func (r *Repository) CreateDailyBonusClaim(tx *sql.Tx, c *models.DailyBonusClaim, transactionIDs []int) error {
+	_, err := tx.Exec(`
+		INSERT INTO daily_bonus_claims (user_id, claim_date, streak, amount_gc, amount_sc, transaction_ids, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, c.UserID, c.ClaimDate, c.Streak, c.AmountGC, c.AmountSC, pq.Array(transactionIDs), c.ClaimedAt)
+	return err
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+	"wallet-ledger/models"
+)
+
+const gameSessionColumns = `id, user_id, started_at, last_activity_at, ended_at, wagers,
+	gc_staked, gc_won, sc_staked, sc_won, loss_cap_gc, loss_cap_sc,
+	reality_check_minutes, reality_check_at, reality_checks`
+
+func scanGameSession(row interface{ Scan(...interface{}) error }) (*models.GameSession, error) {
+	var s models.GameSession
+	var endedAt sql.NullTime
+
+	err := row.Scan(&s.ID, &s.UserID, &s.StartedAt, &s.LastActivityAt, &endedAt, &s.Wagers,
+		&s.GCStaked, &s.GCWon, &s.SCStaked, &s.SCWon, &s.LossCapGC, &s.LossCapSC,
+		&s.RealityCheckMinutes, &s.RealityCheckAt, &s.RealityChecks)
+	if err != nil {
+		return nil, err
+	}
+
+	if endedAt.Valid {
+		s.EndedAt = &endedAt.Time
+	}
+	return &s, nil
+}
+
+func scanGameSessionSettings(row *sql.Row, userID int) (*models.GameSessionSettings, error) {
+	s := models.GameSessionSettings{UserID: userID}
+	err := row.Scan(&s.RealityCheckMinutes, &s.LossCapGC, &s.LossCapSC, &s.UpdatedAt)
+	if err == sql.ErrNoRows {
+		// Users who never saved settings play without reality checks or caps
+		return &s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+const gameSessionSettingsQuery = `
+	SELECT reality_check_minutes, loss_cap_gc, loss_cap_sc, updated_at
+	FROM game_session_settings
+	WHERE user_id = $1
+`
+
+// GetGameSessionSettings retrieves a user's session settings
+func (r *Repository) GetGameSessionSettings(userID int) (*models.GameSessionSettings, error) {
+	return scanGameSessionSettings(r.db.QueryRow(gameSessionSettingsQuery, userID), userID)
+}
+
+// GetGameSessionSettingsTx retrieves a user's session settings within a transaction
+func (r *Repository) GetGameSessionSettingsTx(tx *sql.Tx, userID int) (*models.GameSessionSettings, error) {
+	return scanGameSessionSettings(tx.QueryRow(gameSessionSettingsQuery, userID), userID)
+}
+
+// SaveGameSessionSettings creates or updates a user's session settings
+func (r *Repository) SaveGameSessionSettings(s *models.GameSessionSettings) error {
+	return r.db.QueryRow(`
+		INSERT INTO game_session_settings (user_id, reality_check_minutes, loss_cap_gc, loss_cap_sc)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE SET
+			reality_check_minutes = EXCLUDED.reality_check_minutes,
+			loss_cap_gc = EXCLUDED.loss_cap_gc,
+			loss_cap_sc = EXCLUDED.loss_cap_sc,
+			updated_at = NOW()
+		RETURNING updated_at
+	`, s.UserID, s.RealityCheckMinutes, s.LossCapGC, s.LossCapSC).Scan(&s.UpdatedAt)
+}
+
+// GetOpenGameSession retrieves a user's open session, or nil if there is none
+func (r *Repository) GetOpenGameSession(userID int) (*models.GameSession, error) {
+	s, err := scanGameSession(r.db.QueryRow(`
+		SELECT `+gameSessionColumns+`
+		FROM game_sessions
+		WHERE user_id = $1 AND ended_at IS NULL
+	`, userID))
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return s, err
+}
+
+// LockOpenGameSessionTx retrieves and locks a user's open session, or returns
+// nil if there is none
+func (r *Repository) LockOpenGameSessionTx(tx *sql.Tx, userID int) (*models.GameSession, error) {
+	s, err := scanGameSession(tx.QueryRow(`
+		SELECT `+gameSessionColumns+`
+		FROM game_sessions
+		WHERE user_id = $1 AND ended_at IS NULL
+		FOR UPDATE
+	`, userID))
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return s, err
+}
+
+// CreateGameSessionTx opens a session at now for a user with their current settings
+func (r *Repository) CreateGameSessionTx(tx *sql.Tx, settings *models.GameSessionSettings, now time.Time) (*models.GameSession, error) {
+	return scanGameSession(tx.QueryRow(`
+		INSERT INTO game_sessions (user_id, started_at, last_activity_at, reality_check_at, loss_cap_gc, loss_cap_sc, reality_check_minutes)
+		VALUES ($1, $2, $2, $2, $3, $4, $5)
+		RETURNING `+gameSessionColumns,
+		settings.UserID, now, settings.LossCapGC, settings.LossCapSC, settings.RealityCheckMinutes))
+}
+
+// CloseGameSessionTx ends a session as of its last activity
+func (r *Repository) CloseGameSessionTx(tx *sql.Tx, id int) error {
+	_, err := tx.Exec(`
+		UPDATE game_sessions SET ended_at = last_activity_at
+		WHERE id = $1 AND ended_at IS NULL
+	`, id)
+	return err
+}
+
+// AddGameSessionActivityTx adds a wager's stakes and winnings to a session,
+// recording at as its latest activity
+func (r *Repository) AddGameSessionActivityTx(tx *sql.Tx, id int, at time.Time, gcStaked, gcWon, scStaked, scWon int64) error {
+	_, err := tx.Exec(`
+		UPDATE game_sessions SET
+			wagers = wagers + 1,
+			gc_staked = gc_staked + $2,
+			gc_won = gc_won + $3,
+			sc_staked = sc_staked + $4,
+			sc_won = sc_won + $5,
+			last_activity_at = GREATEST(last_activity_at, $6)
+		WHERE id = $1
+	`, id, gcStaked, gcWon, scStaked, scWon, at)
+	return err
+}
+
+// RefundGameSessionStakeTx takes a refunded stake off the session of the user
+// that was running when the stake was wagered, if any
+func (r *Repository) RefundGameSessionStakeTx(tx *sql.Tx, userID int, currency models.Currency, amount int64, wageredAt time.Time) error {
+	var column string
+	switch currency {
+	case models.CurrencyGC:
+		column = "gc_staked"
+	case models.CurrencySC:
+		column = "sc_staked"
+	default:
+		// Sessions only keep GC and SC totals
+		return nil
+	}
+
+	_, err := tx.Exec(fmt.Sprintf(`
+		UPDATE game_sessions SET %[1]s = %[1]s - $3
+		WHERE user_id = $1 AND started_at <= $2 AND (ended_at IS NULL OR ended_at >= $2)
+	`, column), userID, wageredAt, amount)
+	return err
+}
+
+// AcknowledgeRealityCheckTx restarts a session's reality check interval at now
+func (r *Repository) AcknowledgeRealityCheckTx(tx *sql.Tx, id int, now time.Time) (*models.GameSession, error) {
+	return scanGameSession(tx.QueryRow(`
+		UPDATE game_sessions SET reality_check_at = $2, reality_checks = reality_checks + 1
+		WHERE id = $1
+		RETURNING `+gameSessionColumns, id, now))
+}
+
+// ListGameSessions retrieves a user's most recent sessions, newest first
+func (r *Repository) ListGameSessions(userID int, limit int) ([]models.GameSession, error) {
+	rows, err := r.db.Query(`
+		SELECT `+gameSessionColumns+`
+		FROM game_sessions
+		WHERE user_id = $1
+		ORDER BY id DESC
+		LIMIT $2
+	`, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []models.GameSession{}
+	for rows.Next() {
+		s, err := scanGameSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, *s)
+	}
+
+	return sessions, rows.Err()
+}
+
+// CloseIdleGameSessions ends every open session with no activity since cutoff
+// and returns how many were closed
+func (r *Repository) CloseIdleGameSessions(cutoff time.Time) (int, error) {
+	result, err := r.db.Exec(`
+		UPDATE game_sessions SET ended_at = last_activity_at
+		WHERE ended_at IS NULL AND last_activity_at <= $1
+	`, cutoff)
+	if err != nil {
+		return 0, err
+	}
+
+	closed, err := result.RowsAffected()
+	return int(closed), err
+}
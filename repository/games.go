@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"wallet-ledger/models"
+)
+
+// ErrGameNotFound is returned when a game ID is not registered
+var ErrGameNotFound = errors.New("game not found")
+
+// GetGame retrieves a game with its allowed currencies
+func (r *Repository) GetGame(gameID string) (*models.Game, error) {
+	var g models.Game
+	err := r.db.QueryRow(`
+		SELECT id, provider, name, rtp, enabled, created_at, updated_at
+		FROM games
+		WHERE id = $1
+	`, gameID).Scan(&g.ID, &g.Provider, &g.Name, &g.RTP, &g.Enabled, &g.CreatedAt, &g.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, ErrGameNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(`
+		SELECT currency, min_stake, max_stake
+		FROM game_currencies
+		WHERE game_id = $1
+		ORDER BY currency
+	`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	g.Currencies = []models.GameCurrency{}
+	for rows.Next() {
+		var c models.GameCurrency
+		if err := rows.Scan(&c.Currency, &c.MinStake, &c.MaxStake); err != nil {
+			return nil, err
+		}
+		g.Currencies = append(g.Currencies, c)
+	}
+
+	return &g, rows.Err()
+}
+
+// ListGames retrieves all registered games with their allowed currencies
+func (r *Repository) ListGames() ([]models.Game, error) {
+	rows, err := r.db.Query(`
+		SELECT id, provider, name, rtp, enabled, created_at, updated_at
+		FROM games
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	games := []models.Game{}
+	index := make(map[string]int)
+	for rows.Next() {
+		var g models.Game
+		if err := rows.Scan(&g.ID, &g.Provider, &g.Name, &g.RTP, &g.Enabled, &g.CreatedAt, &g.UpdatedAt); err != nil {
+			return nil, err
+		}
+		g.Currencies = []models.GameCurrency{}
+		index[g.ID] = len(games)
+		games = append(games, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	currencyRows, err := r.db.Query(`
+		SELECT game_id, currency, min_stake, max_stake
+		FROM game_currencies
+		ORDER BY game_id, currency
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer currencyRows.Close()
+
+	for currencyRows.Next() {
+		var gameID string
+		var c models.GameCurrency
+		if err := currencyRows.Scan(&gameID, &c.Currency, &c.MinStake, &c.MaxStake); err != nil {
+			return nil, err
+		}
+		if i, ok := index[gameID]; ok {
+			games[i].Currencies = append(games[i].Currencies, c)
+		}
+	}
+
+	return games, currencyRows.Err()
+}
+
+// SaveGame creates or replaces a game and its allowed currencies
+func (r *Repository) SaveGame(g *models.Game) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`
+		INSERT INTO games (id, provider, name, rtp, enabled)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO UPDATE SET
+			provider = EXCLUDED.provider,
+			name = EXCLUDED.name,
+			rtp = EXCLUDED.rtp,
+			enabled = EXCLUDED.enabled,
+			updated_at = NOW()
+		RETURNING created_at, updated_at
+	`, g.ID, g.Provider, g.Name, g.RTP, g.Enabled).Scan(&g.CreatedAt, &g.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM game_currencies WHERE game_id = $1`, g.ID); err != nil {
+		return err
+	}
+
+	for _, c := range g.Currencies {
+		_, err := tx.Exec(`
+			INSERT INTO game_currencies (game_id, currency, min_stake, max_stake)
+			VALUES ($1, $2, $3, $4)
+		`, g.ID, c.Currency, c.MinStake, c.MaxStake)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
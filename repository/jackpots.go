@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"wallet-ledger/models"
+
+	"github.com/lib/pq"
+)
+
+// ErrJackpotNotFound is returned when a jackpot pool ID does not exist
+var ErrJackpotNotFound = errors.New("jackpot pool not found")
+
+const jackpotPoolColumns = `id, name, currency, contribution_bps, min_stake, seed_amount, amount, enabled, created_at, updated_at`
+
+func scanJackpotPool(row interface{ Scan(...interface{}) error }) (*models.JackpotPool, error) {
+	var p models.JackpotPool
+	err := row.Scan(&p.ID, &p.Name, &p.Currency, &p.ContributionBPS, &p.MinStake, &p.SeedAmount, &p.Amount, &p.Enabled, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// GetJackpotPool retrieves a jackpot pool with its live amount
+func (r *Repository) GetJackpotPool(poolID string) (*models.JackpotPool, error) {
+	pool, err := scanJackpotPool(r.db.QueryRow(`
+		SELECT `+jackpotPoolColumns+`
+		FROM jackpot_pools
+		WHERE id = $1
+	`, poolID))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrJackpotNotFound
+	}
+	return pool, err
+}
+
+// ListJackpotPools retrieves all jackpot pools with their live amounts
+func (r *Repository) ListJackpotPools() ([]models.JackpotPool, error) {
+	rows, err := r.db.Query(`
+		SELECT ` + jackpotPoolColumns + `
+		FROM jackpot_pools
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pools := []models.JackpotPool{}
+	for rows.Next() {
+		pool, err := scanJackpotPool(rows)
+		if err != nil {
+			return nil, err
+		}
+		pools = append(pools, *pool)
+	}
+
+	return pools, rows.Err()
+}
+
+// SaveJackpotPool creates a pool starting at its seed amount, or updates the
+// configuration of an existing pool without touching its current amount
+func (r *Repository) SaveJackpotPool(p *models.JackpotPool) error {
+	return r.db.QueryRow(`
+		INSERT INTO jackpot_pools (id, name, currency, contribution_bps, min_stake, seed_amount, amount, enabled)
+		VALUES ($1, $2, $3, $4, $5, $6, $6, $7)
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			currency = EXCLUDED.currency,
+			contribution_bps = EXCLUDED.contribution_bps,
+			min_stake = EXCLUDED.min_stake,
+			seed_amount = EXCLUDED.seed_amount,
+			enabled = EXCLUDED.enabled,
+			updated_at = NOW()
+		RETURNING amount, created_at, updated_at
+	`, p.ID, p.Name, p.Currency, p.ContributionBPS, p.MinStake, p.SeedAmount, p.Enabled).
+		Scan(&p.Amount, &p.CreatedAt, &p.UpdatedAt)
+}
+
+// ListEnabledJackpotPoolsTx retrieves the enabled pools of the given
+// currencies in ID order, without locking them
+func (r *Repository) ListEnabledJackpotPoolsTx(tx *sql.Tx, currencies []models.Currency) ([]*models.JackpotPool, error) {
+	codes := make([]string, len(currencies))
+	for i, c := range currencies {
+		codes[i] = string(c)
+	}
+
+	rows, err := tx.Query(`
+		SELECT `+jackpotPoolColumns+`
+		FROM jackpot_pools
+		WHERE enabled AND currency = ANY($1)
+		ORDER BY id
+	`, pq.Array(codes))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pools []*models.JackpotPool
+	for rows.Next() {
+		pool, err := scanJackpotPool(rows)
+		if err != nil {
+			return nil, err
+		}
+		pools = append(pools, pool)
+	}
+
+	return pools, rows.Err()
+}
+
+// LockJackpotPoolTx locks a single pool, enabled or not, until tx ends
+func (r *Repository) LockJackpotPoolTx(tx *sql.Tx, poolID string) (*models.JackpotPool, error) {
+	pool, err := scanJackpotPool(tx.QueryRow(`
+		SELECT `+jackpotPoolColumns+`
+		FROM jackpot_pools
+		WHERE id = $1
+		FOR UPDATE
+	`, poolID))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrJackpotNotFound
+	}
+	return pool, err
+}
+
+// AddJackpotContribution atomically adds c.Amount to its pool and records the
+// contribution, setting c.PoolAmountAfter, c.ID and c.CreatedAt. It reports
+// false, recording nothing, if the pool has been disabled.
+func (r *Repository) AddJackpotContribution(tx *sql.Tx, c *models.JackpotContribution) (bool, error) {
+	err := tx.QueryRow(`
+		UPDATE jackpot_pools
+		SET amount = amount + $2, updated_at = NOW()
+		WHERE id = $1 AND enabled
+		RETURNING amount
+	`, c.PoolID, c.Amount).Scan(&c.PoolAmountAfter)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO jackpot_contributions (pool_id, user_id, transaction_id, stake, amount, pool_amount_after)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`, c.PoolID, c.UserID, c.TransactionID, c.Stake, c.Amount, c.PoolAmountAfter).Scan(&c.ID, &c.CreatedAt)
+	return err == nil, err
+}
+
+// RecordJackpotWin resets a paid pool to its seed amount and records the win
+func (r *Repository) RecordJackpotWin(tx *sql.Tx, pool *models.JackpotPool, userID int, transactionID int) error {
+	_, err := tx.Exec(`
+		UPDATE jackpot_pools
+		SET amount = seed_amount, updated_at = NOW()
+		WHERE id = $1
+	`, pool.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO jackpot_wins (pool_id, user_id, transaction_id, amount)
+		VALUES ($1, $2, $3, $4)
+	`, pool.ID, userID, transactionID, pool.Amount)
+	return err
+}
+
+// ListJackpotContributions retrieves a pool's contributions, newest first
+func (r *Repository) ListJackpotContributions(poolID string, cursor *string, limit int) (*models.JackpotContributionList, error) {
+	query := `SELECT id, pool_id, user_id, transaction_id, stake, amount, pool_amount_after, created_at FROM jackpot_contributions WHERE pool_id = $1`
+	args := []interface{}{poolID}
+
+	// Same (created_at, id) cursor scheme as ListTransactions
+	if cursor != nil && *cursor != "" {
+		cursorID, cursorTime, err := decodeCursor(*cursor)
+		if err == nil {
+			query += " AND (created_at < $2 OR (created_at = $2 AND id < $3))"
+			args = append(args, cursorTime, cursorID)
+		}
+	}
+
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", len(args)+1)
+	args = append(args, limit+1) // Fetch one extra to determine if there's a next page
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	contributions := []models.JackpotContribution{}
+	for rows.Next() {
+		var c models.JackpotContribution
+		err := rows.Scan(&c.ID, &c.PoolID, &c.UserID, &c.TransactionID, &c.Stake, &c.Amount, &c.PoolAmountAfter, &c.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		contributions = append(contributions, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var nextCursor *string
+	if len(contributions) > limit {
+		last := contributions[limit-1]
+		cursorStr := encodeCursor(last.ID, last.CreatedAt)
+		nextCursor = &cursorStr
+		contributions = contributions[:limit]
+	}
+
+	return &models.JackpotContributionList{
+		Items:      contributions,
+		NextCursor: nextCursor,
+	}, nil
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// LockPostingBatchTx serializes batch postings sharing key until tx ends and
+// returns the transaction IDs already recorded under it, or nil if it is
+// unused, with the digest of the legs it was posted with. Batches recorded
+// before digests were kept have an empty digest.
+func (r *Repository) LockPostingBatchTx(tx *sql.Tx, key string) ([]int, string, error) {
+	// Batches may involve different users, so the user locks alone do not stop
+	// two batches with the same key from racing
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext('posting_batch:' || $1))`, key); err != nil {
+		return nil, "", err
+	}
+
+	var transactionIDs pq.Int64Array
+	var digest sql.NullString
+	err := tx.QueryRow(`
+		SELECT transaction_ids, legs_digest
+		FROM posting_batches
+		WHERE key = $1
+	`, key).Scan(&transactionIDs, &digest)
+	if err == sql.ErrNoRows {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	result := make([]int, len(transactionIDs))
+	for i, v := range transactionIDs {
+		result[i] = int(v)
+	}
+	return result, digest.String, nil
+}
+
+// SavePostingBatch records the users, transactions and legs digest of a batch
+// posting under its key
+func (r *Repository) SavePostingBatch(tx *sql.Tx, key string, userIDs []int, transactionIDs []int, digest string) error {
+	_, err := tx.Exec(`
+		INSERT INTO posting_batches (key, user_ids, transaction_ids, legs_digest, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, key, pq.Array(userIDs), pq.Array(transactionIDs), digest, time.Now())
+	return err
+}
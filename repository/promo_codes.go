@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"wallet-ledger/models"
+
+	"github.com/lib/pq"
+)
+
+// ErrPromoCodeNotFound is returned when a promo code does not exist
+var ErrPromoCodeNotFound = errors.New("promo code not found")
+
+const promoCodeColumns = `code, description, kind, value_gc, value_sc, package_codes, starts_at, ends_at, max_redemptions, redemptions, enabled, created_at, updated_at`
+
+func scanPromoCode(row interface{ Scan(...interface{}) error }) (*models.PromoCode, error) {
+	var p models.PromoCode
+	var packageCodes pq.StringArray
+	var startsAt, endsAt sql.NullTime
+
+	err := row.Scan(&p.Code, &p.Description, &p.Kind, &p.ValueGC, &p.ValueSC, &packageCodes, &startsAt, &endsAt,
+		&p.MaxRedemptions, &p.Redemptions, &p.Enabled, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	p.PackageCodes = packageCodes
+	if startsAt.Valid {
+		p.StartsAt = &startsAt.Time
+	}
+	if endsAt.Valid {
+		p.EndsAt = &endsAt.Time
+	}
+	return &p, nil
+}
+
+// GetPromoCode retrieves a promo code
+func (r *Repository) GetPromoCode(code string) (*models.PromoCode, error) {
+	p, err := scanPromoCode(r.db.QueryRow(`
+		SELECT `+promoCodeColumns+`
+		FROM promo_codes
+		WHERE code = $1
+	`, code))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrPromoCodeNotFound
+	}
+	return p, err
+}
+
+// LockPromoCodeTx retrieves and locks a promo code until tx ends
+func (r *Repository) LockPromoCodeTx(tx *sql.Tx, code string) (*models.PromoCode, error) {
+	p, err := scanPromoCode(tx.QueryRow(`
+		SELECT `+promoCodeColumns+`
+		FROM promo_codes
+		WHERE code = $1
+		FOR UPDATE
+	`, code))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrPromoCodeNotFound
+	}
+	return p, err
+}
+
+// ListPromoCodes retrieves all promo codes with their redemption counts
+func (r *Repository) ListPromoCodes() ([]models.PromoCode, error) {
+	rows, err := r.db.Query(`
+		SELECT ` + promoCodeColumns + `
+		FROM promo_codes
+		ORDER BY created_at DESC, code
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	codes := []models.PromoCode{}
+	for rows.Next() {
+		p, err := scanPromoCode(rows)
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, *p)
+	}
+
+	return codes, rows.Err()
+}
+
+// SavePromoCode creates or reconfigures a promo code, keeping its redemption count
+func (r *Repository) SavePromoCode(p *models.PromoCode) error {
+	packageCodes := p.PackageCodes
+	if packageCodes == nil {
+		packageCodes = []string{}
+	}
+
+	return r.db.QueryRow(`
+		INSERT INTO promo_codes (code, description, kind, value_gc, value_sc, package_codes, starts_at, ends_at, max_redemptions, enabled)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (code) DO UPDATE SET
+			description = EXCLUDED.description,
+			kind = EXCLUDED.kind,
+			value_gc = EXCLUDED.value_gc,
+			value_sc = EXCLUDED.value_sc,
+			package_codes = EXCLUDED.package_codes,
+			starts_at = EXCLUDED.starts_at,
+			ends_at = EXCLUDED.ends_at,
+			max_redemptions = EXCLUDED.max_redemptions,
+			enabled = EXCLUDED.enabled,
+			updated_at = NOW()
+		RETURNING redemptions, created_at, updated_at
+	`, p.Code, p.Description, p.Kind, p.ValueGC, p.ValueSC, pq.Array(packageCodes), p.StartsAt, p.EndsAt, p.MaxRedemptions, p.Enabled).
+		Scan(&p.Redemptions, &p.CreatedAt, &p.UpdatedAt)
+}
+
+// HasRedeemedPromoCodeTx reports whether a user already redeemed a promo code
+func (r *Repository) HasRedeemedPromoCodeTx(tx *sql.Tx, code string, userID int) (bool, error) {
+	var exists bool
+	err := tx.QueryRow(`
+		SELECT EXISTS (SELECT 1 FROM promo_redemptions WHERE code = $1 AND user_id = $2)
+	`, code, userID).Scan(&exists)
+	return exists, err
+}
+
+// RecordPromoRedemption counts a redemption against its code and records it
+func (r *Repository) RecordPromoRedemption(tx *sql.Tx, code string, userID int, packageCode string, transactionIDs []int) error {
+	_, err := tx.Exec(`
+		UPDATE promo_codes
+		SET redemptions = redemptions + 1, updated_at = NOW()
+		WHERE code = $1
+	`, code)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO promo_redemptions (code, user_id, package_code, transaction_ids)
+		VALUES ($1, $2, $3, $4)
+	`, code, userID, packageCode, pq.Array(transactionIDs))
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -13,6 +14,9 @@ import (
 	"github.com/lib/pq"
 )
 
+// ErrUserNotFound is returned when a user ID does not exist
+var ErrUserNotFound = errors.New("user not found")
+
 type Repository struct {
 	db *sql.DB
 }
@@ -31,17 +35,35 @@ func (r *Repository) BeginTx() (*sql.Tx, error) {
 	return r.db.Begin()
 }
 
+// Savepoint marks a point within tx that later statements can be rolled back to
+func (r *Repository) Savepoint(tx *sql.Tx, name string) error {
+	_, err := tx.Exec("SAVEPOINT " + pq.QuoteIdentifier(name))
+	return err
+}
+
+// RollbackToSavepoint undoes everything in tx after the named savepoint
+func (r *Repository) RollbackToSavepoint(tx *sql.Tx, name string) error {
+	_, err := tx.Exec("ROLLBACK TO SAVEPOINT " + pq.QuoteIdentifier(name))
+	return err
+}
+
+// ReleaseSavepoint keeps the work done since the named savepoint
+func (r *Repository) ReleaseSavepoint(tx *sql.Tx, name string) error {
+	_, err := tx.Exec("RELEASE SAVEPOINT " + pq.QuoteIdentifier(name))
+	return err
+}
+
 // GetUser retrieves a user by ID
 func (r *Repository) GetUser(userID int) (*models.User, error) {
 	var user models.User
 	err := r.db.QueryRow(`
-		SELECT id, username, created_at 
+		SELECT id, username, time_zone, vip_tier, created_at
 		FROM users 
 		WHERE id = $1
-	`, userID).Scan(&user.ID, &user.Username, &user.CreatedAt)
+	`, userID).Scan(&user.ID, &user.Username, &user.TimeZone, &user.VIPTier, &user.CreatedAt)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("user not found")
+		return nil, ErrUserNotFound
 	}
 	if err != nil {
 		return nil, err
@@ -49,87 +71,150 @@ func (r *Repository) GetUser(userID int) (*models.User, error) {
 	return &user, nil
 }
 
+// SetUserTimeZone changes the IANA time zone a user's calendar days are counted in
+func (r *Repository) SetUserTimeZone(userID int, timeZone string) error {
+	result, err := r.db.Exec(`UPDATE users SET time_zone = $2 WHERE id = $1`, userID, timeZone)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// SetUserVIPTier changes a user's VIP tier
+func (r *Repository) SetUserVIPTier(userID int, tier int) error {
+	result, err := r.db.Exec(`UPDATE users SET vip_tier = $2 WHERE id = $1`, userID, tier)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// GetPurchaseHistoryTx summarizes a user's purchases and activity for offer
+// eligibility; BoughtToday lists packages purchased at or after dayStart
+func (r *Repository) GetPurchaseHistoryTx(tx *sql.Tx, userID int, dayStart time.Time) (*models.PurchaseHistory, error) {
+	var h models.PurchaseHistory
+	var lastActivity sql.NullTime
+	var boughtToday pq.StringArray
+
+	err := tx.QueryRow(`
+		SELECT
+			EXISTS (SELECT 1 FROM transactions WHERE user_id = $1 AND type = 'purchase'),
+			(SELECT MAX(created_at) FROM transactions WHERE user_id = $1),
+			ARRAY(
+				SELECT DISTINCT metadata->>'package_code'
+				FROM transactions
+				WHERE user_id = $1 AND type = 'purchase' AND created_at >= $2
+			)
+	`, userID, dayStart).Scan(&h.HasPurchased, &lastActivity, &boughtToday)
+	if err != nil {
+		return nil, err
+	}
+
+	if lastActivity.Valid {
+		h.LastActivityAt = &lastActivity.Time
+	}
+	h.BoughtToday = boughtToday
+	return &h, nil
+}
+
 // GetUserWithBalances retrieves a user with their balances calculated from transactions
 func (r *Repository) GetUserWithBalances(userID int) (*models.UserWithBalances, error) {
 	var result models.UserWithBalances
 	err := r.db.QueryRow(`
-		SELECT id, username, created_at
+		SELECT id, username, time_zone, vip_tier, created_at
 		FROM users
 		WHERE id = $1
-	`, userID).Scan(&result.ID, &result.Username, &result.CreatedAt)
+	`, userID).Scan(&result.ID, &result.Username, &result.TimeZone, &result.VIPTier, &result.CreatedAt)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("user not found")
+		return nil, ErrUserNotFound
 	}
 	if err != nil {
 		return nil, err
 	}
 
-	// Calculate balances from transactions
-	var gcBalance, scBalance sql.NullInt64
-	err = r.db.QueryRow(`
-		SELECT 
-			COALESCE(SUM(CASE WHEN currency = 'GC' THEN 
-				CASE 
-					WHEN type IN ('purchase', 'win_gc') THEN amount
-					WHEN type = 'wager_gc' THEN -amount
-				END
-			END), 0) as gc_balance,
-			COALESCE(SUM(CASE WHEN currency = 'SC' THEN 
-				CASE 
-					WHEN type IN ('purchase', 'win_sc') THEN amount
-					WHEN type IN ('wager_sc', 'redeem_sc') THEN -amount
-				END
-			END), 0) as sc_balance
-		FROM transactions
-		WHERE user_id = $1
-	`, userID).Scan(&gcBalance, &scBalance)
-
+	// Calculate balances and statistics per currency from transactions
+	sums, err := r.SumTransactions(userID)
 	if err != nil {
 		return nil, err
 	}
 
-	if gcBalance.Valid {
-		result.GoldBalance = gcBalance.Int64
-	}
-	if scBalance.Valid {
-		result.SweepsBalance = scBalance.Int64
+	result.Balances = make(map[models.Currency]int64, len(sums))
+	result.Stats = make(map[models.Currency]models.CurrencyStats, len(sums))
+	for currency, sum := range sums {
+		result.Balances[currency] = sum.Balance
+		result.Stats[currency] = models.CurrencyStats{Wagered: sum.Wagered, Won: sum.Won, Redeemed: sum.Redeemed}
 	}
+	gc, sc := sums[models.CurrencyGC], sums[models.CurrencySC]
+	result.GoldBalance = gc.Balance
+	result.SweepsBalance = sc.Balance
+	result.TotalGCWagered = gc.Wagered
+	result.TotalGCWon = gc.Won
+	result.TotalSCWagered = sc.Wagered
+	result.TotalSCWon = sc.Won
+	result.TotalSCRedeemed = sc.Redeemed
+
+	return &result, nil
+}
+
+// LedgerSums are a user's transaction totals in one currency
+type LedgerSums struct {
+	Balance  int64
+	Wagered  int64
+	Won      int64
+	Redeemed int64
+}
 
-	// Calculate statistics from transactions
-	var gcWagered, gcWon, scWagered, scWon, scRedeemed sql.NullInt64
-	err = r.db.QueryRow(`
-		SELECT 
-			COALESCE(SUM(CASE WHEN type = 'wager_gc' THEN amount END), 0) as gc_wagered,
-			COALESCE(SUM(CASE WHEN type = 'win_gc' THEN amount END), 0) as gc_won,
-			COALESCE(SUM(CASE WHEN type = 'wager_sc' THEN amount END), 0) as sc_wagered,
-			COALESCE(SUM(CASE WHEN type = 'win_sc' THEN amount END), 0) as sc_won,
-			COALESCE(SUM(CASE WHEN type = 'redeem_sc' THEN amount END), 0) as sc_redeemed
+// SumTransactions returns a user's balance and statistics per currency,
+// summed from the transactions according to the posting rules
+func (r *Repository) SumTransactions(userID int) (map[models.Currency]LedgerSums, error) {
+	rows, err := r.db.Query(`
+		SELECT currency,
+			COALESCE(SUM(CASE WHEN type = ANY($2) THEN amount WHEN type = ANY($3) THEN -amount END), 0),
+			COALESCE(SUM(CASE WHEN type = ANY($4) THEN amount WHEN type = ANY($5) THEN -amount END), 0),
+			COALESCE(SUM(CASE WHEN type = ANY($6) THEN amount END), 0),
+			COALESCE(SUM(CASE WHEN type = ANY($7) THEN amount END), 0)
 		FROM transactions
 		WHERE user_id = $1
-	`, userID).Scan(&gcWagered, &gcWon, &scWagered, &scWon, &scRedeemed)
-
+		GROUP BY currency
+	`, userID,
+		typeArray(models.TransactionTypesWith(models.Credit)),
+		typeArray(models.TransactionTypesWith(models.Debit)),
+		typeArray(models.TransactionTypesCounting(models.StatWagered, 1)),
+		typeArray(models.TransactionTypesCounting(models.StatWagered, -1)),
+		typeArray(models.TransactionTypesCounting(models.StatWon, 1)),
+		typeArray(models.TransactionTypesCounting(models.StatRedeemed, 1)),
+	)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	if gcWagered.Valid {
-		result.TotalGCWagered = gcWagered.Int64
-	}
-	if gcWon.Valid {
-		result.TotalGCWon = gcWon.Int64
-	}
-	if scWagered.Valid {
-		result.TotalSCWagered = scWagered.Int64
-	}
-	if scWon.Valid {
-		result.TotalSCWon = scWon.Int64
-	}
-	if scRedeemed.Valid {
-		result.TotalSCRedeemed = scRedeemed.Int64
+	sums := make(map[models.Currency]LedgerSums)
+	for rows.Next() {
+		var currency models.Currency
+		var s LedgerSums
+		if err := rows.Scan(&currency, &s.Balance, &s.Wagered, &s.Won, &s.Redeemed); err != nil {
+			return nil, err
+		}
+		sums[currency] = s
 	}
+	return sums, rows.Err()
+}
 
-	return &result, nil
+// typeArray converts transaction types to a Postgres text array parameter
+func typeArray(types []models.TransactionType) interface{} {
+	values := make([]string, len(types))
+	for i, t := range types {
+		values[i] = string(t)
+	}
+	return pq.Array(values)
 }
 
 // GetCurrentBalance returns the current balance for a user and currency from transactions
@@ -174,7 +259,9 @@ func (r *Repository) CreateTransaction(tx *sql.Tx, t *models.Transaction) error
 		Scan(&t.ID, &t.CreatedAt)
 }
 
-// CheckIdempotencyKey checks if an idempotency key was already used
+// CheckIdempotencyKey checks if an idempotency key was already used. It
+// returns nil for an unused key and an empty slice for a key recorded without
+// transactions.
 func (r *Repository) CheckIdempotencyKey(tx *sql.Tx, key string, userID int) ([]int, error) {
 	var transactionIDs pq.Int64Array
 	err := tx.QueryRow(`
@@ -230,6 +317,50 @@ func (r *Repository) GetTransaction(transactionID int) (*models.Transaction, err
 	return &t, nil
 }
 
+// GetTransactionsTx retrieves transactions by ID within tx, preserving the order of ids.
+// Unlike GetTransaction it sees rows written earlier in the same uncommitted transaction.
+func (r *Repository) GetTransactionsTx(tx *sql.Tx, ids []int) ([]*models.Transaction, error) {
+	rows, err := tx.Query(`
+		SELECT id, user_id, currency, type, amount, balance_after, metadata, created_at
+		FROM transactions
+		WHERE id = ANY($1)
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byID := make(map[int]*models.Transaction, len(ids))
+	for rows.Next() {
+		var t models.Transaction
+		var metadataBytes []byte
+
+		err := rows.Scan(&t.ID, &t.UserID, &t.Currency, &t.Type, &t.Amount, &t.BalanceAfter, &metadataBytes, &t.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(metadataBytes) > 0 {
+			t.Metadata = json.RawMessage(metadataBytes)
+		}
+
+		byID[t.ID] = &t
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.Transaction, 0, len(ids))
+	for _, id := range ids {
+		t, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("transaction %d not found", id)
+		}
+		result = append(result, t)
+	}
+	return result, nil
+}
+
 // ListTransactions retrieves paginated transactions for a user with optional filters
 func (r *Repository) ListTransactions(userID int, cursor *string, limit int, txType *models.TransactionType, currency *models.Currency) (*models.TransactionList, error) {
 	// Build query
@@ -305,6 +436,52 @@ func (r *Repository) ListTransactions(userID int, cursor *string, limit int, txT
 	}, nil
 }
 
+// ListTransactionsAfter retrieves a user's transactions with IDs greater than afterID in ID order
+func (r *Repository) ListTransactionsAfter(userID int, afterID int, limit int) ([]models.Transaction, error) {
+	rows, err := r.db.Query(`
+		SELECT id, user_id, currency, type, amount, balance_after, metadata, created_at
+		FROM transactions
+		WHERE user_id = $1 AND id > $2
+		ORDER BY id ASC
+		LIMIT $3
+	`, userID, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactions []models.Transaction
+	for rows.Next() {
+		var t models.Transaction
+		var metadataBytes []byte
+
+		err := rows.Scan(&t.ID, &t.UserID, &t.Currency, &t.Type, &t.Amount, &t.BalanceAfter, &metadataBytes, &t.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(metadataBytes) > 0 {
+			t.Metadata = json.RawMessage(metadataBytes)
+		}
+
+		transactions = append(transactions, t)
+	}
+
+	return transactions, rows.Err()
+}
+
+// GetLatestTransactionID retrieves the ID of a user's latest transaction, or 0
+// if the user has none
+func (r *Repository) GetLatestTransactionID(userID int) (int, error) {
+	var id int
+	err := r.db.QueryRow(`
+		SELECT COALESCE(MAX(id), 0)
+		FROM transactions
+		WHERE user_id = $1
+	`, userID).Scan(&id)
+	return id, err
+}
+
 // encodeCursor creates a cursor from transaction ID and timestamp
 func encodeCursor(id int, createdAt time.Time) string {
 	// Use RFC3339Nano to preserve microsecond precision
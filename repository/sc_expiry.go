@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+	"wallet-ledger/models"
+)
+
+var (
+	// ErrSCExpiryRunNotFound is returned when an SC expiry run ID does not exist
+	ErrSCExpiryRunNotFound = errors.New("sc expiry run not found")
+)
+
+const scExpiryRunColumns = `id, run_date, inactivity_days, cutoff, started_at, finished_at,
+	warnings_sent, users_expired, total_expired_sc`
+
+func scanSCExpiryRun(row interface{ Scan(...interface{}) error }) (*models.SCExpiryRun, error) {
+	var run models.SCExpiryRun
+	var finishedAt sql.NullTime
+
+	err := row.Scan(&run.ID, &run.RunDate, &run.InactivityDays, &run.Cutoff, &run.StartedAt, &finishedAt,
+		&run.WarningsSent, &run.UsersExpired, &run.TotalExpiredSC)
+	if err != nil {
+		return nil, err
+	}
+
+	if finishedAt.Valid {
+		run.FinishedAt = &finishedAt.Time
+	}
+	return &run, nil
+}
+
+// GetSCExpirySettings retrieves the SC expiry thresholds
+func (r *Repository) GetSCExpirySettings() (*models.SCExpirySettings, error) {
+	var s models.SCExpirySettings
+	err := r.db.QueryRow(`
+		SELECT inactivity_days, warning_days, updated_at
+		FROM sc_expiry_settings
+	`).Scan(&s.InactivityDays, &s.WarningDays, &s.UpdatedAt)
+	return &s, err
+}
+
+// SaveSCExpirySettings updates the SC expiry thresholds
+func (r *Repository) SaveSCExpirySettings(s *models.SCExpirySettings) error {
+	return r.db.QueryRow(`
+		UPDATE sc_expiry_settings
+		SET inactivity_days = $1, warning_days = $2, updated_at = NOW()
+		RETURNING updated_at
+	`, s.InactivityDays, s.WarningDays).Scan(&s.UpdatedAt)
+}
+
+// StartSCExpiryRun creates the run for runDate, or returns the existing one if
+// the job already ran (or started running) that day
+func (r *Repository) StartSCExpiryRun(runDate time.Time, inactivityDays int, cutoff time.Time) (*models.SCExpiryRun, error) {
+	run, err := scanSCExpiryRun(r.db.QueryRow(`
+		INSERT INTO sc_expiry_runs (run_date, inactivity_days, cutoff)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (run_date) DO NOTHING
+		RETURNING `+scExpiryRunColumns,
+		runDate, inactivityDays, cutoff))
+	if err != sql.ErrNoRows {
+		return run, err
+	}
+
+	return scanSCExpiryRun(r.db.QueryRow(`
+		SELECT `+scExpiryRunColumns+`
+		FROM sc_expiry_runs
+		WHERE run_date = $1
+	`, runDate))
+}
+
+// FinishSCExpiryRun marks a run finished and records its totals
+func (r *Repository) FinishSCExpiryRun(runID int) (*models.SCExpiryRun, error) {
+	return scanSCExpiryRun(r.db.QueryRow(`
+		UPDATE sc_expiry_runs SET
+			finished_at = NOW(),
+			warnings_sent = (SELECT COUNT(*) FROM sc_expiry_warnings WHERE run_id = $1),
+			users_expired = (SELECT COUNT(*) FROM sc_expirations WHERE run_id = $1),
+			total_expired_sc = (SELECT COALESCE(SUM(amount_sc), 0) FROM sc_expirations WHERE run_id = $1)
+		WHERE id = $1
+		RETURNING `+scExpiryRunColumns, runID))
+}
+
+// GetSCExpiryRun retrieves a run with the balances it expired
+func (r *Repository) GetSCExpiryRun(runID int) (*models.SCExpiryRun, error) {
+	run, err := scanSCExpiryRun(r.db.QueryRow(`
+		SELECT `+scExpiryRunColumns+`
+		FROM sc_expiry_runs
+		WHERE id = $1
+	`, runID))
+	if err == sql.ErrNoRows {
+		return nil, ErrSCExpiryRunNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(`
+		SELECT run_id, user_id, amount_sc, last_activity_at, transaction_id, created_at
+		FROM sc_expirations
+		WHERE run_id = $1
+		ORDER BY user_id
+	`, runID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	run.Expirations = []models.SCExpiration{}
+	for rows.Next() {
+		var e models.SCExpiration
+		if err := rows.Scan(&e.RunID, &e.UserID, &e.AmountSC, &e.LastActivityAt, &e.TransactionID, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		run.Expirations = append(run.Expirations, e)
+	}
+
+	return run, rows.Err()
+}
+
+// ListSCExpiryRuns retrieves the most recent runs, newest first
+func (r *Repository) ListSCExpiryRuns(limit int) ([]models.SCExpiryRun, error) {
+	rows, err := r.db.Query(`
+		SELECT `+scExpiryRunColumns+`
+		FROM sc_expiry_runs
+		ORDER BY run_date DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := []models.SCExpiryRun{}
+	for rows.Next() {
+		run, err := scanSCExpiryRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, *run)
+	}
+
+	return runs, rows.Err()
+}
+
+// ListInactiveSCBalances retrieves users holding SC whose last transaction was
+// at or before inactiveSince, with the expiry they were warned of since then
+func (r *Repository) ListInactiveSCBalances(inactiveSince time.Time) ([]models.InactiveSCBalance, error) {
+	rows, err := r.db.Query(`
+		WITH last AS (
+			SELECT user_id, MAX(id) AS last_id, MAX(created_at) AS last_at
+			FROM transactions
+			GROUP BY user_id
+		)
+		SELECT l.user_id, l.last_id, l.last_at, sc.balance_after, w.expires_at
+		FROM last l
+		JOIN LATERAL (
+			SELECT balance_after FROM transactions
+			WHERE user_id = l.user_id AND currency = 'SC'
+			ORDER BY id DESC
+			LIMIT 1
+		) sc ON TRUE
+		LEFT JOIN sc_expiry_warnings w ON w.user_id = l.user_id AND w.last_transaction_id = l.last_id
+		WHERE l.last_at <= $1 AND sc.balance_after > 0
+		ORDER BY l.user_id
+	`, inactiveSince)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var balances []models.InactiveSCBalance
+	for rows.Next() {
+		var b models.InactiveSCBalance
+		var warnedExpiresAt sql.NullTime
+		if err := rows.Scan(&b.UserID, &b.LastTransactionID, &b.LastActivityAt, &b.BalanceSC, &warnedExpiresAt); err != nil {
+			return nil, err
+		}
+		if warnedExpiresAt.Valid {
+			b.WarnedExpiresAt = &warnedExpiresAt.Time
+		}
+		balances = append(balances, b)
+	}
+
+	return balances, rows.Err()
+}
+
+// GetLastTransactionTx retrieves the ID and time of a user's latest transaction,
+// or a zero ID if the user has none
+func (r *Repository) GetLastTransactionTx(tx *sql.Tx, userID int) (int, time.Time, error) {
+	var id int
+	var createdAt time.Time
+	err := tx.QueryRow(`
+		SELECT id, created_at
+		FROM transactions
+		WHERE user_id = $1
+		ORDER BY id DESC
+		LIMIT 1
+	`, userID).Scan(&id, &createdAt)
+	if err == sql.ErrNoRows {
+		return 0, time.Time{}, nil
+	}
+	return id, createdAt, err
+}
+
+// CreateSCExpiryWarning stores a warning unless the user was already warned
+// about the same stretch of inactivity, and reports whether it was stored
+func (r *Repository) CreateSCExpiryWarning(w *models.SCExpiryWarning) (bool, error) {
+	err := r.db.QueryRow(`
+		INSERT INTO sc_expiry_warnings (user_id, run_id, last_transaction_id, last_activity_at, expires_at, balance_sc)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, last_transaction_id) DO NOTHING
+		RETURNING id, created_at
+	`, w.UserID, w.RunID, w.LastTransactionID, w.LastActivityAt, w.ExpiresAt, w.BalanceSC).Scan(&w.ID, &w.CreatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// GetSCExpiryWarning retrieves a warning by ID
+func (r *Repository) GetSCExpiryWarning(warningID int) (*models.SCExpiryWarning, error) {
+	var w models.SCExpiryWarning
+	err := r.db.QueryRow(`
+		SELECT id, user_id, run_id, last_transaction_id, last_activity_at, expires_at, balance_sc, created_at
+		FROM sc_expiry_warnings
+		WHERE id = $1
+	`, warningID).Scan(&w.ID, &w.UserID, &w.RunID, &w.LastTransactionID, &w.LastActivityAt, &w.ExpiresAt, &w.BalanceSC, &w.CreatedAt)
+	return &w, err
+}
+
+// CreateSCExpirationTx records a balance expired by a run
+func (r *Repository) CreateSCExpirationTx(tx *sql.Tx, e *models.SCExpiration) error {
+	return tx.QueryRow(`
+		INSERT INTO sc_expirations (run_id, user_id, amount_sc, last_activity_at, transaction_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
+	`, e.RunID, e.UserID, e.AmountSC, e.LastActivityAt, e.TransactionID).Scan(&e.CreatedAt)
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+	"wallet-ledger/models"
+)
+
+const selfExclusionColumns = `id, user_id, period, starts_at, ends_at, expired_at, created_at`
+
+func scanSelfExclusion(row interface{ Scan(...interface{}) error }) (*models.SelfExclusion, error) {
+	var e models.SelfExclusion
+	var endsAt, expiredAt sql.NullTime
+
+	err := row.Scan(&e.ID, &e.UserID, &e.Period, &e.StartsAt, &endsAt, &expiredAt, &e.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if endsAt.Valid {
+		e.EndsAt = &endsAt.Time
+	}
+	if expiredAt.Valid {
+		e.ExpiredAt = &expiredAt.Time
+	}
+	return &e, nil
+}
+
+// CreateSelfExclusion stores a new exclusion and audits it as started by actor
+func (r *Repository) CreateSelfExclusion(tx *sql.Tx, e *models.SelfExclusion, actor string) error {
+	err := tx.QueryRow(`
+		INSERT INTO self_exclusions (user_id, period, starts_at, ends_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`, e.UserID, e.Period, e.StartsAt, e.EndsAt).Scan(&e.ID, &e.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO self_exclusion_audit (exclusion_id, user_id, action, actor)
+		VALUES ($1, $2, 'started', $3)
+	`, e.ID, e.UserID, actor)
+	return err
+}
+
+// GetActiveSelfExclusionTx retrieves the user's exclusion in force at now that
+// ends last, or nil if the user is not excluded
+func (r *Repository) GetActiveSelfExclusionTx(tx *sql.Tx, userID int, now time.Time) (*models.SelfExclusion, error) {
+	e, err := scanSelfExclusion(tx.QueryRow(`
+		SELECT `+selfExclusionColumns+`
+		FROM self_exclusions
+		WHERE user_id = $1 AND starts_at <= $2 AND (ends_at IS NULL OR ends_at > $2)
+		ORDER BY ends_at DESC NULLS FIRST
+		LIMIT 1
+	`, userID, now))
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return e, err
+}
+
+// ListSelfExclusions retrieves all of a user's exclusions newest first
+func (r *Repository) ListSelfExclusions(userID int) ([]models.SelfExclusion, error) {
+	rows, err := r.db.Query(`
+		SELECT `+selfExclusionColumns+`
+		FROM self_exclusions
+		WHERE user_id = $1
+		ORDER BY id DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	exclusions := []models.SelfExclusion{}
+	for rows.Next() {
+		e, err := scanSelfExclusion(rows)
+		if err != nil {
+			return nil, err
+		}
+		exclusions = append(exclusions, *e)
+	}
+
+	return exclusions, rows.Err()
+}
+
+// ListSelfExclusionAudit retrieves a user's exclusion audit trail oldest first
+func (r *Repository) ListSelfExclusionAudit(userID int) ([]models.SelfExclusionAction, error) {
+	rows, err := r.db.Query(`
+		SELECT id, exclusion_id, user_id, action, actor, created_at
+		FROM self_exclusion_audit
+		WHERE user_id = $1
+		ORDER BY id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	actions := []models.SelfExclusionAction{}
+	for rows.Next() {
+		var a models.SelfExclusionAction
+		if err := rows.Scan(&a.ID, &a.ExclusionID, &a.UserID, &a.Action, &a.Actor, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		actions = append(actions, a)
+	}
+
+	return actions, rows.Err()
+}
+
+// ExpireSelfExclusions stamps every exclusion that ended before now as expired,
+// audits it, and returns how many were expired
+func (r *Repository) ExpireSelfExclusions(now time.Time, actor string) (int, error) {
+	var count int
+	err := r.db.QueryRow(`
+		WITH expired AS (
+			UPDATE self_exclusions
+			SET expired_at = NOW()
+			WHERE expired_at IS NULL AND ends_at <= $1
+			RETURNING id, user_id
+		), audited AS (
+			INSERT INTO self_exclusion_audit (exclusion_id, user_id, action, actor)
+			SELECT id, user_id, 'expired', $2 FROM expired
+			RETURNING 1
+		)
+		SELECT COUNT(*) FROM audited
+	`, now, actor).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+	"wallet-ledger/models"
+)
+
+// GetSpendLimitsTx retrieves the spend limits a user has set, keyed by period
+func (r *Repository) GetSpendLimitsTx(tx *sql.Tx, userID int) (map[models.SpendLimitPeriod]*models.SpendLimit, error) {
+	rows, err := tx.Query(`
+		SELECT user_id, period, limit_cents, pending_limit_cents, pending_effective_at, updated_at
+		FROM spend_limits
+		WHERE user_id = $1
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	limits := make(map[models.SpendLimitPeriod]*models.SpendLimit)
+	for rows.Next() {
+		var l models.SpendLimit
+		var pendingLimit sql.NullInt64
+		var pendingEffectiveAt sql.NullTime
+
+		err := rows.Scan(&l.UserID, &l.Period, &l.LimitCents, &pendingLimit, &pendingEffectiveAt, &l.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if pendingLimit.Valid && pendingEffectiveAt.Valid {
+			l.PendingLimitCents = &pendingLimit.Int64
+			l.PendingEffectiveAt = &pendingEffectiveAt.Time
+		}
+		limits[l.Period] = &l
+	}
+
+	return limits, rows.Err()
+}
+
+// SaveSpendLimitTx creates or updates a user's spend limit for one period
+func (r *Repository) SaveSpendLimitTx(tx *sql.Tx, l *models.SpendLimit) error {
+	return tx.QueryRow(`
+		INSERT INTO spend_limits (user_id, period, limit_cents, pending_limit_cents, pending_effective_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, period) DO UPDATE SET
+			limit_cents = EXCLUDED.limit_cents,
+			pending_limit_cents = EXCLUDED.pending_limit_cents,
+			pending_effective_at = EXCLUDED.pending_effective_at,
+			updated_at = NOW()
+		RETURNING updated_at
+	`, l.UserID, l.Period, l.LimitCents, l.PendingLimitCents, l.PendingEffectiveAt).Scan(&l.UpdatedAt)
+}
+
+// AddSpendLimitChange appends to a user's spend limit history
+func (r *Repository) AddSpendLimitChange(tx *sql.Tx, c *models.SpendLimitChange) error {
+	return tx.QueryRow(`
+		INSERT INTO spend_limit_changes (user_id, period, old_limit_cents, new_limit_cents, effective_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, requested_at
+	`, c.UserID, c.Period, c.OldLimitCents, c.NewLimitCents, c.EffectiveAt).Scan(&c.ID, &c.RequestedAt)
+}
+
+// ListSpendLimitChanges retrieves a user's spend limit history newest first
+func (r *Repository) ListSpendLimitChanges(userID int) ([]models.SpendLimitChange, error) {
+	rows, err := r.db.Query(`
+		SELECT id, user_id, period, old_limit_cents, new_limit_cents, requested_at, effective_at
+		FROM spend_limit_changes
+		WHERE user_id = $1
+		ORDER BY id DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []models.SpendLimitChange{}
+	for rows.Next() {
+		var c models.SpendLimitChange
+		err := rows.Scan(&c.ID, &c.UserID, &c.Period, &c.OldLimitCents, &c.NewLimitCents, &c.RequestedAt, &c.EffectiveAt)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, c)
+	}
+
+	return changes, rows.Err()
+}
+
+// GetSpendLimitDefaults retrieves the operator-wide spend caps
+func (r *Repository) GetSpendLimitDefaults() (*models.SpendLimitDefaults, error) {
+	var d models.SpendLimitDefaults
+	err := r.db.QueryRow(`
+		SELECT daily_cents, weekly_cents, monthly_cents, updated_at
+		FROM spend_limit_defaults
+	`).Scan(&d.DailyCents, &d.WeeklyCents, &d.MonthlyCents, &d.UpdatedAt)
+	return &d, err
+}
+
+// SaveSpendLimitDefaults updates the operator-wide spend caps
+func (r *Repository) SaveSpendLimitDefaults(d *models.SpendLimitDefaults) error {
+	return r.db.QueryRow(`
+		UPDATE spend_limit_defaults
+		SET daily_cents = $1, weekly_cents = $2, monthly_cents = $3, updated_at = NOW()
+		RETURNING updated_at
+	`, d.DailyCents, d.WeeklyCents, d.MonthlyCents).Scan(&d.UpdatedAt)
+}
+
+// GetPurchaseSpendTx sums the price of a user's purchases at or after since.
+// Only GC rows are counted since every package includes GC and both rows of a
+// purchase carry the same price.
+func (r *Repository) GetPurchaseSpendTx(tx *sql.Tx, userID int, since time.Time) (int64, error) {
+	var spent int64
+	err := tx.QueryRow(`
+		SELECT COALESCE(SUM((metadata->>'price_cents')::BIGINT), 0)
+		FROM transactions
+		WHERE user_id = $1 AND type = 'purchase' AND currency = 'GC' AND created_at >= $2
+	`, userID, since).Scan(&spent)
+	return spent, err
+}
@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+	"wallet-ledger/models"
+
+	"github.com/lib/pq"
+)
+
+// Tournament lookup errors
+var (
+	ErrTournamentNotFound      = errors.New("tournament not found")
+	ErrTournamentEntryNotFound = errors.New("tournament entry not found")
+)
+
+const tournamentColumns = `id, name, currency, entry_fee, scoring, prizes, starts_at, ends_at, settled_at, created_at, updated_at`
+
+const tournamentEntryColumns = `tournament_id, user_id, score, entry_transaction_id, prize, prize_transaction_id, joined_at, score_updated_at`
+
+// leaderboardOrder ranks higher scores first, then whoever reached their score first
+const leaderboardOrder = `ORDER BY score DESC, score_updated_at ASC, user_id ASC`
+
+func scanTournament(row interface{ Scan(...interface{}) error }) (*models.Tournament, error) {
+	var t models.Tournament
+	var prizes pq.Int64Array
+	var settledAt sql.NullTime
+
+	err := row.Scan(&t.ID, &t.Name, &t.Currency, &t.EntryFee, &t.Scoring, &prizes, &t.StartsAt, &t.EndsAt, &settledAt, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	t.Prizes = []int64(prizes)
+	if t.Prizes == nil {
+		t.Prizes = []int64{}
+	}
+	if settledAt.Valid {
+		t.SettledAt = &settledAt.Time
+	}
+	return &t, nil
+}
+
+func scanTournamentEntry(row interface{ Scan(...interface{}) error }) (*models.TournamentEntry, error) {
+	var e models.TournamentEntry
+	var entryTxID, prizeTxID sql.NullInt64
+
+	err := row.Scan(&e.TournamentID, &e.UserID, &e.Score, &entryTxID, &e.Prize, &prizeTxID, &e.JoinedAt, &e.ScoreUpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if entryTxID.Valid {
+		id := int(entryTxID.Int64)
+		e.EntryTransactionID = &id
+	}
+	if prizeTxID.Valid {
+		id := int(prizeTxID.Int64)
+		e.PrizeTransactionID = &id
+	}
+	return &e, nil
+}
+
+// GetTournament retrieves a tournament by ID
+func (r *Repository) GetTournament(tournamentID string) (*models.Tournament, error) {
+	t, err := scanTournament(r.db.QueryRow(`
+		SELECT `+tournamentColumns+`
+		FROM tournaments
+		WHERE id = $1
+	`, tournamentID))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrTournamentNotFound
+	}
+	return t, err
+}
+
+// GetTournamentTx retrieves a tournament within tx, locking it until tx ends if forUpdate is set
+func (r *Repository) GetTournamentTx(tx *sql.Tx, tournamentID string, forUpdate bool) (*models.Tournament, error) {
+	query := `SELECT ` + tournamentColumns + ` FROM tournaments WHERE id = $1`
+	if forUpdate {
+		query += ` FOR UPDATE`
+	}
+
+	t, err := scanTournament(tx.QueryRow(query, tournamentID))
+	if err == sql.ErrNoRows {
+		return nil, ErrTournamentNotFound
+	}
+	return t, err
+}
+
+// ListTournaments retrieves all tournaments, most recent first
+func (r *Repository) ListTournaments() ([]models.Tournament, error) {
+	rows, err := r.db.Query(`
+		SELECT ` + tournamentColumns + `
+		FROM tournaments
+		ORDER BY starts_at DESC, id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tournaments := []models.Tournament{}
+	for rows.Next() {
+		t, err := scanTournament(rows)
+		if err != nil {
+			return nil, err
+		}
+		tournaments = append(tournaments, *t)
+	}
+
+	return tournaments, rows.Err()
+}
+
+// SaveTournament creates or replaces a tournament definition within tx
+func (r *Repository) SaveTournament(tx *sql.Tx, t *models.Tournament) error {
+	return tx.QueryRow(`
+		INSERT INTO tournaments (id, name, currency, entry_fee, scoring, prizes, starts_at, ends_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			currency = EXCLUDED.currency,
+			entry_fee = EXCLUDED.entry_fee,
+			scoring = EXCLUDED.scoring,
+			prizes = EXCLUDED.prizes,
+			starts_at = EXCLUDED.starts_at,
+			ends_at = EXCLUDED.ends_at,
+			updated_at = NOW()
+		RETURNING created_at, updated_at
+	`, t.ID, t.Name, t.Currency, t.EntryFee, t.Scoring, pq.Array(t.Prizes), t.StartsAt, t.EndsAt).
+		Scan(&t.CreatedAt, &t.UpdatedAt)
+}
+
+// HasTournamentEntriesTx reports whether anyone has entered a tournament
+func (r *Repository) HasTournamentEntriesTx(tx *sql.Tx, tournamentID string) (bool, error) {
+	var exists bool
+	err := tx.QueryRow(`
+		SELECT EXISTS (SELECT 1 FROM tournament_entries WHERE tournament_id = $1)
+	`, tournamentID).Scan(&exists)
+	return exists, err
+}
+
+// MarkTournamentSettled records that a tournament's prizes have been paid
+func (r *Repository) MarkTournamentSettled(tx *sql.Tx, t *models.Tournament) error {
+	return tx.QueryRow(`
+		UPDATE tournaments
+		SET settled_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+		RETURNING settled_at, updated_at
+	`, t.ID).Scan(&t.SettledAt, &t.UpdatedAt)
+}
+
+// GetTournamentEntryTx retrieves and locks a user's entry until tx ends
+func (r *Repository) GetTournamentEntryTx(tx *sql.Tx, tournamentID string, userID int) (*models.TournamentEntry, error) {
+	e, err := scanTournamentEntry(tx.QueryRow(`
+		SELECT `+tournamentEntryColumns+`
+		FROM tournament_entries
+		WHERE tournament_id = $1 AND user_id = $2
+		FOR UPDATE
+	`, tournamentID, userID))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrTournamentEntryNotFound
+	}
+	return e, err
+}
+
+// CreateTournamentEntry records a user joining a tournament
+func (r *Repository) CreateTournamentEntry(tx *sql.Tx, e *models.TournamentEntry) error {
+	return tx.QueryRow(`
+		INSERT INTO tournament_entries (tournament_id, user_id, entry_transaction_id)
+		VALUES ($1, $2, $3)
+		RETURNING score, joined_at, score_updated_at
+	`, e.TournamentID, e.UserID, e.EntryTransactionID).Scan(&e.Score, &e.JoinedAt, &e.ScoreUpdatedAt)
+}
+
+// UpdateTournamentScore sets a user's score in a tournament
+func (r *Repository) UpdateTournamentScore(tx *sql.Tx, tournamentID string, userID int, score int64, updatedAt time.Time) error {
+	_, err := tx.Exec(`
+		UPDATE tournament_entries
+		SET score = $3, score_updated_at = $4
+		WHERE tournament_id = $1 AND user_id = $2
+	`, tournamentID, userID, score, updatedAt)
+	return err
+}
+
+// ListTournamentEntries retrieves the top entries of a tournament in leaderboard order
+func (r *Repository) ListTournamentEntries(tournamentID string, limit int) ([]models.TournamentEntry, error) {
+	rows, err := r.db.Query(`
+		SELECT `+tournamentEntryColumns+`
+		FROM tournament_entries
+		WHERE tournament_id = $1
+		`+leaderboardOrder+`
+		LIMIT $2
+	`, tournamentID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectTournamentEntries(rows)
+}
+
+// LockTournamentLeadersTx locks the top limit entries of a tournament in
+// leaderboard order until tx ends
+func (r *Repository) LockTournamentLeadersTx(tx *sql.Tx, tournamentID string, limit int) ([]models.TournamentEntry, error) {
+	rows, err := tx.Query(`
+		SELECT `+tournamentEntryColumns+`
+		FROM tournament_entries
+		WHERE tournament_id = $1
+		`+leaderboardOrder+`
+		LIMIT $2
+		FOR UPDATE
+	`, tournamentID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectTournamentEntries(rows)
+}
+
+// SetTournamentPrize records the prize paid to an entry
+func (r *Repository) SetTournamentPrize(tx *sql.Tx, tournamentID string, userID int, prize int64, transactionID int) error {
+	_, err := tx.Exec(`
+		UPDATE tournament_entries
+		SET prize = $3, prize_transaction_id = $4
+		WHERE tournament_id = $1 AND user_id = $2
+	`, tournamentID, userID, prize, transactionID)
+	return err
+}
+
+func collectTournamentEntries(rows *sql.Rows) ([]models.TournamentEntry, error) {
+	entries := []models.TournamentEntry{}
+	for rows.Next() {
+		e, err := scanTournamentEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *e)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"database/sql"
+	"wallet-ledger/models"
+)
+
+// GetWagerLimitsTx retrieves the wager limits a user has set
+func (r *Repository) GetWagerLimitsTx(tx *sql.Tx, userID int) ([]*models.WagerLimit, error) {
+	rows, err := tx.Query(`
+		SELECT user_id, currency, kind, limit_amount, pending_limit_amount, pending_effective_at, updated_at
+		FROM wager_limits
+		WHERE user_id = $1
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var limits []*models.WagerLimit
+	for rows.Next() {
+		var l models.WagerLimit
+		var pendingLimit sql.NullInt64
+		var pendingEffectiveAt sql.NullTime
+
+		err := rows.Scan(&l.UserID, &l.Currency, &l.Kind, &l.Limit, &pendingLimit, &pendingEffectiveAt, &l.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if pendingLimit.Valid && pendingEffectiveAt.Valid {
+			l.PendingLimit = &pendingLimit.Int64
+			l.PendingEffectiveAt = &pendingEffectiveAt.Time
+		}
+		limits = append(limits, &l)
+	}
+
+	return limits, rows.Err()
+}
+
+// SaveWagerLimitTx creates or updates one of a user's wager limits
+func (r *Repository) SaveWagerLimitTx(tx *sql.Tx, l *models.WagerLimit) error {
+	return tx.QueryRow(`
+		INSERT INTO wager_limits (user_id, currency, kind, limit_amount, pending_limit_amount, pending_effective_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, currency, kind) DO UPDATE SET
+			limit_amount = EXCLUDED.limit_amount,
+			pending_limit_amount = EXCLUDED.pending_limit_amount,
+			pending_effective_at = EXCLUDED.pending_effective_at,
+			updated_at = NOW()
+		RETURNING updated_at
+	`, l.UserID, l.Currency, l.Kind, l.Limit, l.PendingLimit, l.PendingEffectiveAt).Scan(&l.UpdatedAt)
+}
+
+// AddWagerLimitChange appends to a user's wager limit history
+func (r *Repository) AddWagerLimitChange(tx *sql.Tx, c *models.WagerLimitChange) error {
+	return tx.QueryRow(`
+		INSERT INTO wager_limit_changes (user_id, currency, kind, old_limit_amount, new_limit_amount, effective_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, requested_at
+	`, c.UserID, c.Currency, c.Kind, c.OldLimit, c.NewLimit, c.EffectiveAt).Scan(&c.ID, &c.RequestedAt)
+}
+
+// ListWagerLimitChanges retrieves a user's wager limit history newest first
+func (r *Repository) ListWagerLimitChanges(userID int) ([]models.WagerLimitChange, error) {
+	rows, err := r.db.Query(`
+		SELECT id, user_id, currency, kind, old_limit_amount, new_limit_amount, requested_at, effective_at
+		FROM wager_limit_changes
+		WHERE user_id = $1
+		ORDER BY id DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []models.WagerLimitChange{}
+	for rows.Next() {
+		var c models.WagerLimitChange
+		err := rows.Scan(&c.ID, &c.UserID, &c.Currency, &c.Kind, &c.OldLimit, &c.NewLimit, &c.RequestedAt, &c.EffectiveAt)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, c)
+	}
+
+	return changes, rows.Err()
+}
+
+// GetWagerTotalsTx retrieves a user's running wager totals for a day, keyed by currency
+func (r *Repository) GetWagerTotalsTx(tx *sql.Tx, userID int, day string) (map[models.Currency]models.WagerTotals, error) {
+	rows, err := tx.Query(`
+		SELECT currency, wagered, won
+		FROM wager_daily_totals
+		WHERE user_id = $1 AND day = $2
+	`, userID, day)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := make(map[models.Currency]models.WagerTotals)
+	for rows.Next() {
+		var currency models.Currency
+		var t models.WagerTotals
+		if err := rows.Scan(&currency, &t.Wagered, &t.Won); err != nil {
+			return nil, err
+		}
+		totals[currency] = t
+	}
+
+	return totals, rows.Err()
+}
+
+// AddWagerTotalsTx adds to a user's running wager totals for a day; refunds
+// pass a negative wagered amount
+func (r *Repository) AddWagerTotalsTx(tx *sql.Tx, userID int, currency models.Currency, day string, wagered, won int64) error {
+	_, err := tx.Exec(`
+		INSERT INTO wager_daily_totals (user_id, currency, day, wagered, won)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, currency, day) DO UPDATE SET
+			wagered = wager_daily_totals.wagered + EXCLUDED.wagered,
+			won = wager_daily_totals.won + EXCLUDED.won
+	`, userID, currency, day, wagered, won)
+	return err
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"wallet-ledger/models"
+)
+
+// AdjustBalance credits (positive amount) or debits (negative amount) a
+// player's balance by hand. The reason code, note and acting admin are
+// recorded with the adjustment transaction; a debit cannot overdraw the balance.
+// An amount above the currency's approval threshold is not posted but held as
+// a pending request, reported with an ApprovalRequiredError.
+func (s *WalletService) AdjustBalance(userID int, currency models.Currency, amount int64, reason models.AdjustmentReason, note, admin, idempotencyKey string) (*models.Adjustment, error) {
+	// Serialize all operations for this user
+	s.lockUser(userID)
+	defer s.unlockUser(userID)
+
+	note, admin = strings.TrimSpace(note), strings.TrimSpace(admin)
+	if err := validateAdjustment(reason, note, admin); err != nil {
+		return nil, err
+	}
+	legs := []models.PostingLeg{{Currency: currency, Type: models.TransactionTypeAdjustment, Amount: amount}}
+	if err := s.validateLegs(legs, true); err != nil {
+		return nil, err
+	}
+
+	// Verify user exists
+	_, err := s.repo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	threshold, err := s.approvalThreshold(models.ApprovalOperationAdjustment, currency, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transaction
+	tx, err := s.repo.BeginTx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Check idempotency
+	existingTxIDs, err := s.repo.CheckIdempotencyKey(tx, idempotencyKey, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existingTxIDs) > 0 {
+		// Already processed, return the existing adjustment
+		adjustment, err := s.repo.GetAdjustmentByTransactionTx(tx, existingTxIDs[0])
+		if err != nil {
+			return nil, err
+		}
+		tx.Commit()
+		return adjustment, nil
+	}
+	held, err := s.repo.GetApprovalRequestByKeyTx(tx, userID, idempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	if held != nil {
+		tx.Commit()
+		return nil, &ApprovalRequiredError{Request: held}
+	}
+
+	// Large adjustments wait for a second admin; a debit is checked now and again on approval
+	if threshold != nil {
+		if amount < 0 {
+			balance, err := s.repo.GetCurrentBalance(tx, userID, currency)
+			if err != nil {
+				return nil, err
+			}
+			if balance+amount < 0 {
+				return nil, s.insufficientFunds(currency, balance, -amount)
+			}
+		}
+		return nil, s.holdForApproval(tx, &models.ApprovalRequest{
+			Operation:      models.ApprovalOperationAdjustment,
+			UserID:         userID,
+			Currency:       currency,
+			Amount:         amount,
+			Reason:         reason,
+			Note:           note,
+			RequestedBy:    admin,
+			IdempotencyKey: idempotencyKey,
+		}, threshold)
+	}
+
+	adjustment := &models.Adjustment{
+		UserID:   userID,
+		Currency: currency,
+		Amount:   amount,
+		Reason:   reason,
+		Note:     note,
+		Admin:    admin,
+	}
+	txIDs, err := s.postAdjustment(tx, adjustment)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save idempotency key
+	err = s.repo.SaveIdempotencyKey(tx, idempotencyKey, userID, txIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return adjustment, nil
+}
+
+// postAdjustment posts the transaction of an adjustment and records who made
+// it within tx. The caller must hold the user lock.
+func (s *WalletService) postAdjustment(tx *sql.Tx, a *models.Adjustment) ([]int, error) {
+	metadata := map[string]interface{}{
+		"reason_code": a.Reason,
+		"admin":       a.Admin,
+	}
+	if a.ApprovedBy != nil {
+		metadata["approved_by"] = *a.ApprovedBy
+	}
+	metadataJSON, _ := json.Marshal(metadata)
+
+	transactions, txIDs, err := s.postLegs(tx, a.UserID, []models.PostingLeg{
+		{Currency: a.Currency, Type: models.TransactionTypeAdjustment, Amount: a.Amount, Metadata: metadataJSON},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.TransactionID = transactions[0].ID
+	a.CreatedAt = transactions[0].CreatedAt
+	if err := s.repo.CreateAdjustment(tx, a); err != nil {
+		return nil, err
+	}
+	return txIDs, nil
+}
+
+// ListAdjustments retrieves manual adjustments, newest first
+func (s *WalletService) ListAdjustments(filter models.AdjustmentFilter, cursor *string, limit int) (*models.AdjustmentList, error) {
+	if filter.UserID != nil {
+		// Verify user exists
+		if _, err := s.repo.GetUser(*filter.UserID); err != nil {
+			return nil, err
+		}
+	}
+	return s.repo.ListAdjustments(filter, cursor, limit)
+}
+
+// validateAdjustment checks the audit fields every adjustment must carry
+func validateAdjustment(reason models.AdjustmentReason, note, admin string) error {
+	if !reason.IsValid() {
+		return fmt.Errorf("unknown reason code %q: %w", reason, ErrInvalidInput)
+	}
+	if note == "" {
+		return fmt.Errorf("note is required: %w", ErrInvalidInput)
+	}
+	if admin == "" {
+		return fmt.Errorf("admin is required: %w", ErrInvalidInput)
+	}
+	if len(admin) > 64 {
+		return fmt.Errorf("admin cannot be longer than 64 characters: %w", ErrInvalidInput)
+	}
+	return nil
+}
@@ -0,0 +1,330 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"wallet-ledger/models"
+)
+
+const (
+	// amoeCodeTTL is how long a request code can be quoted on an entry; mail-in
+	// entries can take weeks to arrive
+	amoeCodeTTL = 90 * 24 * time.Hour
+
+	// amoeCodeAlphabet leaves out characters that are easily misread when
+	// handwritten (0/O, 1/I/L, 5/S, 8/B)
+	amoeCodeAlphabet = "234679ACDEFGHJKMNPQRTUVWXYZ"
+	amoeCodeGroups   = 3
+	amoeCodeGroupLen = 4
+)
+
+// GetAMOESettings retrieves the AMOE amount and limits
+func (s *WalletService) GetAMOESettings() (*models.AMOESettings, error) {
+	return s.repo.GetAMOESettings()
+}
+
+// SaveAMOESettings changes the AMOE amount and limits. Entries already
+// submitted keep the amount they were submitted with.
+func (s *WalletService) SaveAMOESettings(settings *models.AMOESettings) error {
+	if settings.AmountSC <= 0 {
+		return fmt.Errorf("amount_sc must be greater than zero: %w", ErrInvalidInput)
+	}
+	if settings.MaxEntriesPerUserPerDay < 0 || settings.MaxEntriesPerDay < 0 {
+		return fmt.Errorf("limits cannot be negative: %w", ErrInvalidInput)
+	}
+	return s.repo.SaveAMOESettings(settings)
+}
+
+// RequestAMOECode issues a single-use request code to a user
+func (s *WalletService) RequestAMOECode(userID int) (*models.AMOECode, error) {
+	// Verify user exists
+	_, err := s.repo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := generateAMOECode()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &models.AMOECode{
+		Code:      code,
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(amoeCodeTTL),
+	}
+	if err := s.repo.CreateAMOECode(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// SubmitAMOEEntry queues an entry quoting a request code for review. Web-form
+// entries default to the code's owner as submitter; mail-in entries must name
+// the operator who keyed them in.
+func (s *WalletService) SubmitAMOEEntry(code string, method models.AMOEMethod, submittedBy string) (*models.AMOEEntry, error) {
+	code, ok := normalizeAMOECode(code)
+	if !ok {
+		return nil, fmt.Errorf("malformed code: %w", ErrInvalidInput)
+	}
+	if method != models.AMOEMethodMail && method != models.AMOEMethodWeb {
+		return nil, fmt.Errorf("method must be mail or web: %w", ErrInvalidInput)
+	}
+	if method == models.AMOEMethodMail && submittedBy == "" {
+		return nil, fmt.Errorf("submitter is required for mail-in entries: %w", ErrInvalidInput)
+	}
+
+	// The code identifies the user; look it up to know whose lock to take
+	owner, err := s.repo.GetAMOECode(code)
+	if err != nil {
+		return nil, err
+	}
+	userID := owner.UserID
+	if submittedBy == "" {
+		submittedBy = fmt.Sprintf("user:%d", userID)
+	}
+
+	// Serialize all operations for this user
+	s.lockUser(userID)
+	defer s.unlockUser(userID)
+
+	// Start transaction
+	tx, err := s.repo.BeginTx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	c, err := s.repo.LockAMOECodeTx(tx, code)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if c.UsedAt != nil {
+		return nil, ErrAMOECodeUsed
+	}
+	if !now.Before(c.ExpiresAt) {
+		return nil, ErrAMOECodeExpired
+	}
+
+	// Locking the settings row serializes submissions across users so the
+	// global daily limit cannot be overrun
+	settings, err := s.repo.LockAMOESettingsTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	dayStart := now.UTC().Truncate(24 * time.Hour)
+	if settings.MaxEntriesPerUserPerDay > 0 {
+		count, err := s.repo.CountAMOEEntriesSinceTx(tx, &userID, dayStart)
+		if err != nil {
+			return nil, err
+		}
+		if count >= settings.MaxEntriesPerUserPerDay {
+			return nil, fmt.Errorf("%w: %d per user per day", ErrAMOELimitReached, settings.MaxEntriesPerUserPerDay)
+		}
+	}
+	if settings.MaxEntriesPerDay > 0 {
+		count, err := s.repo.CountAMOEEntriesSinceTx(tx, nil, dayStart)
+		if err != nil {
+			return nil, err
+		}
+		if count >= settings.MaxEntriesPerDay {
+			return nil, fmt.Errorf("%w: %d per day across all users", ErrAMOELimitReached, settings.MaxEntriesPerDay)
+		}
+	}
+
+	entry := &models.AMOEEntry{
+		Code:        code,
+		UserID:      userID,
+		Method:      method,
+		Status:      models.AMOEStatusPending,
+		AmountSC:    settings.AmountSC,
+		SubmittedBy: submittedBy,
+	}
+	if err := s.repo.CreateAMOEEntry(tx, entry); err != nil {
+		return nil, err
+	}
+
+	event := models.AMOEAuditEvent{EntryID: entry.ID, Action: "submitted", Actor: submittedBy}
+	if err := s.repo.AddAMOEAuditEvent(tx, &event); err != nil {
+		return nil, err
+	}
+	entry.Audit = []models.AMOEAuditEvent{event}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// ListAMOEEntries retrieves AMOE entries newest first; pass the pending status
+// for the review queue
+func (s *WalletService) ListAMOEEntries(status *models.AMOEStatus, cursor *string, limit int) (*models.AMOEEntryList, error) {
+	return s.repo.ListAMOEEntries(status, cursor, limit)
+}
+
+// GetAMOEEntry retrieves an AMOE entry with its audit trail
+func (s *WalletService) GetAMOEEntry(entryID int) (*models.AMOEEntry, error) {
+	entry, err := s.repo.GetAMOEEntry(entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Audit, err = s.repo.ListAMOEAuditEvents(entryID)
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// ApproveAMOEEntry approves a pending entry and credits its SC to the user
+func (s *WalletService) ApproveAMOEEntry(entryID int, reviewer, note string) (*models.AMOEEntry, error) {
+	return s.reviewAMOEEntry(entryID, reviewer, note, models.AMOEStatusApproved)
+}
+
+// RejectAMOEEntry rejects a pending entry without crediting anything
+func (s *WalletService) RejectAMOEEntry(entryID int, reviewer, note string) (*models.AMOEEntry, error) {
+	return s.reviewAMOEEntry(entryID, reviewer, note, models.AMOEStatusRejected)
+}
+
+// reviewAMOEEntry moves a pending entry to status, recording the reviewer.
+// An entry can be reviewed only once, so it is credited at most once.
+func (s *WalletService) reviewAMOEEntry(entryID int, reviewer, note string, status models.AMOEStatus) (*models.AMOEEntry, error) {
+	if reviewer == "" {
+		return nil, fmt.Errorf("reviewer is required: %w", ErrInvalidInput)
+	}
+
+	// The entry identifies the user; look it up to know whose lock to take
+	pending, err := s.repo.GetAMOEEntry(entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Serialize all operations for this user
+	s.lockUser(pending.UserID)
+	defer s.unlockUser(pending.UserID)
+
+	// Start transaction
+	tx, err := s.repo.BeginTx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	entry, err := s.repo.LockAMOEEntryTx(tx, entryID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Status != models.AMOEStatusPending {
+		return nil, fmt.Errorf("%w: entry %d is %s", ErrAMOEEntryReviewed, entryID, entry.Status)
+	}
+
+	if status == models.AMOEStatusApproved {
+		balance, err := s.repo.GetCurrentBalance(tx, entry.UserID, models.CurrencySC)
+		if err != nil {
+			return nil, err
+		}
+
+		metadata, _ := json.Marshal(map[string]interface{}{
+			"amoe_entry_id": entry.ID,
+			"code":          entry.Code,
+			"method":        entry.Method,
+			"approved_by":   reviewer,
+		})
+		amoeTx := &models.Transaction{
+			UserID:       entry.UserID,
+			Currency:     models.CurrencySC,
+			Type:         models.TransactionTypeAMOESC,
+			Amount:       entry.AmountSC,
+			BalanceAfter: models.TransactionTypeAMOESC.Apply(balance, entry.AmountSC),
+			Metadata:     metadata,
+		}
+		if err := s.repo.CreateTransaction(tx, amoeTx); err != nil {
+			return nil, err
+		}
+		entry.TransactionID = &amoeTx.ID
+	}
+
+	entry.Status = status
+	entry.ReviewedBy = &reviewer
+	entry.ReviewNote = note
+	if err := s.repo.ReviewAMOEEntry(tx, entry); err != nil {
+		return nil, err
+	}
+
+	event := models.AMOEAuditEvent{EntryID: entry.ID, Action: string(status), Actor: reviewer, Note: note}
+	if err := s.repo.AddAMOEAuditEvent(tx, &event); err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Audit, err = s.repo.ListAMOEAuditEvents(entryID)
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// generateAMOECode returns a random code formatted as XXXX-XXXX-XXXX
+func generateAMOECode() (string, error) {
+	// Reject bytes past the last whole multiple of the alphabet size so every
+	// character is equally likely
+	limit := 256 - 256%len(amoeCodeAlphabet)
+	chars := make([]byte, 0, amoeCodeGroups*amoeCodeGroupLen)
+	buf := make([]byte, 16)
+	for len(chars) < cap(chars) {
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		for _, v := range buf {
+			if int(v) < limit && len(chars) < cap(chars) {
+				chars = append(chars, amoeCodeAlphabet[int(v)%len(amoeCodeAlphabet)])
+			}
+		}
+	}
+	return formatAMOECode(chars), nil
+}
+
+// normalizeAMOECode canonicalizes a code as transcribed from a letter or typed
+// into a form: case, spaces and dashes are ignored
+func normalizeAMOECode(code string) (string, bool) {
+	var chars []byte
+	for _, r := range strings.ToUpper(code) {
+		switch {
+		case r == ' ' || r == '-':
+			continue
+		case strings.ContainsRune(amoeCodeAlphabet, r):
+			chars = append(chars, byte(r))
+		default:
+			return "", false
+		}
+	}
+	if len(chars) != amoeCodeGroups*amoeCodeGroupLen {
+		return "", false
+	}
+	return formatAMOECode(chars), true
+}
+
+// formatAMOECode groups code characters with dashes
+func formatAMOECode(chars []byte) string {
+	var b strings.Builder
+	for i, c := range chars {
+		if i > 0 && i%amoeCodeGroupLen == 0 {
+			b.WriteByte('-')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,313 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+	"wallet-ledger/models"
+)
+
+const (
+	// approvalActorPlayer is the history actor of redemptions requested by the player
+	approvalActorPlayer = "player"
+
+	// approvalActorSystem is the history actor of requests expired unapproved
+	approvalActorSystem = "system"
+)
+
+// ApprovalRequiredError reports an operation held for a second admin's
+// approval, with the request it is held under
+type ApprovalRequiredError struct {
+	Request *models.ApprovalRequest
+}
+
+func (e *ApprovalRequiredError) Error() string {
+	return fmt.Sprintf("%s: request %d is %s", ErrApprovalRequired, e.Request.ID, e.Request.Status)
+}
+
+func (e *ApprovalRequiredError) Unwrap() error {
+	return ErrApprovalRequired
+}
+
+// ListApprovalThresholds retrieves the approval threshold of every operation and currency
+func (s *WalletService) ListApprovalThresholds() ([]models.ApprovalThreshold, error) {
+	return s.repo.ListApprovalThresholds()
+}
+
+// SaveApprovalThreshold changes the amount above which an operation in a
+// currency needs approval, and how long a request waits before it expires.
+// Requests already pending keep their expiry.
+func (s *WalletService) SaveApprovalThreshold(t *models.ApprovalThreshold) error {
+	if err := s.validateApprovalThreshold(t); err != nil {
+		return err
+	}
+	return s.repo.SaveApprovalThreshold(t)
+}
+
+// validateApprovalThreshold checks a threshold before it is saved
+func (s *WalletService) validateApprovalThreshold(t *models.ApprovalThreshold) error {
+	if !t.Operation.IsValid() {
+		return fmt.Errorf("unknown operation %q: %w", t.Operation, ErrInvalidInput)
+	}
+	if err := s.validateCurrency(t.Currency, false); err != nil {
+		return err
+	}
+	if t.Operation == models.ApprovalOperationBonus && t.Currency != models.CurrencyGC && t.Currency != models.CurrencySC {
+		return fmt.Errorf("bonuses are granted only in GC and SC: %w", ErrInvalidInput)
+	}
+	if t.Operation == models.ApprovalOperationRedemption {
+		if err := s.requireCurrencyFlag(t.Currency, "redeemable", isRedeemable); err != nil {
+			return fmt.Errorf("%s is not redeemable: %w", t.Currency, ErrInvalidInput)
+		}
+	}
+	if t.Threshold < 0 {
+		return fmt.Errorf("threshold cannot be negative: %w", ErrInvalidInput)
+	}
+	if t.ExpiresAfterHours < 1 || t.ExpiresAfterHours > 720 {
+		return fmt.Errorf("expires_after_hours must be between 1 and 720: %w", ErrInvalidInput)
+	}
+	return nil
+}
+
+// approvalThreshold returns the threshold an operation's amount exceeds, or
+// nil if it can post without approval
+func (s *WalletService) approvalThreshold(operation models.ApprovalOperation, currency models.Currency, amount int64) (*models.ApprovalThreshold, error) {
+	t, err := s.repo.GetApprovalThreshold(operation, currency)
+	if err != nil || t == nil {
+		return nil, err
+	}
+	if !exceedsThreshold(amount, t.Threshold) {
+		return nil, nil
+	}
+	return t, nil
+}
+
+// exceedsThreshold reports whether the size of a signed amount is above a
+// threshold; a zero threshold is never exceeded
+func exceedsThreshold(amount, threshold int64) bool {
+	if amount < 0 {
+		amount = -amount
+	}
+	return threshold > 0 && amount > threshold
+}
+
+// holdForApproval records request as pending under threshold t, commits tx and
+// returns the ApprovalRequiredError to report to the caller
+func (s *WalletService) holdForApproval(tx *sql.Tx, request *models.ApprovalRequest, t *models.ApprovalThreshold) error {
+	request.Status = models.ApprovalStatusPending
+	request.CreatedAt = time.Now()
+	request.ExpiresAt = request.CreatedAt.Add(time.Duration(t.ExpiresAfterHours) * time.Hour)
+	if err := s.repo.CreateApprovalRequest(tx, request); err != nil {
+		return err
+	}
+
+	actor := request.RequestedBy
+	if actor == "" {
+		actor = approvalActorPlayer
+	}
+	event := models.ApprovalEvent{RequestID: request.ID, Action: "requested", Actor: actor, Note: request.Note}
+	if err := s.repo.AddApprovalEvent(tx, &event); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	request.History = []models.ApprovalEvent{event}
+	return &ApprovalRequiredError{Request: request}
+}
+
+// ListApprovalRequests retrieves approval requests, newest first
+func (s *WalletService) ListApprovalRequests(status *models.ApprovalStatus, operation *models.ApprovalOperation, cursor *string, limit int) (*models.ApprovalRequestList, error) {
+	return s.repo.ListApprovalRequests(status, operation, cursor, limit)
+}
+
+// GetApprovalRequest retrieves an approval request with its history
+func (s *WalletService) GetApprovalRequest(requestID int) (*models.ApprovalRequest, error) {
+	request, err := s.repo.GetApprovalRequest(requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	request.History, err = s.repo.ListApprovalEvents(requestID)
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// ApproveRequest posts a pending request to the ledger. The approving admin
+// must differ from the admin who requested it.
+func (s *WalletService) ApproveRequest(requestID int, admin, note string) (*models.ApprovalRequest, error) {
+	return s.decideApproval(requestID, admin, note, models.ApprovalStatusApproved)
+}
+
+// RejectRequest closes a pending request without posting it
+func (s *WalletService) RejectRequest(requestID int, admin, note string) (*models.ApprovalRequest, error) {
+	return s.decideApproval(requestID, admin, note, models.ApprovalStatusRejected)
+}
+
+// decideApproval approves or rejects a pending request
+func (s *WalletService) decideApproval(requestID int, admin, note string, status models.ApprovalStatus) (*models.ApprovalRequest, error) {
+	admin = strings.TrimSpace(admin)
+	if admin == "" {
+		return nil, fmt.Errorf("admin is required: %w", ErrInvalidInput)
+	}
+
+	// The request identifies the user; look it up to know whose lock to take
+	pending, err := s.repo.GetApprovalRequest(requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Serialize all operations for this user
+	s.lockUser(pending.UserID)
+	defer s.unlockUser(pending.UserID)
+
+	// Start transaction
+	tx, err := s.repo.BeginTx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	request, err := s.repo.LockApprovalRequestTx(tx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != models.ApprovalStatusPending {
+		return nil, fmt.Errorf("%w: request %d is %s", ErrApprovalDecided, requestID, request.Status)
+	}
+
+	// A request past its expiry is closed rather than decided, even if the
+	// maintenance job has not reached it yet
+	if !time.Now().Before(request.ExpiresAt) {
+		if err := s.expireApprovalRequest(tx, request); err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: request %d expired at %s", ErrApprovalExpired, requestID, request.ExpiresAt.Format(time.RFC3339))
+	}
+
+	if status == models.ApprovalStatusApproved {
+		if request.RequestedBy == admin {
+			return nil, fmt.Errorf("%w: request %d was made by %s", ErrSelfApproval, requestID, admin)
+		}
+
+		txIDs, err := s.postApprovedRequest(tx, request, admin)
+		if err != nil {
+			return nil, err
+		}
+
+		// Replays of the original operation now return what was posted
+		err = s.repo.SaveIdempotencyKey(tx, request.IdempotencyKey, request.UserID, txIDs)
+		if err != nil {
+			return nil, err
+		}
+		request.TransactionID = &txIDs[0]
+	}
+
+	request.Status = status
+	request.DecidedBy = &admin
+	request.DecisionNote = note
+	if err := s.repo.DecideApprovalRequest(tx, request); err != nil {
+		return nil, err
+	}
+
+	event := models.ApprovalEvent{RequestID: request.ID, Action: string(status), Actor: admin, Note: note}
+	if err := s.repo.AddApprovalEvent(tx, &event); err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	request.History, err = s.repo.ListApprovalEvents(requestID)
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// postApprovedRequest posts the operation of an approved request within tx.
+// Balances and campaign limits are checked again, since they may have changed
+// while it waited.
+func (s *WalletService) postApprovedRequest(tx *sql.Tx, request *models.ApprovalRequest, approver string) ([]int, error) {
+	switch request.Operation {
+	case models.ApprovalOperationAdjustment:
+		return s.postAdjustment(tx, &models.Adjustment{
+			UserID:     request.UserID,
+			Currency:   request.Currency,
+			Amount:     request.Amount,
+			Reason:     request.Reason,
+			Note:       request.Note,
+			Admin:      request.RequestedBy,
+			ApprovedBy: &approver,
+		})
+	case models.ApprovalOperationRedemption:
+		if err := s.requireCurrencyFlag(request.Currency, "redeemable", isRedeemable); err != nil {
+			return nil, err
+		}
+		_, txIDs, err := s.postLegs(tx, request.UserID, []models.PostingLeg{
+			{Currency: request.Currency, Type: models.FlowTypesOf(request.Currency).Redeem, Amount: request.Amount},
+		})
+		return txIDs, err
+	case models.ApprovalOperationBonus:
+		amountGC, amountSC := request.Amount, int64(0)
+		if request.Currency == models.CurrencySC {
+			amountGC, amountSC = 0, request.Amount
+		}
+		_, txIDs, err := s.createBonusTransactions(tx, request.CampaignID, request.UserID, amountGC, amountSC, map[string]interface{}{
+			"granted_by":  request.RequestedBy,
+			"approved_by": approver,
+		})
+		return txIDs, err
+	}
+	return nil, fmt.Errorf("unknown operation %q: %w", request.Operation, ErrInvalidInput)
+}
+
+// expireApprovalRequest closes a single pending request past its expiry within tx
+func (s *WalletService) expireApprovalRequest(tx *sql.Tx, request *models.ApprovalRequest) error {
+	request.Status = models.ApprovalStatusExpired
+	if err := s.repo.DecideApprovalRequest(tx, request); err != nil {
+		return err
+	}
+	event := models.ApprovalEvent{RequestID: request.ID, Action: string(models.ApprovalStatusExpired), Actor: approvalActorSystem}
+	return s.repo.AddApprovalEvent(tx, &event)
+}
+
+// ExpireApprovalRequests closes every pending request past its expiry and
+// returns how many were closed
+func (s *WalletService) ExpireApprovalRequests() (int, error) {
+	// Start transaction
+	tx, err := s.repo.BeginTx()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	ids, err := s.repo.ExpireApprovalRequestsTx(tx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	for _, id := range ids {
+		event := models.ApprovalEvent{RequestID: id, Action: string(models.ApprovalStatusExpired), Actor: approvalActorSystem}
+		if err := s.repo.AddApprovalEvent(tx, &event); err != nil {
+			return 0, err
+		}
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"wallet-ledger/models"
+)
+
+const (
+	// MaxWagerBatchSize caps the number of wagers accepted in one batch
+	MaxWagerBatchSize = 500
+
+	// wagerBatchWorkers bounds how many users' wager groups are written concurrently
+	wagerBatchWorkers = 8
+
+	// wagerItemSavepoint isolates each batch item within its user's DB transaction
+	wagerItemSavepoint = "wager_item"
+)
+
+// WagerBatch settles many wagers, possibly for many users, with a result per item.
+// Wagers for the same user are applied in request order inside one DB transaction,
+// each behind a savepoint so a failed item does not undo the others. Different
+// users are processed in parallel.
+func (s *WalletService) WagerBatch(items []models.WagerBatchItem) (*models.WagerBatchResponse, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("batch must contain at least one wager: %w", ErrInvalidInput)
+	}
+	if len(items) > MaxWagerBatchSize {
+		return nil, fmt.Errorf("batch cannot contain more than %d wagers: %w", MaxWagerBatchSize, ErrInvalidInput)
+	}
+
+	results := make([]models.WagerBatchResult, len(items))
+	metadata := make([]json.RawMessage, len(items))
+	games := make(map[string]*models.Game)
+
+	// Group valid items by user, keeping the order users first appear in
+	groups := make(map[int][]int)
+	var users []int
+	for i, item := range items {
+		results[i] = models.WagerBatchResult{
+			Index:          i,
+			UserID:         item.UserID,
+			IdempotencyKey: item.IdempotencyKey,
+		}
+
+		if item.IdempotencyKey == "" {
+			results[i].Status = models.WagerBatchStatusInvalid
+			results[i].Error = "idempotency_key is required"
+			continue
+		}
+		if err := validateWager(item.Legs); err != nil {
+			results[i].Status = models.WagerBatchStatusInvalid
+			results[i].Error = err.Error()
+			continue
+		}
+		if item.GameID == "" {
+			results[i].Status = models.WagerBatchStatusInvalid
+			results[i].Error = "game_id is required"
+			continue
+		}
+
+		// Each distinct game is loaded once per batch
+		game, ok := games[item.GameID]
+		if !ok {
+			var err error
+			game, err = s.loadWagerGame(item.GameID)
+			if err != nil && !errors.Is(err, ErrInvalidGame) {
+				return nil, err
+			}
+			games[item.GameID] = game
+		}
+		if game == nil {
+			results[i].Status = models.WagerBatchStatusInvalid
+			results[i].Error = fmt.Sprintf("%s: unknown game %s", ErrInvalidGame, item.GameID)
+			continue
+		}
+		if err := validateGameWager(game, item.Legs); err != nil {
+			results[i].Status = models.WagerBatchStatusInvalid
+			results[i].Error = err.Error()
+			continue
+		}
+		var extra map[string]interface{}
+		if item.TournamentID != "" {
+			extra = map[string]interface{}{TournamentMetadataKey: item.TournamentID}
+		}
+		itemMetadata, err := wagerMetadata(game, item.RoundID, extra)
+		if err != nil {
+			return nil, err
+		}
+		metadata[i] = itemMetadata
+
+		if _, ok := groups[item.UserID]; !ok {
+			users = append(users, item.UserID)
+		}
+		groups[item.UserID] = append(groups[item.UserID], i)
+	}
+
+	sem := make(chan struct{}, wagerBatchWorkers)
+	var wg sync.WaitGroup
+	for _, userID := range users {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(userID int, indexes []int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			s.settleWagerGroup(userID, items, metadata, indexes, results)
+		}(userID, groups[userID])
+	}
+	wg.Wait()
+
+	return &models.WagerBatchResponse{
+		Results: results,
+		Summary: summarizeWagerBatch(results),
+	}, nil
+}
+
+// settleWagerGroup applies all batch items of one user in a single DB transaction
+func (s *WalletService) settleWagerGroup(userID int, items []models.WagerBatchItem, metadata []json.RawMessage, indexes []int, results []models.WagerBatchResult) {
+	// Serialize with all other operations for this user
+	s.lockUser(userID)
+	defer s.unlockUser(userID)
+
+	failAll := func(status models.WagerBatchStatus, message string) {
+		for _, i := range indexes {
+			results[i].Status = status
+			results[i].Error = message
+			results[i].Transactions = nil
+		}
+	}
+
+	if _, err := s.repo.GetUser(userID); err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			failAll(models.WagerBatchStatusUserNotFound, err.Error())
+			return
+		}
+		log.Printf("Error loading user %d for wager batch: %v", userID, err)
+		failAll(models.WagerBatchStatusError, "failed to process wager")
+		return
+	}
+
+	tx, err := s.repo.BeginTx()
+	if err != nil {
+		log.Printf("Error starting wager batch transaction for user %d: %v", userID, err)
+		failAll(models.WagerBatchStatusError, "failed to process wager")
+		return
+	}
+	defer tx.Rollback()
+
+	for _, i := range indexes {
+		if err := s.settleWagerItem(tx, userID, items[i], metadata[i], &results[i]); err != nil {
+			// The DB transaction itself is unusable; nothing in this group is saved
+			log.Printf("Error settling wager batch for user %d: %v", userID, err)
+			failAll(models.WagerBatchStatusError, "failed to process wager")
+			return
+		}
+	}
+
+	// Fund jackpots from every newly settled stake at once, right before commit,
+	// so pool rows are not held for the whole group and other groups run in parallel
+	var settled []*models.Transaction
+	for _, i := range indexes {
+		if results[i].Status == models.WagerBatchStatusSuccess {
+			settled = append(settled, results[i].Transactions...)
+		}
+	}
+	if err := s.contributeToJackpots(tx, settled); err != nil {
+		log.Printf("Error funding jackpots for wager batch of user %d: %v", userID, err)
+		failAll(models.WagerBatchStatusError, "failed to process wager")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("Error committing wager batch for user %d: %v", userID, err)
+		failAll(models.WagerBatchStatusError, "failed to process wager")
+	}
+}
+
+// settleWagerItem applies one batch item behind a savepoint and records its outcome.
+// It only returns an error when the savepoint itself cannot be managed.
+func (s *WalletService) settleWagerItem(tx *sql.Tx, userID int, item models.WagerBatchItem, metadata json.RawMessage, result *models.WagerBatchResult) error {
+	if err := s.repo.Savepoint(tx, wagerItemSavepoint); err != nil {
+		return err
+	}
+
+	transactions, duplicate, err := s.applyBatchWager(tx, userID, item, metadata)
+	if err != nil {
+		if rbErr := s.repo.RollbackToSavepoint(tx, wagerItemSavepoint); rbErr != nil {
+			return rbErr
+		}
+
+		result.Status, result.Error = batchItemFailure(err)
+		if result.Status == models.WagerBatchStatusError {
+			log.Printf("Error processing batch wager %q: %v", item.IdempotencyKey, err)
+		}
+		return nil
+	}
+
+	if err := s.repo.ReleaseSavepoint(tx, wagerItemSavepoint); err != nil {
+		return err
+	}
+
+	result.Status = models.WagerBatchStatusSuccess
+	if duplicate {
+		result.Status = models.WagerBatchStatusDuplicate
+	}
+	result.Transactions = transactions
+	return nil
+}
+
+// batchItemFailure classifies the error of a failed batch item the way the
+// single-wager endpoint does: rule violations are reported with their message,
+// anything else as an internal error without details
+func batchItemFailure(err error) (models.WagerBatchStatus, string) {
+	switch {
+	case errors.Is(err, ErrInsufficientFunds):
+		return models.WagerBatchStatusInsufficientFunds, err.Error()
+	case errors.Is(err, ErrInvalidInput), errors.Is(err, ErrInvalidGame), errors.Is(err, ErrGameDisabled),
+		errors.Is(err, ErrCurrencyNotAllowed), errors.Is(err, ErrStakeOutOfRange),
+		errors.Is(err, ErrTournamentNotFound), errors.Is(err, ErrTournamentClosed), errors.Is(err, ErrTournamentNotEntered),
+		errors.Is(err, ErrWagerLimitExceeded), errors.Is(err, ErrSelfExcluded),
+		errors.Is(err, ErrRealityCheckDue), errors.Is(err, ErrSessionLossCapReached):
+		return models.WagerBatchStatusInvalid, err.Error()
+	}
+	return models.WagerBatchStatusError, "failed to process wager"
+}
+
+// applyBatchWager writes a single wager, or returns the original transactions if
+// its idempotency key was already used (including earlier in the same batch)
+func (s *WalletService) applyBatchWager(tx *sql.Tx, userID int, item models.WagerBatchItem, metadata json.RawMessage) ([]*models.Transaction, bool, error) {
+	existingTxIDs, err := s.repo.CheckIdempotencyKey(tx, item.IdempotencyKey, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(existingTxIDs) > 0 {
+		transactions, err := s.repo.GetTransactionsTx(tx, existingTxIDs)
+		return transactions, true, err
+	}
+
+	transactions, txIDs, err := s.createWagerTransactions(tx, userID, item.Legs, metadata, item.TournamentID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := s.repo.SaveIdempotencyKey(tx, item.IdempotencyKey, userID, txIDs); err != nil {
+		return nil, false, err
+	}
+
+	return transactions, false, nil
+}
+
+// summarizeWagerBatch counts the outcomes of a batch
+func summarizeWagerBatch(results []models.WagerBatchResult) models.WagerBatchSummary {
+	summary := models.WagerBatchSummary{Total: len(results)}
+	for _, r := range results {
+		switch r.Status {
+		case models.WagerBatchStatusSuccess:
+			summary.Succeeded++
+		case models.WagerBatchStatusDuplicate:
+			summary.Duplicate++
+		default:
+			summary.Failed++
+		}
+	}
+	return summary
+}
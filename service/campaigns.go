@@ -0,0 +1,305 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"wallet-ledger/models"
+)
+
+// ListCampaigns retrieves all bonus campaigns with their issued totals
+func (s *WalletService) ListCampaigns() ([]models.BonusCampaign, error) {
+	return s.repo.ListCampaigns()
+}
+
+// GetCampaign retrieves a single bonus campaign
+func (s *WalletService) GetCampaign(campaignID string) (*models.BonusCampaign, error) {
+	return s.repo.GetCampaign(campaignID)
+}
+
+// SaveCampaign creates or reconfigures a bonus campaign. Lowering a budget below
+// what was already issued is allowed and stops further grants.
+func (s *WalletService) SaveCampaign(campaign *models.BonusCampaign) error {
+	if err := validateCampaign(campaign); err != nil {
+		return err
+	}
+	return s.repo.SaveCampaign(campaign)
+}
+
+// GetCampaignReport summarizes how much a campaign has issued and to how many users
+func (s *WalletService) GetCampaignReport(campaignID string) (*models.CampaignReport, error) {
+	campaign, err := s.repo.GetCampaign(campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	grants, users, err := s.repo.GetCampaignGrantCounts(campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.CampaignReport{
+		Campaign: campaign,
+		Grants:   grants,
+		Users:    users,
+	}
+	if campaign.BudgetGC > 0 {
+		remaining := max(campaign.BudgetGC-campaign.IssuedGC, 0)
+		report.RemainingGC = &remaining
+	}
+	if campaign.BudgetSC > 0 {
+		remaining := max(campaign.BudgetSC-campaign.IssuedSC, 0)
+		report.RemainingSC = &remaining
+	}
+	return report, nil
+}
+
+// GrantBonus credits promotional GC and/or SC to a user under a campaign,
+// within the campaign's budget and per-user caps. A grant above the bonus
+// approval threshold is held for a second admin instead of posting.
+func (s *WalletService) GrantBonus(userID int, campaignID string, amountGC, amountSC int64, admin, idempotencyKey string) ([]*models.Transaction, error) {
+	// Serialize all operations for this user
+	s.lockUser(userID)
+	defer s.unlockUser(userID)
+
+	if err := validateBonus(campaignID, amountGC, amountSC); err != nil {
+		return nil, err
+	}
+	admin = strings.TrimSpace(admin)
+
+	// Verify user exists
+	_, err := s.repo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	thresholdGC, err := s.repo.GetApprovalThreshold(models.ApprovalOperationBonus, models.CurrencyGC)
+	if err != nil {
+		return nil, err
+	}
+	thresholdSC, err := s.repo.GetApprovalThreshold(models.ApprovalOperationBonus, models.CurrencySC)
+	if err != nil {
+		return nil, err
+	}
+	request, threshold, err := bonusApproval(thresholdGC, thresholdSC, amountGC, amountSC)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transaction
+	tx, err := s.repo.BeginTx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Check idempotency
+	existingTxIDs, err := s.repo.CheckIdempotencyKey(tx, idempotencyKey, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existingTxIDs) > 0 {
+		// Already granted, return existing transactions
+		transactions, err := s.repo.GetTransactionsTx(tx, existingTxIDs)
+		if err != nil {
+			return nil, err
+		}
+		tx.Commit()
+		return transactions, nil
+	}
+	held, err := s.repo.GetApprovalRequestByKeyTx(tx, userID, idempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	if held != nil {
+		tx.Commit()
+		return nil, &ApprovalRequiredError{Request: held}
+	}
+
+	// Large grants wait for a second admin; the campaign is checked now and again on approval
+	if request != nil {
+		if _, err := s.checkBonusGrant(tx, campaignID, userID, amountGC, amountSC); err != nil {
+			return nil, err
+		}
+		request.UserID = userID
+		request.CampaignID = campaignID
+		request.RequestedBy = admin
+		request.IdempotencyKey = idempotencyKey
+		return nil, s.holdForApproval(tx, request, threshold)
+	}
+
+	transactions, txIDs, err := s.createBonusTransactions(tx, campaignID, userID, amountGC, amountSC, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save idempotency key with all transaction IDs
+	err = s.repo.SaveIdempotencyKey(tx, idempotencyKey, userID, txIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// createBonusTransactions checks a grant against its campaign and writes the
+// bonus rows within tx. The campaign row stays locked until tx ends, so
+// concurrent grants for different users cannot overrun the budget. The caller
+// must hold the user lock.
+func (s *WalletService) createBonusTransactions(tx *sql.Tx, campaignID string, userID int, amountGC, amountSC int64, extra map[string]interface{}) ([]*models.Transaction, []int, error) {
+	campaign, err := s.checkBonusGrant(tx, campaignID, userID, amountGC, amountSC)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metadata := make(map[string]interface{}, len(extra)+2)
+	for k, v := range extra {
+		metadata[k] = v
+	}
+	metadata["campaign_id"] = campaign.ID
+	metadata["campaign_name"] = campaign.Name
+	metadataJSON, _ := json.Marshal(metadata)
+
+	var transactions []*models.Transaction
+	var txIDs []int
+
+	credit := func(currency models.Currency, txType models.TransactionType, amount int64) error {
+		if amount == 0 {
+			return nil
+		}
+
+		balance, err := s.repo.GetCurrentBalance(tx, userID, currency)
+		if err != nil {
+			return err
+		}
+
+		bonusTx := &models.Transaction{
+			UserID:       userID,
+			Currency:     currency,
+			Type:         txType,
+			Amount:       amount,
+			BalanceAfter: txType.Apply(balance, amount),
+			Metadata:     metadataJSON,
+		}
+		if err := s.repo.CreateTransaction(tx, bonusTx); err != nil {
+			return err
+		}
+		transactions = append(transactions, bonusTx)
+		txIDs = append(txIDs, bonusTx.ID)
+		return nil
+	}
+
+	if err := credit(models.CurrencyGC, models.TransactionTypeBonusGC, amountGC); err != nil {
+		return nil, nil, err
+	}
+	if err := credit(models.CurrencySC, models.TransactionTypeBonusSC, amountSC); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.repo.RecordBonusGrant(tx, campaignID, userID, amountGC, amountSC, txIDs); err != nil {
+		return nil, nil, err
+	}
+
+	return transactions, txIDs, nil
+}
+
+// checkBonusGrant locks a grant's campaign within tx and checks that it is
+// active and that the grant fits its budget and the user's caps
+func (s *WalletService) checkBonusGrant(tx *sql.Tx, campaignID string, userID int, amountGC, amountSC int64) (*models.BonusCampaign, error) {
+	campaign, err := s.repo.LockCampaignTx(tx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if !campaign.IsActive(time.Now()) {
+		return nil, fmt.Errorf("%w: %s", ErrCampaignInactive, campaignID)
+	}
+
+	userGC, userSC, err := s.repo.GetUserCampaignTotalsTx(tx, campaignID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCampaignLimits(campaign, userGC, userSC, amountGC, amountSC); err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// bonusApproval returns the approval request a grant is held under, with the
+// threshold it exceeds, or nil if the grant can post now. A request carries a
+// single currency, so a grant that needs approval cannot mix GC and SC.
+func bonusApproval(thresholdGC, thresholdSC *models.ApprovalThreshold, amountGC, amountSC int64) (*models.ApprovalRequest, *models.ApprovalThreshold, error) {
+	overGC := thresholdGC != nil && exceedsThreshold(amountGC, thresholdGC.Threshold)
+	overSC := thresholdSC != nil && exceedsThreshold(amountSC, thresholdSC.Threshold)
+	if !overGC && !overSC {
+		return nil, nil, nil
+	}
+	if amountGC > 0 && amountSC > 0 {
+		return nil, nil, fmt.Errorf("a grant above the approval threshold must be in one currency; grant GC and SC separately: %w", ErrInvalidInput)
+	}
+
+	request := &models.ApprovalRequest{Operation: models.ApprovalOperationBonus}
+	if overGC {
+		request.Currency, request.Amount = models.CurrencyGC, amountGC
+		return request, thresholdGC, nil
+	}
+	request.Currency, request.Amount = models.CurrencySC, amountSC
+	return request, thresholdSC, nil
+}
+
+// checkCampaignLimits checks a grant against the campaign budget and the
+// amounts the user already received under it
+func checkCampaignLimits(c *models.BonusCampaign, userGC, userSC, amountGC, amountSC int64) error {
+	gc, sc := models.CurrencyGC.MinorUnits(), models.CurrencySC.MinorUnits()
+	if c.BudgetGC > 0 && c.IssuedGC+amountGC > c.BudgetGC {
+		return fmt.Errorf("%w: %s has %s GC left", ErrBonusBudgetExceeded, c.ID, models.FormatAmount(max(c.BudgetGC-c.IssuedGC, 0), gc))
+	}
+	if c.BudgetSC > 0 && c.IssuedSC+amountSC > c.BudgetSC {
+		return fmt.Errorf("%w: %s has %s SC left", ErrBonusBudgetExceeded, c.ID, models.FormatAmount(max(c.BudgetSC-c.IssuedSC, 0), sc))
+	}
+	if c.PerUserCapGC > 0 && userGC+amountGC > c.PerUserCapGC {
+		return fmt.Errorf("%w: %s GC left for this user in %s", ErrBonusCapExceeded, models.FormatAmount(max(c.PerUserCapGC-userGC, 0), gc), c.ID)
+	}
+	if c.PerUserCapSC > 0 && userSC+amountSC > c.PerUserCapSC {
+		return fmt.Errorf("%w: %s SC left for this user in %s", ErrBonusCapExceeded, models.FormatAmount(max(c.PerUserCapSC-userSC, 0), sc), c.ID)
+	}
+	return nil
+}
+
+// validateBonus checks bonus amounts before any repository access
+func validateBonus(campaignID string, amountGC, amountSC int64) error {
+	if campaignID == "" {
+		return fmt.Errorf("campaign_id is required: %w", ErrInvalidInput)
+	}
+	if amountGC < 0 || amountSC < 0 {
+		return fmt.Errorf("amounts cannot be negative: %w", ErrInvalidInput)
+	}
+	if amountGC == 0 && amountSC == 0 {
+		return fmt.Errorf("at least one amount must be greater than zero: %w", ErrInvalidInput)
+	}
+	return nil
+}
+
+// validateCampaign checks a campaign definition before it is saved
+func validateCampaign(c *models.BonusCampaign) error {
+	if c.ID == "" || len(c.ID) > 64 {
+		return fmt.Errorf("campaign id must be 1-64 characters: %w", ErrInvalidInput)
+	}
+	if c.Name == "" {
+		return fmt.Errorf("name is required: %w", ErrInvalidInput)
+	}
+	if c.BudgetGC < 0 || c.BudgetSC < 0 || c.PerUserCapGC < 0 || c.PerUserCapSC < 0 {
+		return fmt.Errorf("budgets and caps cannot be negative: %w", ErrInvalidInput)
+	}
+	if c.StartsAt != nil && c.EndsAt != nil && !c.EndsAt.After(*c.StartsAt) {
+		return fmt.Errorf("ends_at must be after starts_at: %w", ErrInvalidInput)
+	}
+	return nil
+}
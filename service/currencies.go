@@ -0,0 +1,146 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"wallet-ledger/models"
+)
+
+// builtinCurrencies is the registry used until it is loaded from the database.
+// It matches the rows seeded by the currencies migration.
+var builtinCurrencies = newCurrencyRegistry([]models.CurrencyInfo{
+	{Code: models.CurrencyGC, Name: "Gold Coins", Purchasable: true, Wagerable: true},
+	{Code: models.CurrencySC, Name: "Sweeps Coins", MinorUnits: 2, Wagerable: true, Redeemable: true},
+})
+
+var currencyCodePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]{1,15}$`)
+
+// currencyRegistry is an immutable snapshot of the currency registry
+type currencyRegistry struct {
+	list   []models.CurrencyInfo
+	byCode map[models.Currency]*models.CurrencyInfo
+}
+
+func newCurrencyRegistry(currencies []models.CurrencyInfo) *currencyRegistry {
+	r := &currencyRegistry{
+		list:   currencies,
+		byCode: make(map[models.Currency]*models.CurrencyInfo, len(currencies)),
+	}
+	for i := range r.list {
+		r.byCode[r.list[i].Code] = &r.list[i]
+	}
+	return r
+}
+
+// LoadCurrencies (re)loads the currency registry from the database
+func (s *WalletService) LoadCurrencies() error {
+	currencies, err := s.repo.ListCurrencies()
+	if err != nil {
+		return err
+	}
+	registry := newCurrencyRegistry(currencies)
+	s.currencies.Store(registry)
+
+	// Amounts are rendered with the scales of the loaded registry
+	scales := make(map[models.Currency]int, len(currencies))
+	for _, c := range currencies {
+		scales[c.Code] = c.MinorUnits
+	}
+	models.SetMinorUnits(scales)
+	return nil
+}
+
+// ListCurrencies returns every registered currency
+func (s *WalletService) ListCurrencies() []models.CurrencyInfo {
+	return s.currencyRegistry().list
+}
+
+// GetCurrency returns a registered currency
+func (s *WalletService) GetCurrency(code models.Currency) (*models.CurrencyInfo, error) {
+	c, ok := s.currencyRegistry().byCode[code]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrCurrencyNotFound, code)
+	}
+	return c, nil
+}
+
+// ParseAmount converts a decimal amount of a registered currency to its minor
+// units; an empty amount is 0
+func (s *WalletService) ParseAmount(currency models.Currency, amount models.Decimal) (int64, error) {
+	c, ok := s.currencyRegistry().byCode[currency]
+	if !ok {
+		return 0, fmt.Errorf("unknown currency %q: %w", currency, ErrInvalidInput)
+	}
+	if amount == "" {
+		return 0, nil
+	}
+	minor, err := models.ParseAmount(string(amount), c.MinorUnits)
+	if err != nil {
+		return 0, fmt.Errorf("%s amount: %v: %w", currency, err, ErrInvalidInput)
+	}
+	return minor, nil
+}
+
+// SaveCurrency registers a new currency or updates the name and flags of an
+// existing one. A currency's minor units cannot change once it is registered,
+// since existing amounts are stored in them.
+func (s *WalletService) SaveCurrency(c *models.CurrencyInfo) error {
+	if !currencyCodePattern.MatchString(string(c.Code)) {
+		return fmt.Errorf("code must be 2-16 upper-case letters, digits or underscores: %w", ErrInvalidInput)
+	}
+	if c.Name == "" || len(c.Name) > 64 {
+		return fmt.Errorf("name must be 1-64 characters: %w", ErrInvalidInput)
+	}
+	if c.MinorUnits < 0 || c.MinorUnits > 8 {
+		return fmt.Errorf("minor_units must be between 0 and 8: %w", ErrInvalidInput)
+	}
+
+	// Reload first so the minor units are compared with the stored value
+	if err := s.LoadCurrencies(); err != nil {
+		return err
+	}
+	if existing, err := s.GetCurrency(c.Code); err == nil && existing.MinorUnits != c.MinorUnits {
+		return fmt.Errorf("minor_units of %s cannot be changed: %w", c.Code, ErrInvalidInput)
+	}
+
+	if err := s.repo.SaveCurrency(c); err != nil {
+		return err
+	}
+	return s.LoadCurrencies()
+}
+
+// currencyRegistry returns the loaded registry, or the built-in one if the
+// registry has not been loaded yet
+func (s *WalletService) currencyRegistry() *currencyRegistry {
+	if r := s.currencies.Load(); r != nil {
+		return r
+	}
+	return builtinCurrencies
+}
+
+// validateCurrency checks that a currency configured on a game, jackpot,
+// tournament or limit is registered, and wagerable when wagerable is set
+func (s *WalletService) validateCurrency(code models.Currency, wagerable bool) error {
+	c, ok := s.currencyRegistry().byCode[code]
+	if !ok {
+		return fmt.Errorf("unknown currency %q: %w", code, ErrInvalidInput)
+	}
+	if wagerable && !c.Wagerable {
+		return fmt.Errorf("currency %s cannot be wagered: %w", code, ErrInvalidInput)
+	}
+	return nil
+}
+
+// requireCurrencyFlag rejects an operation on a currency whose registry flag
+// for it is off; name describes the flag in the error
+func (s *WalletService) requireCurrencyFlag(code models.Currency, name string, flag func(*models.CurrencyInfo) bool) error {
+	c, ok := s.currencyRegistry().byCode[code]
+	if !ok || !flag(c) {
+		return fmt.Errorf("%w: %s is not %s", ErrCurrencyNotAllowed, code, name)
+	}
+	return nil
+}
+
+func isPurchasable(c *models.CurrencyInfo) bool { return c.Purchasable }
+func isWagerable(c *models.CurrencyInfo) bool   { return c.Wagerable }
+func isRedeemable(c *models.CurrencyInfo) bool  { return c.Redeemable }
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"fmt"
+	"time"
+	"wallet-ledger/models"
+)
+
+const (
+	// DailyBonusCampaignID is the bonus campaign daily login bonuses are issued under
+	DailyBonusCampaignID = "daily_login"
+
+	dateLayout = "2006-01-02"
+)
+
+// GetDailyBonusSchedule retrieves the daily login streak schedule
+func (s *WalletService) GetDailyBonusSchedule() ([]models.DailyBonusDay, error) {
+	return s.repo.GetDailyBonusSchedule()
+}
+
+// SaveDailyBonusSchedule replaces the daily login streak schedule
+func (s *WalletService) SaveDailyBonusSchedule(schedule []models.DailyBonusDay) error {
+	if err := validateDailyBonusSchedule(schedule); err != nil {
+		return err
+	}
+	return s.repo.SaveDailyBonusSchedule(schedule)
+}
+
+// SetUserTimeZone changes the IANA time zone a user's calendar days are counted
+// in. A change does not open a second daily bonus claim on the same day; see
+// dailyBonusClaimed.
+func (s *WalletService) SetUserTimeZone(userID int, timeZone string) error {
+	if _, err := time.LoadLocation(timeZone); err != nil || timeZone == "" {
+		return fmt.Errorf("unknown time zone %q: %w", timeZone, ErrInvalidInput)
+	}
+	return s.repo.SetUserTimeZone(userID, timeZone)
+}
+
+// ClaimDailyBonus grants the daily login bonus once per calendar day in the
+// user's time zone. Consecutive days escalate along the streak schedule; a
+// missed day restarts the streak. Claiming again on the same day returns the
+// original claim.
+func (s *WalletService) ClaimDailyBonus(userID int) (*models.DailyBonusClaim, error) {
+	// Serialize all operations for this user
+	s.lockUser(userID)
+	defer s.unlockUser(userID)
+
+	// Verify user exists
+	user, err := s.repo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(user.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("user %d has invalid time zone %q: %w", userID, user.TimeZone, err)
+	}
+	now := time.Now()
+	today, nextClaimAt := claimDay(now, loc)
+
+	// Start transaction
+	tx, err := s.repo.BeginTx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	last, lastTxIDs, err := s.repo.GetLastDailyBonusClaimTx(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Already claimed today, including after a time zone change
+	if dailyBonusClaimed(last, today, loc) {
+		transactions, err := s.repo.GetTransactionsTx(tx, lastTxIDs)
+		if err != nil {
+			return nil, err
+		}
+		tx.Commit()
+
+		last.AlreadyClaimed = true
+		last.NextClaimAt = nextClaimAt
+		last.Transactions = transactions
+		return last, nil
+	}
+
+	schedule, err := s.repo.GetDailyBonusSchedule()
+	if err != nil {
+		return nil, err
+	}
+	if len(schedule) == 0 {
+		return nil, ErrDailyBonusUnavailable
+	}
+
+	var lastDate string
+	var lastStreak int
+	if last != nil {
+		lastDate, lastStreak = last.ClaimDate, last.Streak
+	}
+	streak := dailyBonusStreak(lastDate, lastStreak, today)
+	reward := dailyBonusReward(schedule, streak)
+
+	claim := &models.DailyBonusClaim{
+		UserID:      userID,
+		ClaimDate:   today,
+		Streak:      streak,
+		AmountGC:    reward.AmountGC,
+		AmountSC:    reward.AmountSC,
+		NextClaimAt: nextClaimAt,
+		ClaimedAt:   now.UTC(),
+	}
+
+	transactions, txIDs, err := s.createBonusTransactions(tx, DailyBonusCampaignID, userID, reward.AmountGC, reward.AmountSC, map[string]interface{}{
+		"claim_date": today,
+		"streak":     streak,
+	})
+	if err != nil {
+		return nil, err
+	}
+	claim.Transactions = transactions
+
+	err = s.repo.CreateDailyBonusClaim(tx, claim, txIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return claim, nil
+}
+
+// claimDay returns the calendar date of now in loc and when the next day starts
+func claimDay(now time.Time, loc *time.Location) (string, time.Time) {
+	local := now.In(loc)
+	y, m, d := local.Date()
+	return local.Format(dateLayout), time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+}
+
+// dailyBonusClaimed reports whether the previous claim already covers today.
+// Besides its claim date, the moment it was claimed is read in the user's
+// current time zone, so moving to a zone ahead of the old one does not open a
+// second claim until the next calendar day after that moment.
+func dailyBonusClaimed(last *models.DailyBonusClaim, today string, loc *time.Location) bool {
+	if last == nil {
+		return false
+	}
+	claimedOn, _ := claimDay(last.ClaimedAt, loc)
+	return last.ClaimDate >= today || claimedOn >= today
+}
+
+// dailyBonusStreak returns the streak day of a claim on today, given the
+// previous claim. The streak continues only if the previous claim was yesterday.
+func dailyBonusStreak(lastDate string, lastStreak int, today string) int {
+	if lastDate == "" {
+		return 1
+	}
+
+	last, err := time.Parse(dateLayout, lastDate)
+	if err != nil {
+		return 1
+	}
+	if last.AddDate(0, 0, 1).Format(dateLayout) == today {
+		return lastStreak + 1
+	}
+	return 1
+}
+
+// dailyBonusReward returns the schedule entry for a streak day; streaks longer
+// than the schedule keep the last day's reward
+func dailyBonusReward(schedule []models.DailyBonusDay, streak int) models.DailyBonusDay {
+	if streak > len(schedule) {
+		return schedule[len(schedule)-1]
+	}
+	return schedule[streak-1]
+}
+
+// validateDailyBonusSchedule checks that the schedule covers days 1..N in order
+// and that every day pays something
+func validateDailyBonusSchedule(schedule []models.DailyBonusDay) error {
+	if len(schedule) == 0 {
+		return fmt.Errorf("schedule must contain at least one day: %w", ErrInvalidInput)
+	}
+	for i, d := range schedule {
+		if d.Day != i+1 {
+			return fmt.Errorf("schedule days must be numbered 1 to %d in order: %w", len(schedule), ErrInvalidInput)
+		}
+		if d.AmountGC < 0 || d.AmountSC < 0 {
+			return fmt.Errorf("day %d: amounts cannot be negative: %w", d.Day, ErrInvalidInput)
+		}
+		if d.AmountGC == 0 && d.AmountSC == 0 {
+			return fmt.Errorf("day %d: at least one amount must be greater than zero: %w", d.Day, ErrInvalidInput)
+		}
+	}
+	return nil
+}
@@ -1,10 +1,62 @@
 package service
 
-import "errors"
+import (
+	"errors"
+	"wallet-ledger/repository"
+)
 
 // Common service errors
 var (
-	ErrInsufficientFunds = errors.New("insufficient funds")
-	ErrInvalidInput      = errors.New("invalid input")
-	ErrInvalidPackage    = errors.New("invalid package")
+	ErrInsufficientFunds      = errors.New("insufficient funds")
+	ErrInvalidInput           = errors.New("invalid input")
+	ErrInvalidPackage         = errors.New("invalid package")
+	ErrOfferNotEligible       = errors.New("not eligible for offer")
+	ErrSpendLimitExceeded     = errors.New("purchase spend limit exceeded")
+	ErrWagerLimitExceeded     = errors.New("wager limit exceeded")
+	ErrSelfExcluded           = errors.New("user is self-excluded")
+	ErrSelfExclusionActive    = errors.New("a longer self-exclusion is already active")
+	ErrGameSessionNotFound    = errors.New("no open game session")
+	ErrRealityCheckDue        = errors.New("reality check must be acknowledged")
+	ErrSessionLossCapReached  = errors.New("session loss cap reached")
+	ErrSCExpiryRunNotFound    = repository.ErrSCExpiryRunNotFound
+	ErrUserNotFound           = repository.ErrUserNotFound
+	ErrWagerNotFound          = errors.New("wager not found")
+	ErrWagerRolledBack        = errors.New("wager was rolled back")
+	ErrGameNotFound           = repository.ErrGameNotFound
+	ErrInvalidGame            = errors.New("invalid game")
+	ErrGameDisabled           = errors.New("game is disabled")
+	ErrCurrencyNotAllowed     = errors.New("currency not allowed")
+	ErrCurrencyNotFound       = errors.New("currency not found")
+	ErrStakeOutOfRange        = errors.New("stake out of range")
+	ErrJackpotNotFound        = repository.ErrJackpotNotFound
+	ErrJackpotEmpty           = errors.New("jackpot pool is empty")
+	ErrJackpotDisabled        = errors.New("jackpot pool is disabled")
+	ErrTournamentNotFound     = repository.ErrTournamentNotFound
+	ErrTournamentClosed       = errors.New("tournament is not open")
+	ErrTournamentNotEntered   = errors.New("user has not entered tournament")
+	ErrTournamentNotEnded     = errors.New("tournament has not ended")
+	ErrCampaignNotFound       = repository.ErrCampaignNotFound
+	ErrCampaignInactive       = errors.New("campaign is not active")
+	ErrBonusBudgetExceeded    = errors.New("campaign budget exceeded")
+	ErrBonusCapExceeded       = errors.New("per-user bonus cap exceeded")
+	ErrDailyBonusUnavailable  = errors.New("daily bonus is not configured")
+	ErrAMOECodeNotFound       = repository.ErrAMOECodeNotFound
+	ErrAMOECodeUsed           = errors.New("amoe code already used")
+	ErrAMOECodeExpired        = errors.New("amoe code expired")
+	ErrAMOELimitReached       = errors.New("amoe entry limit reached")
+	ErrAMOEEntryNotFound      = repository.ErrAMOEEntryNotFound
+	ErrAMOEEntryReviewed      = errors.New("amoe entry already reviewed")
+	ErrPromoCodeNotFound      = repository.ErrPromoCodeNotFound
+	ErrPromoCodeInvalid       = errors.New("invalid promo code")
+	ErrPromoCodeExpired       = errors.New("promo code expired")
+	ErrPromoCodeExhausted     = errors.New("promo code fully redeemed")
+	ErrPromoCodeAlreadyUsed   = errors.New("promo code already used")
+	ErrPromoCodeNotApplicable = errors.New("promo code not valid for package")
+	ErrApprovalRequired       = errors.New("approval required")
+	ErrApprovalNotFound       = repository.ErrApprovalNotFound
+	ErrApprovalDecided        = errors.New("approval request already decided")
+	ErrApprovalExpired        = errors.New("approval request expired")
+	ErrSelfApproval           = errors.New("requester cannot approve their own request")
+	ErrPostingOverThreshold   = errors.New("posting exceeds approval threshold")
+	ErrBatchKeyReused         = errors.New("batch key already used for different legs")
 )
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+	"wallet-ledger/models"
+)
+
+// GameSessionIdleTimeout is how long a session stays open without a wager
+const GameSessionIdleTimeout = 30 * time.Minute
+
+// GetGameSessionSettings retrieves a user's session settings
+func (s *WalletService) GetGameSessionSettings(userID int) (*models.GameSessionSettings, error) {
+	// Verify user exists
+	_, err := s.repo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetGameSessionSettings(userID)
+}
+
+// SaveGameSessionSettings updates a user's session settings. They apply from
+// the user's next session.
+func (s *WalletService) SaveGameSessionSettings(settings *models.GameSessionSettings) error {
+	if settings.RealityCheckMinutes < 0 {
+		return fmt.Errorf("reality_check_minutes cannot be negative: %w", ErrInvalidInput)
+	}
+	if settings.LossCapGC < 0 || settings.LossCapSC < 0 {
+		return fmt.Errorf("loss caps cannot be negative: %w", ErrInvalidInput)
+	}
+
+	// Verify user exists
+	_, err := s.repo.GetUser(settings.UserID)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.SaveGameSessionSettings(settings)
+}
+
+// GetGameSession retrieves a summary of a user's open session
+func (s *WalletService) GetGameSession(userID int) (*models.GameSessionSummary, error) {
+	// Verify user exists
+	_, err := s.repo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.repo.GetOpenGameSession(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if session == nil || isGameSessionIdle(session, now) {
+		return nil, ErrGameSessionNotFound
+	}
+
+	summary := session.Summary(now)
+	return &summary, nil
+}
+
+// ListGameSessions retrieves summaries of a user's most recent sessions
+func (s *WalletService) ListGameSessions(userID int, limit int) ([]models.GameSessionSummary, error) {
+	// Verify user exists
+	_, err := s.repo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.repo.ListGameSessions(userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	summaries := make([]models.GameSessionSummary, 0, len(sessions))
+	for i := range sessions {
+		session := &sessions[i]
+		if session.EndedAt == nil && isGameSessionIdle(session, now) {
+			// Not yet closed by the background job
+			endedAt := session.LastActivityAt
+			session.EndedAt = &endedAt
+		}
+		summaries = append(summaries, session.Summary(now))
+	}
+	return summaries, nil
+}
+
+// AcknowledgeRealityCheck records that the player has seen their session
+// summary, allowing wagers again until the next reality check falls due
+func (s *WalletService) AcknowledgeRealityCheck(userID int) (*models.GameSessionSummary, error) {
+	// Serialize all operations for this user
+	s.lockUser(userID)
+	defer s.unlockUser(userID)
+
+	// Verify user exists
+	_, err := s.repo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transaction
+	tx, err := s.repo.BeginTx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	session, err := s.repo.LockOpenGameSessionTx(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if session == nil || isGameSessionIdle(session, now) {
+		return nil, ErrGameSessionNotFound
+	}
+
+	session, err = s.repo.AcknowledgeRealityCheckTx(tx, session.ID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	summary := session.Summary(now)
+	return &summary, nil
+}
+
+// CloseIdleGameSessions ends every session that has been idle for the idle
+// timeout and returns how many were closed
+func (s *WalletService) CloseIdleGameSessions() (int, error) {
+	return s.repo.CloseIdleGameSessions(time.Now().Add(-GameSessionIdleTimeout))
+}
+
+// openGameSession returns the user's open session, closing it and opening a
+// new one if it has been idle too long. The caller must hold the user lock.
+func (s *WalletService) openGameSession(tx *sql.Tx, userID int, now time.Time) (*models.GameSession, error) {
+	session, err := s.repo.LockOpenGameSessionTx(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if session != nil && !isGameSessionIdle(session, now) {
+		return session, nil
+	}
+
+	if session != nil {
+		if err := s.repo.CloseGameSessionTx(tx, session.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	settings, err := s.repo.GetGameSessionSettingsTx(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.CreateGameSessionTx(tx, settings, now)
+}
+
+// recordGameSessionActivity adds the stakes and payouts among transactions to
+// session. The session's last activity is taken from the transactions so that
+// refunds can find the session their stake was wagered in.
+func (s *WalletService) recordGameSessionActivity(tx *sql.Tx, session *models.GameSession, transactions []*models.Transaction) error {
+	lastActivity := session.LastActivityAt
+	for _, t := range transactions {
+		if t.CreatedAt.After(lastActivity) {
+			lastActivity = t.CreatedAt
+		}
+	}
+
+	totals := wagerTotals(transactions)
+	gc, sc := totals[models.CurrencyGC], totals[models.CurrencySC]
+	return s.repo.AddGameSessionActivityTx(tx, session.ID, lastActivity, gc.Wagered, gc.Won, sc.Wagered, sc.Won)
+}
+
+// checkGameSession rejects stakes while a reality check is due or that could
+// take the session's losses past its loss cap
+func checkGameSession(session *models.GameSession, legs []models.WagerLeg, now time.Time) error {
+	if session.RealityCheckDue(now) {
+		return fmt.Errorf("%w: session summary due since %s", ErrRealityCheckDue, session.NextRealityCheckAt().UTC().Format(time.RFC3339))
+	}
+
+	for _, l := range legs {
+		limit := session.LossCap(l.Currency)
+		if l.Stake <= 0 || limit <= 0 {
+			continue
+		}
+
+		// A stake counts as lost until its payout arrives
+		lost := session.Totals(l.Currency).NetLoss()
+		if lost+l.Stake > limit {
+			scale := l.Currency.MinorUnits()
+			return fmt.Errorf("%w: %s loss cap of %s has %s remaining", ErrSessionLossCapReached, l.Currency,
+				models.FormatAmount(limit, scale), models.FormatAmount(max(limit-lost, 0), scale))
+		}
+	}
+	return nil
+}
+
+// isGameSessionIdle reports whether session has had no wager for the idle timeout
+func isGameSessionIdle(session *models.GameSession, now time.Time) bool {
+	return !now.Before(session.LastActivityAt.Add(GameSessionIdleTimeout))
+}
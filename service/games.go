@@ -0,0 +1,133 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"wallet-ledger/models"
+)
+
+// ListGames retrieves the game registry
+func (s *WalletService) ListGames() ([]models.Game, error) {
+	return s.repo.ListGames()
+}
+
+// GetGame retrieves a single game
+func (s *WalletService) GetGame(gameID string) (*models.Game, error) {
+	return s.repo.GetGame(gameID)
+}
+
+// SaveGame creates or replaces a game in the registry
+func (s *WalletService) SaveGame(game *models.Game) error {
+	if err := s.validateGame(game); err != nil {
+		return err
+	}
+	return s.repo.SaveGame(game)
+}
+
+// validateGame checks a game definition before it is saved
+func (s *WalletService) validateGame(game *models.Game) error {
+	if game.ID == "" || len(game.ID) > 64 {
+		return fmt.Errorf("game id must be 1-64 characters: %w", ErrInvalidInput)
+	}
+	if game.Provider == "" || len(game.Provider) > 64 {
+		return fmt.Errorf("provider must be 1-64 characters: %w", ErrInvalidInput)
+	}
+	if game.Name == "" {
+		return fmt.Errorf("name is required: %w", ErrInvalidInput)
+	}
+	if game.RTP <= 0 || game.RTP > 100 {
+		return fmt.Errorf("rtp must be between 0 and 100: %w", ErrInvalidInput)
+	}
+	if len(game.Currencies) == 0 {
+		return fmt.Errorf("at least one currency is required: %w", ErrInvalidInput)
+	}
+
+	seen := make(map[models.Currency]bool)
+	for _, c := range game.Currencies {
+		if err := s.validateCurrency(c.Currency, true); err != nil {
+			return err
+		}
+		if seen[c.Currency] {
+			return fmt.Errorf("duplicate currency %s: %w", c.Currency, ErrInvalidInput)
+		}
+		seen[c.Currency] = true
+
+		if c.MinStake < 0 || c.MaxStake < 0 {
+			return fmt.Errorf("stake limits cannot be negative: %w", ErrInvalidInput)
+		}
+		if c.MaxStake > 0 && c.MinStake > c.MaxStake {
+			return fmt.Errorf("min_stake cannot exceed max_stake: %w", ErrInvalidInput)
+		}
+	}
+
+	return nil
+}
+
+// validateGameWager checks a wager against the game it is played on
+func validateGameWager(game *models.Game, legs []models.WagerLeg) error {
+	if !game.Enabled {
+		return fmt.Errorf("%w: %s", ErrGameDisabled, game.ID)
+	}
+
+	for _, l := range legs {
+		if l.Stake == 0 && l.Payout == 0 {
+			continue
+		}
+
+		limits, ok := game.CurrencyLimits(l.Currency)
+		if !ok {
+			return fmt.Errorf("%w: %s cannot be played with %s", ErrCurrencyNotAllowed, game.ID, l.Currency)
+		}
+
+		scale := l.Currency.MinorUnits()
+		if l.Stake > 0 && l.Stake < limits.MinStake {
+			return fmt.Errorf("%w: %s stake %s is below the minimum of %s", ErrStakeOutOfRange, l.Currency,
+				models.FormatAmount(l.Stake, scale), models.FormatAmount(limits.MinStake, scale))
+		}
+		if limits.MaxStake > 0 && l.Stake > limits.MaxStake {
+			return fmt.Errorf("%w: %s stake %s exceeds the maximum of %s", ErrStakeOutOfRange, l.Currency,
+				models.FormatAmount(l.Stake, scale), models.FormatAmount(limits.MaxStake, scale))
+		}
+	}
+	return nil
+}
+
+// gameWagerMetadata loads and validates the game of a wager and returns the
+// metadata stored on its transactions, merged with any caller metadata
+func (s *WalletService) gameWagerMetadata(gameID, roundID string, legs []models.WagerLeg, extra map[string]interface{}) (json.RawMessage, error) {
+	game, err := s.loadWagerGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateGameWager(game, legs); err != nil {
+		return nil, err
+	}
+
+	return wagerMetadata(game, roundID, extra)
+}
+
+// loadWagerGame retrieves the game a wager is placed on
+func (s *WalletService) loadWagerGame(gameID string) (*models.Game, error) {
+	game, err := s.repo.GetGame(gameID)
+	if errors.Is(err, ErrGameNotFound) {
+		return nil, fmt.Errorf("%w: unknown game %s", ErrInvalidGame, gameID)
+	}
+	return game, err
+}
+
+// wagerMetadata builds the metadata recorded on a wager's transactions
+func wagerMetadata(game *models.Game, roundID string, extra map[string]interface{}) (json.RawMessage, error) {
+	metadata := make(map[string]interface{}, len(extra)+3)
+	for k, v := range extra {
+		metadata[k] = v
+	}
+	metadata["game_id"] = game.ID
+	metadata["provider"] = game.Provider
+	if roundID != "" {
+		metadata["round_id"] = roundID
+	}
+
+	return json.Marshal(metadata)
+}
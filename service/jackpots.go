@@ -0,0 +1,221 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"wallet-ledger/models"
+)
+
+// ListJackpotPools retrieves all jackpot pools with their live amounts
+func (s *WalletService) ListJackpotPools() ([]models.JackpotPool, error) {
+	return s.repo.ListJackpotPools()
+}
+
+// GetJackpotPool retrieves a single jackpot pool
+func (s *WalletService) GetJackpotPool(poolID string) (*models.JackpotPool, error) {
+	return s.repo.GetJackpotPool(poolID)
+}
+
+// SaveJackpotPool creates or reconfigures a jackpot pool. A new pool starts at
+// its seed amount; reconfiguring keeps the amount accumulated so far.
+func (s *WalletService) SaveJackpotPool(pool *models.JackpotPool) error {
+	if err := s.validateJackpotPool(pool); err != nil {
+		return err
+	}
+	return s.repo.SaveJackpotPool(pool)
+}
+
+// ListJackpotContributions retrieves paginated contributions to a pool
+func (s *WalletService) ListJackpotContributions(poolID string, cursor *string, limit int) (*models.JackpotContributionList, error) {
+	// Verify pool exists
+	pool, err := s.repo.GetJackpotPool(poolID)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.repo.ListJackpotContributions(poolID, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		list.Items[i].Currency = pool.Currency
+	}
+	return list, nil
+}
+
+// JackpotWin pays the current value of a pool to a user and resets the pool to
+// its seed amount, atomically with respect to concurrent contributions. A
+// disabled pool cannot be won.
+func (s *WalletService) JackpotWin(poolID string, userID int, idempotencyKey string) (*models.Transaction, error) {
+	// Serialize all operations for this user
+	s.lockUser(userID)
+	defer s.unlockUser(userID)
+
+	if poolID == "" {
+		return nil, fmt.Errorf("pool id is required: %w", ErrInvalidInput)
+	}
+
+	// Verify user exists
+	_, err := s.repo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transaction
+	tx, err := s.repo.BeginTx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Check idempotency
+	existingTxIDs, err := s.repo.CheckIdempotencyKey(tx, idempotencyKey, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existingTxIDs) > 0 {
+		// Already paid, return existing transaction
+		tx.Commit()
+		return s.repo.GetTransaction(existingTxIDs[0])
+	}
+
+	// Lock the pool so no contribution lands between payout and reset
+	pool, err := s.repo.LockJackpotPoolTx(tx, poolID)
+	if err != nil {
+		return nil, err
+	}
+	if !pool.Enabled {
+		return nil, fmt.Errorf("%w: %s", ErrJackpotDisabled, poolID)
+	}
+	if pool.Amount <= 0 {
+		return nil, fmt.Errorf("%w: %s", ErrJackpotEmpty, poolID)
+	}
+
+	txType := models.FlowTypesOf(pool.Currency).Jackpot
+
+	balance, err := s.repo.GetCurrentBalance(tx, userID, pool.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := map[string]interface{}{
+		"pool_id":     pool.ID,
+		"pool_name":   pool.Name,
+		"seed_amount": pool.SeedAmount,
+	}
+	metadataJSON, _ := json.Marshal(metadata)
+
+	winTx := &models.Transaction{
+		UserID:       userID,
+		Currency:     pool.Currency,
+		Type:         txType,
+		Amount:       pool.Amount,
+		BalanceAfter: txType.Apply(balance, pool.Amount),
+		Metadata:     metadataJSON,
+	}
+
+	err = s.repo.CreateTransaction(tx, winTx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.RecordJackpotWin(tx, pool, userID, winTx.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save idempotency key
+	err = s.repo.SaveIdempotencyKey(tx, idempotencyKey, userID, []int{winTx.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return winTx, nil
+}
+
+// contributeToJackpots adds the configured share of each stake in transactions
+// to the enabled pools of its currency, within the wager's DB transaction.
+// Pools are read without locking; each contribution is a single atomic
+// increment, applied in pool ID order so that wagers touching several pools
+// cannot deadlock.
+func (s *WalletService) contributeToJackpots(tx *sql.Tx, transactions []*models.Transaction) error {
+	var stakes []*models.Transaction
+	var currencies []models.Currency
+	for _, t := range transactions {
+		if t.Type.IsStake() {
+			stakes = append(stakes, t)
+			currencies = append(currencies, t.Currency)
+		}
+	}
+	if len(stakes) == 0 {
+		return nil
+	}
+
+	pools, err := s.repo.ListEnabledJackpotPoolsTx(tx, currencies)
+	if err != nil {
+		return err
+	}
+
+	for _, pool := range pools {
+		for _, stake := range stakes {
+			if stake.Currency != pool.Currency || stake.Amount < pool.MinStake {
+				continue
+			}
+
+			amount := jackpotContribution(stake.Amount, pool.ContributionBPS)
+			if amount == 0 {
+				continue
+			}
+
+			added, err := s.repo.AddJackpotContribution(tx, &models.JackpotContribution{
+				PoolID:        pool.ID,
+				UserID:        stake.UserID,
+				TransactionID: stake.ID,
+				Stake:         stake.Amount,
+				Amount:        amount,
+			})
+			if err != nil {
+				return err
+			}
+			if !added {
+				// Disabled since it was read
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// jackpotContribution returns the share of a stake contributed to a pool,
+// rounded down to the currency's minor unit
+func jackpotContribution(stake, contributionBPS int64) int64 {
+	return stake * contributionBPS / 10000
+}
+
+// validateJackpotPool checks a pool definition before it is saved
+func (s *WalletService) validateJackpotPool(pool *models.JackpotPool) error {
+	if pool.ID == "" || len(pool.ID) > 64 {
+		return fmt.Errorf("pool id must be 1-64 characters: %w", ErrInvalidInput)
+	}
+	if pool.Name == "" {
+		return fmt.Errorf("name is required: %w", ErrInvalidInput)
+	}
+	if err := s.validateCurrency(pool.Currency, true); err != nil {
+		return err
+	}
+	if pool.ContributionBPS < 0 || pool.ContributionBPS > 10000 {
+		return fmt.Errorf("contribution_bps must be between 0 and 10000: %w", ErrInvalidInput)
+	}
+	if pool.MinStake < 0 || pool.SeedAmount < 0 {
+		return fmt.Errorf("min_stake and seed_amount cannot be negative: %w", ErrInvalidInput)
+	}
+	return nil
+}
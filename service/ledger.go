@@ -0,0 +1,133 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"wallet-ledger/models"
+)
+
+// ledgerVerifyPage is how many transactions are loaded at a time while verifying
+const ledgerVerifyPage = 1000
+
+// VerifyLedger checks a user's ledger against the posting rules: each
+// transaction must have a known type allowed in its currency, each
+// balance_after must follow from the previous one, and the balances summed by
+// the database must match the latest balance_after of each currency.
+func (s *WalletService) VerifyLedger(userID int) (*models.LedgerVerification, error) {
+	// Verify user exists
+	_, err := s.repo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	checker := newLedgerChecker()
+	afterID := 0
+	for {
+		page, err := s.repo.ListTransactionsAfter(userID, afterID, ledgerVerifyPage)
+		if err != nil {
+			return nil, err
+		}
+		for i := range page {
+			checker.check(&page[i])
+		}
+		if len(page) < ledgerVerifyPage {
+			break
+		}
+		afterID = page[len(page)-1].ID
+	}
+
+	sums, err := s.repo.SumTransactions(userID)
+	if err != nil {
+		return nil, err
+	}
+	summed := make(map[models.Currency]int64, len(sums))
+	for currency, sum := range sums {
+		summed[currency] = sum.Balance
+	}
+	checker.compareSums(summed)
+
+	return &models.LedgerVerification{
+		UserID:              userID,
+		TransactionsChecked: checker.checked,
+		Balances:            checker.balances,
+		Consistent:          len(checker.discrepancies) == 0,
+		Discrepancies:       checker.discrepancies,
+	}, nil
+}
+
+// ledgerChecker replays transactions in ID order through the posting rules
+type ledgerChecker struct {
+	checked       int
+	balances      map[models.Currency]int64 // latest stored balance_after
+	discrepancies []models.LedgerDiscrepancy
+}
+
+func newLedgerChecker() *ledgerChecker {
+	return &ledgerChecker{
+		balances:      make(map[models.Currency]int64),
+		discrepancies: []models.LedgerDiscrepancy{},
+	}
+}
+
+// check verifies one transaction against the running balance of its currency.
+// The running balance continues from the stored balance_after, so a single
+// bad row is reported once instead of for every row after it.
+func (c *ledgerChecker) check(t *models.Transaction) {
+	c.checked++
+	previous := c.balances[t.Currency]
+	c.balances[t.Currency] = t.BalanceAfter
+
+	rule, ok := t.Type.Rule()
+	switch {
+	case !ok:
+		c.report(t, fmt.Sprintf("unknown transaction type %q", t.Type), 0, 0)
+		return
+	case !rule.AllowsCurrency(t.Currency):
+		c.report(t, fmt.Sprintf("%s cannot be posted in %s", t.Type, t.Currency), 0, 0)
+	case t.Amount < 0 && !rule.Signed:
+		c.report(t, "negative amount", 0, t.Amount)
+	}
+
+	if expected := t.Type.Apply(previous, t.Amount); t.BalanceAfter != expected {
+		c.report(t, "balance_after does not follow from the previous balance", expected, t.BalanceAfter)
+	}
+	if t.BalanceAfter < 0 {
+		c.report(t, "negative balance", 0, t.BalanceAfter)
+	}
+}
+
+// compareSums checks the balances summed by the database against the latest
+// balance_after of each currency
+func (c *ledgerChecker) compareSums(summed map[models.Currency]int64) {
+	currencies := make([]models.Currency, 0, len(c.balances))
+	for currency := range c.balances {
+		currencies = append(currencies, currency)
+	}
+	for currency := range summed {
+		if _, ok := c.balances[currency]; !ok {
+			currencies = append(currencies, currency)
+		}
+	}
+	sort.Slice(currencies, func(i, j int) bool { return currencies[i] < currencies[j] })
+
+	for _, currency := range currencies {
+		if summed[currency] != c.balances[currency] {
+			c.discrepancies = append(c.discrepancies, models.LedgerDiscrepancy{
+				Currency: currency,
+				Problem:  "summed balance does not match the latest balance_after",
+				Expected: c.balances[currency],
+				Actual:   summed[currency],
+			})
+		}
+	}
+}
+
+func (c *ledgerChecker) report(t *models.Transaction, problem string, expected, actual int64) {
+	c.discrepancies = append(c.discrepancies, models.LedgerDiscrepancy{
+		TransactionID: t.ID,
+		Currency:      t.Currency,
+		Problem:       problem,
+		Expected:      expected,
+		Actual:        actual,
+	})
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+	"wallet-ledger/models"
+)
+
+// winBackInactivity is how long a user must have been inactive to see win-back offers
+const winBackInactivity = 30 * 24 * time.Hour
+
+// ListUserPackages retrieves the packages a user can buy right now: every
+// regular package plus the offers the user is eligible for
+func (s *WalletService) ListUserPackages(userID int) ([]models.Package, error) {
+	user, err := s.repo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.repo.BeginTx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	history, err := s.purchaseHistory(tx, user, now)
+	if err != nil {
+		return nil, err
+	}
+
+	packages := make([]models.Package, 0, len(models.Packages))
+	for _, pkg := range models.Packages {
+		if checkOfferEligibility(pkg, user, history, now) == nil {
+			packages = append(packages, pkg)
+		}
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		if packages[i].GoldCoins != packages[j].GoldCoins {
+			return packages[i].GoldCoins < packages[j].GoldCoins
+		}
+		return packages[i].Code < packages[j].Code
+	})
+	return packages, nil
+}
+
+// SetUserVIPTier changes the VIP tier that VIP offers are targeted by
+func (s *WalletService) SetUserVIPTier(userID int, tier int) error {
+	if tier < 0 {
+		return fmt.Errorf("vip_tier cannot be negative: %w", ErrInvalidInput)
+	}
+	return s.repo.SetUserVIPTier(userID, tier)
+}
+
+// purchaseHistory loads what offer eligibility depends on, with "today"
+// counted in the user's time zone
+func (s *WalletService) purchaseHistory(tx *sql.Tx, user *models.User, now time.Time) (*models.PurchaseHistory, error) {
+	loc, err := time.LoadLocation(user.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("user %d has invalid time zone %q: %w", user.ID, user.TimeZone, err)
+	}
+	return s.repo.GetPurchaseHistoryTx(tx, user.ID, dayStart(now, loc))
+}
+
+// checkOfferEligibility returns nil if the user may buy pkg at time now. A user
+// with no transactions counts as active since sign-up.
+func checkOfferEligibility(pkg models.Package, user *models.User, history *models.PurchaseHistory, now time.Time) error {
+	switch pkg.Offer {
+	case "":
+		return nil
+
+	case models.OfferFirstPurchase:
+		if history.HasPurchased {
+			return fmt.Errorf("%w: %s is for first purchases only", ErrOfferNotEligible, pkg.Code)
+		}
+
+	case models.OfferDaily:
+		for _, code := range history.BoughtToday {
+			if code == pkg.Code {
+				return fmt.Errorf("%w: %s was already bought today", ErrOfferNotEligible, pkg.Code)
+			}
+		}
+
+	case models.OfferWinBack:
+		lastActivity := user.CreatedAt
+		if history.LastActivityAt != nil && history.LastActivityAt.After(lastActivity) {
+			lastActivity = *history.LastActivityAt
+		}
+		if now.Sub(lastActivity) < winBackInactivity {
+			return fmt.Errorf("%w: %s is for players inactive for 30 days", ErrOfferNotEligible, pkg.Code)
+		}
+
+	case models.OfferVIP:
+		if user.VIPTier < pkg.MinVIPTier {
+			return fmt.Errorf("%w: %s requires VIP tier %d", ErrOfferNotEligible, pkg.Code, pkg.MinVIPTier)
+		}
+
+	default:
+		return fmt.Errorf("%w: %s has unknown offer %q", ErrOfferNotEligible, pkg.Code, pkg.Offer)
+	}
+	return nil
+}
+
+// dayStart returns midnight of now's calendar day in loc
+func dayStart(now time.Time, loc *time.Location) time.Time {
+	y, m, d := now.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,346 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"wallet-ledger/models"
+)
+
+// MaxPostingBatchSize caps the number of legs accepted in one batch posting
+const MaxPostingBatchSize = 1000
+
+// Post applies a list of legs to a user's balances atomically under one
+// idempotency key. Legs are written in order and each debit is checked against
+// the balance left by the legs before it. Reserved transaction types belong to
+// their own flows (purchases, wagers, redemptions, bonuses, ...) and are
+// rejected here, and the credits of a posting are capped by the adjustment
+// approval threshold.
+func (s *WalletService) Post(userID int, legs []models.PostingLeg, idempotencyKey string) ([]*models.Transaction, error) {
+	// Serialize all operations for this user
+	s.lockUser(userID)
+	defer s.unlockUser(userID)
+
+	if err := s.validateLegs(legs, false); err != nil {
+		return nil, err
+	}
+
+	// Verify user exists
+	_, err := s.repo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transaction
+	tx, err := s.repo.BeginTx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Check idempotency
+	existing, err := s.replayIdempotencyKey(tx, idempotencyKey, userID)
+	if err != nil || existing != nil {
+		return existing, err
+	}
+
+	if err := s.checkPostingThreshold(legs); err != nil {
+		return nil, err
+	}
+
+	transactions, txIDs, err := s.postLegs(tx, userID, legs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save idempotency key with all transaction IDs
+	err = s.repo.SaveIdempotencyKey(tx, idempotencyKey, userID, txIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// PostBatch applies legs for many users atomically under one batch key, for
+// tournament payouts, referral rewards and bulk corrections. Every involved user
+// is locked in ascending ID order, so concurrent batches and single-user
+// operations cannot deadlock, and all legs are written in request order in one
+// DB transaction: if any leg fails, nothing is posted. Legs follow the same
+// rules as Post, and the credits are capped across the whole batch.
+func (s *WalletService) PostBatch(legs []models.BatchPostingLeg, batchKey string) ([]*models.Transaction, error) {
+	if batchKey == "" {
+		return nil, fmt.Errorf("batch key is required: %w", ErrInvalidInput)
+	}
+	if len(legs) > MaxPostingBatchSize {
+		return nil, fmt.Errorf("batch cannot contain more than %d legs: %w", MaxPostingBatchSize, ErrInvalidInput)
+	}
+
+	postingLegs, userIDs := splitBatchLegs(legs)
+	if err := s.validateLegs(postingLegs, false); err != nil {
+		return nil, err
+	}
+
+	// Serialize with all other operations of every involved user
+	unlock := s.lockUsers(userIDs)
+	defer unlock()
+
+	// Verify users exist
+	for _, userID := range userIDs {
+		if _, err := s.repo.GetUser(userID); err != nil {
+			return nil, fmt.Errorf("user %d: %w", userID, err)
+		}
+	}
+
+	// Start transaction
+	tx, err := s.repo.BeginTx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Check idempotency
+	digest, err := batchDigest(legs)
+	if err != nil {
+		return nil, err
+	}
+	existingTxIDs, existingDigest, err := s.repo.LockPostingBatchTx(tx, batchKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(existingTxIDs) > 0 {
+		if existingDigest != "" && existingDigest != digest {
+			return nil, fmt.Errorf("%w: %s", ErrBatchKeyReused, batchKey)
+		}
+		transactions, err := s.repo.GetTransactionsTx(tx, existingTxIDs)
+		if err != nil {
+			return nil, err
+		}
+		tx.Commit()
+		return transactions, nil
+	}
+
+	// The threshold applies to the batch as a whole, so it cannot be dodged by
+	// spreading a credit over many legs or users
+	if err := s.checkPostingThreshold(postingLegs); err != nil {
+		return nil, err
+	}
+
+	// Balances are read inside tx, so each leg sees the legs before it
+	transactions := make([]*models.Transaction, 0, len(legs))
+	txIDs := make([]int, 0, len(legs))
+	for i, leg := range legs {
+		posted, ids, err := s.postLegs(tx, leg.UserID, postingLegs[i:i+1])
+		if err != nil {
+			return nil, fmt.Errorf("leg %d (user %d): %w", i, leg.UserID, err)
+		}
+		transactions = append(transactions, posted...)
+		txIDs = append(txIDs, ids...)
+	}
+
+	// Save the batch key with all transaction IDs
+	err = s.repo.SavePostingBatch(tx, batchKey, userIDs, txIDs, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// splitBatchLegs returns the posting legs of a batch and its users in
+// ascending ID order, the order they are locked in
+func splitBatchLegs(legs []models.BatchPostingLeg) ([]models.PostingLeg, []int) {
+	postingLegs := make([]models.PostingLeg, len(legs))
+	var userIDs []int
+	seen := make(map[int]bool)
+	for i, leg := range legs {
+		postingLegs[i] = leg.PostingLeg
+		if !seen[leg.UserID] {
+			seen[leg.UserID] = true
+			userIDs = append(userIDs, leg.UserID)
+		}
+	}
+	sort.Ints(userIDs)
+	return postingLegs, userIDs
+}
+
+// batchDigest identifies the legs of a batch posting, so a reused batch key can
+// be told apart from a retry of the same batch. Metadata is compacted first,
+// so whitespace does not matter.
+func batchDigest(legs []models.BatchPostingLeg) (string, error) {
+	payload, err := json.Marshal(legs)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// validateLegs checks legs before any repository access. Reserved types are
+// only accepted from the flows that own them.
+func (s *WalletService) validateLegs(legs []models.PostingLeg, allowReserved bool) error {
+	if len(legs) == 0 {
+		return fmt.Errorf("at least one leg is required: %w", ErrInvalidInput)
+	}
+
+	registry := s.currencyRegistry()
+	for i, leg := range legs {
+		rule, ok := leg.Type.Rule()
+		if !ok {
+			return fmt.Errorf("leg %d: unknown transaction type %q: %w", i, leg.Type, ErrInvalidInput)
+		}
+		if rule.Reserved && !allowReserved {
+			return fmt.Errorf("leg %d: transaction type %s cannot be posted directly: %w", i, leg.Type, ErrInvalidInput)
+		}
+		if _, ok := registry.byCode[leg.Currency]; !ok {
+			return fmt.Errorf("leg %d: unknown currency %q: %w", i, leg.Currency, ErrInvalidInput)
+		}
+		if !rule.AllowsCurrency(leg.Currency) {
+			return fmt.Errorf("leg %d: %s cannot be posted in %s: %w", i, leg.Type, leg.Currency, ErrInvalidInput)
+		}
+		if leg.Amount == 0 || (leg.Amount < 0 && !rule.Signed) {
+			return fmt.Errorf("leg %d: amount must be positive: %w", i, ErrInvalidInput)
+		}
+		if len(leg.Metadata) > 0 && !bytes.HasPrefix(bytes.TrimSpace(leg.Metadata), []byte("{")) {
+			return fmt.Errorf("leg %d: metadata must be a JSON object: %w", i, ErrInvalidInput)
+		}
+	}
+	return nil
+}
+
+// checkPostingThreshold refuses legs that credit more of a currency, in total,
+// than an adjustment may credit without a second admin's approval. A generic
+// posting cannot be held for approval, so larger credits must be made as
+// adjustments.
+func (s *WalletService) checkPostingThreshold(legs []models.PostingLeg) error {
+	currencies, credited := postingCredits(legs)
+	for _, currency := range currencies {
+		t, err := s.approvalThreshold(models.ApprovalOperationAdjustment, currency, credited[currency])
+		if err != nil {
+			return err
+		}
+		if t != nil {
+			scale := currency.MinorUnits()
+			return fmt.Errorf("%w: credits %s %s, above the %s %s threshold; post it as an adjustment instead",
+				ErrPostingOverThreshold, models.FormatAmount(credited[currency], scale), currency,
+				models.FormatAmount(t.Threshold, scale), currency)
+		}
+	}
+	return nil
+}
+
+// postingCredits totals the credit legs per currency, in the order the
+// currencies first appear. A total too large for int64 saturates.
+func postingCredits(legs []models.PostingLeg) ([]models.Currency, map[models.Currency]int64) {
+	credited := make(map[models.Currency]int64)
+	var currencies []models.Currency
+	for _, leg := range legs {
+		amount := leg.Type.SignedAmount(leg.Amount)
+		if amount <= 0 {
+			continue
+		}
+		total, ok := credited[leg.Currency]
+		if !ok {
+			currencies = append(currencies, leg.Currency)
+		}
+		if total > math.MaxInt64-amount {
+			credited[leg.Currency] = math.MaxInt64
+		} else {
+			credited[leg.Currency] = total + amount
+		}
+	}
+	return currencies, credited
+}
+
+// postLegs writes legs in order within tx, keeping a running balance per
+// currency so that every debit is checked against the balance at its position.
+// Nothing is written unless the caller commits. The caller must hold the user lock.
+func (s *WalletService) postLegs(tx *sql.Tx, userID int, legs []models.PostingLeg) ([]*models.Transaction, []int, error) {
+	balances := make(map[models.Currency]int64)
+	transactions := make([]*models.Transaction, 0, len(legs))
+	txIDs := make([]int, 0, len(legs))
+
+	for _, leg := range legs {
+		balance, ok := balances[leg.Currency]
+		if !ok {
+			var err error
+			balance, err = s.repo.GetCurrentBalance(tx, userID, leg.Currency)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		after := leg.Type.Apply(balance, leg.Amount)
+		if after < 0 {
+			return nil, nil, s.insufficientFunds(leg.Currency, balance, -leg.Type.SignedAmount(leg.Amount))
+		}
+
+		t := &models.Transaction{
+			UserID:       userID,
+			Currency:     leg.Currency,
+			Type:         leg.Type,
+			Amount:       leg.Amount,
+			BalanceAfter: after,
+			Metadata:     leg.Metadata,
+		}
+		if err := s.repo.CreateTransaction(tx, t); err != nil {
+			return nil, nil, err
+		}
+		balances[leg.Currency] = after
+		transactions = append(transactions, t)
+		txIDs = append(txIDs, t.ID)
+	}
+
+	return transactions, txIDs, nil
+}
+
+// insufficientFunds describes a debit the balance cannot cover, in the
+// currency's registered name and decimal scale
+func (s *WalletService) insufficientFunds(currency models.Currency, have, need int64) error {
+	name := string(currency)
+	if c, ok := s.currencyRegistry().byCode[currency]; ok {
+		name = strings.ToLower(c.Name)
+	}
+	scale := currency.MinorUnits()
+	return fmt.Errorf("%w: %s - have %s, need %s", ErrInsufficientFunds, name,
+		models.FormatAmount(have, scale), models.FormatAmount(need, scale))
+}
+
+// replayIdempotencyKey returns the transactions already recorded under key, or
+// nil if it is unused. On a replay the read-only tx is committed. A key
+// recorded without transactions is a wager rolled back before it arrived.
+func (s *WalletService) replayIdempotencyKey(tx *sql.Tx, key string, userID int) ([]*models.Transaction, error) {
+	existingTxIDs, err := s.repo.CheckIdempotencyKey(tx, key, userID)
+	if err != nil {
+		return nil, err
+	}
+	if existingTxIDs != nil && len(existingTxIDs) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrWagerRolledBack, key)
+	}
+	if len(existingTxIDs) == 0 {
+		return nil, nil
+	}
+
+	transactions, err := s.repo.GetTransactionsTx(tx, existingTxIDs)
+	if err != nil {
+		return nil, err
+	}
+	tx.Commit()
+	return transactions, nil
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"wallet-ledger/models"
+)
+
+// ListPromoCodes retrieves all promo codes with their redemption counts
+func (s *WalletService) ListPromoCodes() ([]models.PromoCode, error) {
+	return s.repo.ListPromoCodes()
+}
+
+// GetPromoCode retrieves a single promo code
+func (s *WalletService) GetPromoCode(code string) (*models.PromoCode, error) {
+	return s.repo.GetPromoCode(normalizePromoCode(code))
+}
+
+// SavePromoCode creates or reconfigures a promo code. Codes are matched
+// case-insensitively and stored upper case.
+func (s *WalletService) SavePromoCode(promo *models.PromoCode) error {
+	promo.Code = normalizePromoCode(promo.Code)
+	if err := validatePromoCode(promo); err != nil {
+		return err
+	}
+	return s.repo.SavePromoCode(promo)
+}
+
+// lockPromoCode locks a promo code quoted with a purchase until tx ends and
+// checks that the user may redeem it on the package. Holding the row lock
+// keeps concurrent purchases by different users within max_redemptions; the
+// caller must hold the user lock.
+func (s *WalletService) lockPromoCode(tx *sql.Tx, code string, userID int, packageCode string) (*models.PromoCode, error) {
+	promo, err := s.repo.LockPromoCodeTx(tx, code)
+	if errors.Is(err, ErrPromoCodeNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrPromoCodeInvalid, code)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkPromoCode(promo, packageCode, time.Now()); err != nil {
+		return nil, err
+	}
+
+	redeemed, err := s.repo.HasRedeemedPromoCodeTx(tx, promo.Code, userID)
+	if err != nil {
+		return nil, err
+	}
+	if redeemed {
+		return nil, fmt.Errorf("%w: %s", ErrPromoCodeAlreadyUsed, promo.Code)
+	}
+	return promo, nil
+}
+
+// createPromoTransactions posts a promo code's extra coins as promo_gc /
+// promo_sc rows linked to the purchase rows, and records the redemption
+func (s *WalletService) createPromoTransactions(tx *sql.Tx, promo *models.PromoCode, userID int, pkg models.Package, purchaseTxIDs []int) ([]*models.Transaction, []int, error) {
+	bonusGC, bonusSC := promo.Bonus(pkg)
+
+	metadataJSON, _ := json.Marshal(map[string]interface{}{
+		"promo_code":               promo.Code,
+		"package_code":             pkg.Code,
+		"purchase_transaction_ids": purchaseTxIDs,
+	})
+
+	var transactions []*models.Transaction
+	var txIDs []int
+
+	credit := func(currency models.Currency, txType models.TransactionType, amount int64) error {
+		if amount == 0 {
+			return nil
+		}
+
+		balance, err := s.repo.GetCurrentBalance(tx, userID, currency)
+		if err != nil {
+			return err
+		}
+
+		promoTx := &models.Transaction{
+			UserID:       userID,
+			Currency:     currency,
+			Type:         txType,
+			Amount:       amount,
+			BalanceAfter: txType.Apply(balance, amount),
+			Metadata:     metadataJSON,
+		}
+		if err := s.repo.CreateTransaction(tx, promoTx); err != nil {
+			return err
+		}
+		transactions = append(transactions, promoTx)
+		txIDs = append(txIDs, promoTx.ID)
+		return nil
+	}
+
+	if err := credit(models.CurrencyGC, models.TransactionTypePromoGC, bonusGC); err != nil {
+		return nil, nil, err
+	}
+	if err := credit(models.CurrencySC, models.TransactionTypePromoSC, bonusSC); err != nil {
+		return nil, nil, err
+	}
+
+	allTxIDs := append(append([]int{}, purchaseTxIDs...), txIDs...)
+	if err := s.repo.RecordPromoRedemption(tx, promo.Code, userID, pkg.Code, allTxIDs); err != nil {
+		return nil, nil, err
+	}
+
+	return transactions, txIDs, nil
+}
+
+// checkPromoCode checks a promo code's window, redemption limit and package
+// restriction at time now
+func checkPromoCode(p *models.PromoCode, packageCode string, now time.Time) error {
+	if !p.Enabled {
+		return fmt.Errorf("%w: %s is disabled", ErrPromoCodeInvalid, p.Code)
+	}
+	if p.StartsAt != nil && now.Before(*p.StartsAt) {
+		return fmt.Errorf("%w: %s is not valid until %s", ErrPromoCodeInvalid, p.Code, p.StartsAt.Format(time.RFC3339))
+	}
+	if p.EndsAt != nil && !now.Before(*p.EndsAt) {
+		return fmt.Errorf("%w: %s ended %s", ErrPromoCodeExpired, p.Code, p.EndsAt.Format(time.RFC3339))
+	}
+	if p.MaxRedemptions > 0 && p.Redemptions >= p.MaxRedemptions {
+		return fmt.Errorf("%w: %s", ErrPromoCodeExhausted, p.Code)
+	}
+	if !p.AppliesTo(packageCode) {
+		return fmt.Errorf("%w: %s cannot be used with %s", ErrPromoCodeNotApplicable, p.Code, packageCode)
+	}
+	return nil
+}
+
+// normalizePromoCode canonicalizes a promo code as typed by a player
+func normalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// validatePromoCode checks a promo code definition before it is saved
+func validatePromoCode(p *models.PromoCode) error {
+	if p.Code == "" || len(p.Code) > 64 {
+		return fmt.Errorf("code must be 1-64 characters: %w", ErrInvalidInput)
+	}
+	if p.Kind != models.PromoKindFixed && p.Kind != models.PromoKindPercent {
+		return fmt.Errorf("kind must be fixed or percent: %w", ErrInvalidInput)
+	}
+	if p.ValueGC < 0 || p.ValueSC < 0 {
+		return fmt.Errorf("values cannot be negative: %w", ErrInvalidInput)
+	}
+	if p.ValueGC == 0 && p.ValueSC == 0 {
+		return fmt.Errorf("at least one value must be greater than zero: %w", ErrInvalidInput)
+	}
+	if p.MaxRedemptions < 0 {
+		return fmt.Errorf("max_redemptions cannot be negative: %w", ErrInvalidInput)
+	}
+	for _, code := range p.PackageCodes {
+		if _, ok := models.Packages[code]; !ok {
+			return fmt.Errorf("%w: %s", ErrInvalidPackage, code)
+		}
+	}
+	if p.StartsAt != nil && p.EndsAt != nil && !p.EndsAt.After(*p.StartsAt) {
+		return fmt.Errorf("ends_at must be after starts_at: %w", ErrInvalidInput)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+	"wallet-ledger/models"
+)
+
+// GetSCExpirySettings retrieves the SC expiry thresholds
+func (s *WalletService) GetSCExpirySettings() (*models.SCExpirySettings, error) {
+	return s.repo.GetSCExpirySettings()
+}
+
+// SaveSCExpirySettings updates the SC expiry thresholds
+func (s *WalletService) SaveSCExpirySettings(settings *models.SCExpirySettings) error {
+	if settings.InactivityDays <= 0 {
+		return fmt.Errorf("inactivity_days must be greater than zero: %w", ErrInvalidInput)
+	}
+	if settings.WarningDays < 0 || settings.WarningDays >= settings.InactivityDays {
+		return fmt.Errorf("warning_days must be between 0 and inactivity_days: %w", ErrInvalidInput)
+	}
+	return s.repo.SaveSCExpirySettings(settings)
+}
+
+// RunSCExpiry runs the SC expiry job for the UTC day of now. It warns users
+// whose SC is about to expire and expires the SC of warned users once the
+// announced time has passed. Each day has a single run: once it has finished,
+// further calls that day return it unchanged, and an unfinished run is resumed
+// without warning or expiring anyone twice.
+func (s *WalletService) RunSCExpiry(now time.Time) (*models.SCExpiryRun, error) {
+	s.scExpiryMu.Lock()
+	defer s.scExpiryMu.Unlock()
+
+	settings, err := s.repo.GetSCExpirySettings()
+	if err != nil {
+		return nil, err
+	}
+
+	runDate := now.UTC().Truncate(24 * time.Hour)
+	run, err := s.repo.StartSCExpiryRun(runDate, settings.InactivityDays, settings.ExpiryCutoff(now))
+	if err != nil {
+		return nil, err
+	}
+	if run.FinishedAt != nil {
+		return run, nil
+	}
+
+	balances, err := s.repo.ListInactiveSCBalances(settings.WarningCutoff(now))
+	if err != nil {
+		return nil, err
+	}
+
+	failed := 0
+	warnings, expire := planSCExpiry(balances, settings, now)
+	for _, warning := range warnings {
+		warning.RunID = run.ID
+		if _, err := s.repo.CreateSCExpiryWarning(warning); err != nil {
+			log.Printf("Error warning user %d of SC expiry: %v", warning.UserID, err)
+			failed++
+		}
+	}
+	for _, b := range expire {
+		if err := s.expireSC(run, b); err != nil {
+			log.Printf("Error expiring SC of user %d: %v", b.UserID, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		// Left unfinished so the next attempt today retries the failed users
+		return run, fmt.Errorf("sc expiry run %d: %d users failed", run.ID, failed)
+	}
+	return s.repo.FinishSCExpiryRun(run.ID)
+}
+
+// expireSC zeroes an inactive user's SC balance with an expire_sc transaction,
+// unless the user has had any transaction since the balance was listed
+func (s *WalletService) expireSC(run *models.SCExpiryRun, inactive models.InactiveSCBalance) error {
+	userID := inactive.UserID
+
+	// Serialize all operations for this user
+	s.lockUser(userID)
+	defer s.unlockUser(userID)
+
+	// Start transaction
+	tx, err := s.repo.BeginTx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Re-check under the lock: the user may have played since the balances were listed
+	lastID, _, err := s.repo.GetLastTransactionTx(tx, userID)
+	if err != nil {
+		return err
+	}
+	if lastID != inactive.LastTransactionID {
+		return nil
+	}
+
+	balance, err := s.repo.GetCurrentBalance(tx, userID, models.CurrencySC)
+	if err != nil {
+		return err
+	}
+	if balance <= 0 {
+		return nil
+	}
+
+	metadata, _ := json.Marshal(map[string]interface{}{
+		"sc_expiry_run_id": run.ID,
+		"last_activity_at": inactive.LastActivityAt,
+		"inactivity_days":  run.InactivityDays,
+	})
+	expireTx := &models.Transaction{
+		UserID:       userID,
+		Currency:     models.CurrencySC,
+		Type:         models.TransactionTypeExpireSC,
+		Amount:       balance,
+		BalanceAfter: models.TransactionTypeExpireSC.Apply(balance, balance),
+		Metadata:     metadata,
+	}
+	if err := s.repo.CreateTransaction(tx, expireTx); err != nil {
+		return err
+	}
+
+	expiration := &models.SCExpiration{
+		RunID:          run.ID,
+		UserID:         userID,
+		AmountSC:       balance,
+		LastActivityAt: inactive.LastActivityAt,
+		TransactionID:  expireTx.ID,
+	}
+	if err := s.repo.CreateSCExpirationTx(tx, expiration); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit()
+}
+
+// ListSCExpiryRuns retrieves the most recent SC expiry runs
+func (s *WalletService) ListSCExpiryRuns(limit int) ([]models.SCExpiryRun, error) {
+	return s.repo.ListSCExpiryRuns(limit)
+}
+
+// GetSCExpiryRun retrieves an SC expiry run with every balance it expired
+func (s *WalletService) GetSCExpiryRun(runID int) (*models.SCExpiryRun, error) {
+	return s.repo.GetSCExpiryRun(runID)
+}
+
+// planSCExpiry decides which inactive balances to warn about and which to
+// expire at now. SC expires after the inactivity threshold, but never without
+// a warning at least warning_days beforehand: users found past the threshold
+// without one are warned first and expire warning_days later.
+func planSCExpiry(balances []models.InactiveSCBalance, settings *models.SCExpirySettings, now time.Time) (warn []*models.SCExpiryWarning, expire []models.InactiveSCBalance) {
+	for _, b := range balances {
+		expiresAt := b.LastActivityAt.AddDate(0, 0, settings.InactivityDays)
+
+		if b.WarnedExpiresAt == nil && settings.WarningDays > 0 {
+			if earliest := now.AddDate(0, 0, settings.WarningDays); earliest.After(expiresAt) {
+				expiresAt = earliest
+			}
+			warn = append(warn, &models.SCExpiryWarning{
+				UserID:            b.UserID,
+				LastTransactionID: b.LastTransactionID,
+				LastActivityAt:    b.LastActivityAt,
+				ExpiresAt:         expiresAt,
+				BalanceSC:         b.BalanceSC,
+			})
+			continue
+		}
+
+		if b.WarnedExpiresAt != nil {
+			expiresAt = *b.WarnedExpiresAt
+		}
+		if !now.Before(expiresAt) {
+			expire = append(expire, b)
+		}
+	}
+	return warn, expire
+}
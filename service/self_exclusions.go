@@ -0,0 +1,118 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+	"wallet-ledger/models"
+)
+
+// selfExclusionExpiryActor is recorded in the audit trail for exclusions
+// expired by the background job
+const selfExclusionExpiryActor = "system"
+
+// SelfExclude excludes a user from purchasing and wagering for period. An
+// exclusion cannot be lifted early; a new one is only accepted if it outlasts
+// the exclusion currently in force.
+func (s *WalletService) SelfExclude(userID int, period models.SelfExclusionPeriod) (*models.SelfExclusion, error) {
+	if !period.IsValid() {
+		return nil, fmt.Errorf("period must be 24h, 7d, 6m or permanent: %w", ErrInvalidInput)
+	}
+
+	// Serialize all operations for this user
+	s.lockUser(userID)
+	defer s.unlockUser(userID)
+
+	// Verify user exists
+	_, err := s.repo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transaction
+	tx, err := s.repo.BeginTx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	exclusion := &models.SelfExclusion{
+		UserID:   userID,
+		Period:   period,
+		StartsAt: now,
+		EndsAt:   period.EndsAt(now),
+	}
+
+	current, err := s.repo.GetActiveSelfExclusionTx(tx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && !current.OutlastedBy(exclusion) {
+		return nil, ErrSelfExclusionActive
+	}
+
+	if err := s.repo.CreateSelfExclusion(tx, exclusion, fmt.Sprintf("user:%d", userID)); err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return exclusion, nil
+}
+
+// GetSelfExclusionStatus retrieves whether a user is excluded, along with their
+// exclusion history and audit trail
+func (s *WalletService) GetSelfExclusionStatus(userID int) (*models.SelfExclusionStatus, error) {
+	// Verify user exists
+	_, err := s.repo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.repo.ListSelfExclusions(userID)
+	if err != nil {
+		return nil, err
+	}
+	audit, err := s.repo.ListSelfExclusionAudit(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &models.SelfExclusionStatus{History: history, Audit: audit}
+	now := time.Now()
+	for i := range history {
+		e := &history[i]
+		if e.IsActive(now) && (status.Current == nil || status.Current.OutlastedBy(e)) {
+			status.Current = e
+		}
+	}
+	status.Excluded = status.Current != nil
+
+	return status, nil
+}
+
+// ExpireSelfExclusions marks every exclusion that has run its course as expired
+// and returns how many were expired. Enforcement does not depend on it; it
+// keeps the exclusion records and audit trail up to date.
+func (s *WalletService) ExpireSelfExclusions() (int, error) {
+	return s.repo.ExpireSelfExclusions(time.Now(), selfExclusionExpiryActor)
+}
+
+// checkSelfExclusion rejects the operation if the user is self-excluded at now
+func (s *WalletService) checkSelfExclusion(tx *sql.Tx, userID int, now time.Time) error {
+	exclusion, err := s.repo.GetActiveSelfExclusionTx(tx, userID, now)
+	if err != nil {
+		return err
+	}
+	if exclusion == nil {
+		return nil
+	}
+	if exclusion.EndsAt == nil {
+		return fmt.Errorf("%w permanently", ErrSelfExcluded)
+	}
+	return fmt.Errorf("%w until %s", ErrSelfExcluded, exclusion.EndsAt.UTC().Format(time.RFC3339))
+}
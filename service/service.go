@@ -1,16 +1,22 @@
 package service
 
 import (
+	"database/sql"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 	"wallet-ledger/models"
 	"wallet-ledger/repository"
 )
 
 type WalletService struct {
-	repo      *repository.Repository
-	userLocks sync.Map // map[int]*sync.Mutex - per-user locks
+	repo       *repository.Repository
+	userLocks  sync.Map   // map[int]*sync.Mutex - per-user locks
+	scExpiryMu sync.Mutex // serializes SC expiry runs
+	currencies atomic.Pointer[currencyRegistry]
 }
 
 func New(repo *repository.Repository) *WalletService {
@@ -36,9 +42,45 @@ func (s *WalletService) unlockUser(userID int) {
 	s.getUserLock(userID).Unlock()
 }
 
+// lockUsers acquires the locks of several users in ascending ID order, so that
+// concurrent multi-user operations cannot deadlock, and returns a func releasing them
+func (s *WalletService) lockUsers(userIDs []int) func() {
+	ids := append([]int(nil), userIDs...)
+	sort.Ints(ids)
+
+	var locked []int
+	for i, id := range ids {
+		if i > 0 && id == ids[i-1] {
+			continue
+		}
+		s.lockUser(id)
+		locked = append(locked, id)
+	}
+
+	return func() {
+		for i := len(locked) - 1; i >= 0; i-- {
+			s.unlockUser(locked[i])
+		}
+	}
+}
+
 // GetUserWithBalances retrieves a user with balances and stats
 func (s *WalletService) GetUserWithBalances(userID int) (*models.UserWithBalances, error) {
-	return s.repo.GetUserWithBalances(userID)
+	user, err := s.repo.GetUserWithBalances(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Report every registered currency, including those never transacted in
+	for _, c := range s.ListCurrencies() {
+		if _, ok := user.Balances[c.Code]; !ok {
+			user.Balances[c.Code] = 0
+		}
+		if _, ok := user.Stats[c.Code]; !ok {
+			user.Stats[c.Code] = models.CurrencyStats{}
+		}
+	}
+	return user, nil
 }
 
 // ListTransactions retrieves paginated transactions
@@ -52,8 +94,19 @@ func (s *WalletService) ListTransactions(userID int, cursor *string, limit int,
 	return s.repo.ListTransactions(userID, cursor, limit, txType, currency)
 }
 
-// Purchase handles purchasing a package with idempotency
-func (s *WalletService) Purchase(userID int, packageCode string, idempotencyKey string) ([]*models.Transaction, error) {
+// ListTransactionsAfter retrieves transactions committed after the given ID, oldest first
+func (s *WalletService) ListTransactionsAfter(userID int, afterID int, limit int) ([]models.Transaction, error) {
+	return s.repo.ListTransactionsAfter(userID, afterID, limit)
+}
+
+// LatestTransactionID returns the ID of the user's latest transaction, or 0
+func (s *WalletService) LatestTransactionID(userID int) (int, error) {
+	return s.repo.GetLatestTransactionID(userID)
+}
+
+// Purchase handles purchasing a package with idempotency. An optional promo
+// code adds its extra coins as separate promo rows.
+func (s *WalletService) Purchase(userID int, packageCode string, promoCode string, idempotencyKey string) ([]*models.Transaction, error) {
 	// Serialize all operations for this user
 	s.lockUser(userID)
 	defer s.unlockUser(userID)
@@ -64,14 +117,15 @@ func (s *WalletService) Purchase(userID int, packageCode string, idempotencyKey
 		return nil, fmt.Errorf("%w: %s", ErrInvalidPackage, packageCode)
 	}
 
-	// Verify package has gold coins (sweepstakes casino requirement)
-	// SC can only be obtained as bonus with GC purchase, not standalone
-	if pkg.GoldCoins == 0 {
-		return nil, fmt.Errorf("%w: package must include gold coins", ErrInvalidPackage)
+	// A package is bought for a purchasable currency (sweepstakes casino
+	// requirement); SC can only be obtained as a bonus with it, not standalone
+	legs, err := s.purchaseLegs(pkg)
+	if err != nil {
+		return nil, err
 	}
 
 	// Verify user exists
-	_, err := s.repo.GetUser(userID)
+	user, err := s.repo.GetUser(userID)
 	if err != nil {
 		return nil, err
 	}
@@ -84,78 +138,74 @@ func (s *WalletService) Purchase(userID int, packageCode string, idempotencyKey
 	defer tx.Rollback()
 
 	// Check idempotency
-	existingTxIDs, err := s.repo.CheckIdempotencyKey(tx, idempotencyKey, userID)
-	if err != nil {
+	existing, err := s.replayIdempotencyKey(tx, idempotencyKey, userID)
+	if err != nil || existing != nil {
+		return existing, err
+	}
+
+	now := time.Now()
+
+	// Self-excluded players cannot buy
+	if err := s.checkSelfExclusion(tx, userID, now); err != nil {
 		return nil, err
 	}
-	if len(existingTxIDs) > 0 {
-		// Already processed, return existing transactions
-		tx.Commit()
-		result := make([]*models.Transaction, 0, len(existingTxIDs))
-		for _, txID := range existingTxIDs {
-			transaction, err := s.repo.GetTransaction(txID)
-			if err != nil {
-				return nil, err
-			}
-			result = append(result, transaction)
+
+	// Offers are re-checked here, not just filtered from the user's package list
+	if pkg.Offer != "" {
+		history, err := s.purchaseHistory(tx, user, now)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkOfferEligibility(pkg, user, history, now); err != nil {
+			return nil, err
 		}
-		return result, nil
 	}
 
-	// Create GC transaction
-	gcBalance, err := s.repo.GetCurrentBalance(tx, userID, models.CurrencyGC)
-	if err != nil {
+	// Responsible-gaming spend limits
+	if err := s.checkSpendLimits(tx, userID, pkg.PriceCents, now); err != nil {
 		return nil, err
 	}
 
+	// Check the promo code before anything is posted
+	var promo *models.PromoCode
+	if promoCode != "" {
+		promo, err = s.lockPromoCode(tx, normalizePromoCode(promoCode), userID, packageCode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	metadata := map[string]interface{}{
 		"package_code": packageCode,
 		"gc_amount":    pkg.GoldCoins,
 		"sc_amount":    pkg.SweepCoins,
+		"price_cents":  pkg.PriceCents,
+	}
+	if len(pkg.Coins) > 0 {
+		metadata["coins"] = pkg.Coins
+	}
+	if promo != nil {
+		metadata["promo_code"] = promo.Code
 	}
 	metadataJSON, _ := json.Marshal(metadata)
-
-	gcTx := &models.Transaction{
-		UserID:       userID,
-		Currency:     models.CurrencyGC,
-		Type:         models.TransactionTypePurchase,
-		Amount:       pkg.GoldCoins,
-		BalanceAfter: gcBalance + pkg.GoldCoins,
-		Metadata:     metadataJSON,
+	for i := range legs {
+		legs[i].Metadata = metadataJSON
 	}
 
-	err = s.repo.CreateTransaction(tx, gcTx)
+	// Track created transactions for idempotency and result
+	result, txIDs, err := s.postLegs(tx, userID, legs)
 	if err != nil {
 		return nil, err
 	}
 
-	// Track created transactions for idempotency and result
-	result := []*models.Transaction{gcTx}
-	txIDs := []int{gcTx.ID}
-
-	// Create SC transaction only if package includes sweep coins
-	if pkg.SweepCoins > 0 {
-		scBalance, err := s.repo.GetCurrentBalance(tx, userID, models.CurrencySC)
-		if err != nil {
-			return nil, err
-		}
-
-		scTx := &models.Transaction{
-			UserID:       userID,
-			Currency:     models.CurrencySC,
-			Type:         models.TransactionTypePurchase,
-			Amount:       pkg.SweepCoins,
-			BalanceAfter: scBalance + pkg.SweepCoins,
-			Metadata:     metadataJSON,
-		}
-
-		err = s.repo.CreateTransaction(tx, scTx)
+	// Post the promo code's extra coins, linked to the purchase rows
+	if promo != nil {
+		promoTxs, promoTxIDs, err := s.createPromoTransactions(tx, promo, userID, pkg, txIDs)
 		if err != nil {
 			return nil, err
 		}
-
-		result = append(result, scTx)
-		txIDs = append(txIDs, scTx.ID)
+		result = append(result, promoTxs...)
+		txIDs = append(txIDs, promoTxIDs...)
 	}
 
 	// Save idempotency key with all transaction IDs
@@ -172,18 +222,128 @@ func (s *WalletService) Purchase(userID int, packageCode string, idempotencyKey
 	return result, nil
 }
 
-// Wager handles a wager with stake and payout
-func (s *WalletService) Wager(userID int, stakeGC, payoutGC, stakeSC, payoutSC int64, idempotencyKey string) ([]*models.Transaction, error) {
+// purchaseLegs returns a purchase leg for every currency a package includes,
+// in registry order. At least one of them must be purchasable; the others are
+// only given away with it.
+func (s *WalletService) purchaseLegs(pkg models.Package) ([]models.PostingLeg, error) {
+	contents := pkg.Contents()
+	var legs []models.PostingLeg
+	purchasable := false
+	for _, c := range s.ListCurrencies() {
+		amount, ok := contents[c.Code]
+		if !ok {
+			continue
+		}
+		legs = append(legs, models.PostingLeg{Currency: c.Code, Type: models.TransactionTypePurchase, Amount: amount})
+		purchasable = purchasable || c.Purchasable
+	}
+	if len(legs) != len(contents) {
+		return nil, fmt.Errorf("%w: %s includes an unregistered currency", ErrInvalidPackage, pkg.Code)
+	}
+	if !purchasable {
+		return nil, fmt.Errorf("%w: %s includes no purchasable currency", ErrCurrencyNotAllowed, pkg.Code)
+	}
+	return legs, nil
+}
+
+// Wager handles a wager with a stake and payout per currency on a registered game
+func (s *WalletService) Wager(userID int, gameID, roundID string, legs []models.WagerLeg, idempotencyKey string) ([]*models.Transaction, error) {
+	return s.WagerWithMetadata(userID, gameID, roundID, legs, idempotencyKey, nil)
+}
+
+// WagerWithMetadata handles a wager and records extra metadata (e.g. provider
+// transaction IDs) on every row alongside the game, round and provider. A
+// TournamentMetadataKey entry also scores the wager in that tournament.
+func (s *WalletService) WagerWithMetadata(userID int, gameID, roundID string, legs []models.WagerLeg, idempotencyKey string, metadata map[string]interface{}) ([]*models.Transaction, error) {
 	// Serialize all operations for this user
 	s.lockUser(userID)
 	defer s.unlockUser(userID)
 
 	// Validate inputs
-	if stakeGC < 0 || payoutGC < 0 || stakeSC < 0 || payoutSC < 0 {
-		return nil, fmt.Errorf("amounts cannot be negative: %w", ErrInvalidInput)
+	if err := validateWager(legs); err != nil {
+		return nil, err
+	}
+	if gameID == "" {
+		return nil, fmt.Errorf("%w: game_id is required", ErrInvalidGame)
+	}
+	tournamentID, err := tournamentFromMetadata(metadata)
+	if err != nil {
+		return nil, err
 	}
-	if stakeGC == 0 && payoutGC == 0 && stakeSC == 0 && payoutSC == 0 {
-		return nil, fmt.Errorf("at least one amount must be greater than zero: %w", ErrInvalidInput)
+
+	// Verify user exists
+	_, err = s.repo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the game accepts this wager
+	metadataJSON, err := s.gameWagerMetadata(gameID, roundID, legs, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transaction
+	tx, err := s.repo.BeginTx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Check idempotency
+	existing, err := s.replayIdempotencyKey(tx, idempotencyKey, userID)
+	if err != nil || existing != nil {
+		return existing, err
+	}
+
+	transactions, txIDs, err := s.createWagerTransactions(tx, userID, legs, metadataJSON, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save idempotency key with all transaction IDs
+	err = s.repo.SaveIdempotencyKey(tx, idempotencyKey, userID, txIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fund progressive jackpots from the stakes last, right before commit
+	if err := s.contributeToJackpots(tx, transactions); err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// RefundWager reverses the stakes of a previously settled wager by crediting them
+// back as refund transactions. Payouts of the original wager are left untouched.
+func (s *WalletService) RefundWager(userID int, wagerIdempotencyKey string, idempotencyKey string, metadata map[string]interface{}) ([]*models.Transaction, error) {
+	return s.refundWager(userID, wagerIdempotencyKey, idempotencyKey, metadata, false)
+}
+
+// RollbackWager refunds a wager like RefundWager for a provider rollback. A
+// rollback can overtake its wager, so when the wager is not found its key is
+// recorded without transactions; the late wager is then refused with
+// ErrWagerRolledBack instead of posting a stake nothing will reverse.
+func (s *WalletService) RollbackWager(userID int, wagerIdempotencyKey string, idempotencyKey string, metadata map[string]interface{}) ([]*models.Transaction, error) {
+	return s.refundWager(userID, wagerIdempotencyKey, idempotencyKey, metadata, true)
+}
+
+// refundWager credits back the stakes of a wager, recording a tombstone under
+// the wager key if it is missing and tombstone is set
+func (s *WalletService) refundWager(userID int, wagerIdempotencyKey string, idempotencyKey string, metadata map[string]interface{}, tombstone bool) ([]*models.Transaction, error) {
+	// Serialize all operations for this user
+	s.lockUser(userID)
+	defer s.unlockUser(userID)
+
+	if wagerIdempotencyKey == "" {
+		return nil, fmt.Errorf("original wager key is required: %w", ErrInvalidInput)
 	}
 
 	// Verify user exists
@@ -205,138 +365,211 @@ func (s *WalletService) Wager(userID int, stakeGC, payoutGC, stakeSC, payoutSC i
 		return nil, err
 	}
 	if len(existingTxIDs) > 0 {
-		// Already processed, return existing transactions
+		// Already refunded, return existing transactions
+		transactions, err := s.repo.GetTransactionsTx(tx, existingTxIDs)
+		if err != nil {
+			return nil, err
+		}
 		tx.Commit()
-		var transactions []*models.Transaction
-		for _, txID := range existingTxIDs {
-			t, err := s.repo.GetTransaction(txID)
-			if err != nil {
+		return transactions, nil
+	}
+
+	// Find the original wager
+	wagerTxIDs, err := s.repo.CheckIdempotencyKey(tx, wagerIdempotencyKey, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(wagerTxIDs) == 0 {
+		// Record the tombstone once; the caller still learns nothing was refunded
+		if tombstone && wagerTxIDs == nil {
+			if err := s.repo.SaveIdempotencyKey(tx, wagerIdempotencyKey, userID, []int{}); err != nil {
+				return nil, err
+			}
+			if err := tx.Commit(); err != nil {
 				return nil, err
 			}
-			transactions = append(transactions, t)
 		}
-		return transactions, nil
+		return nil, fmt.Errorf("%w: %s", ErrWagerNotFound, wagerIdempotencyKey)
+	}
+	original, err := s.repo.GetTransactionsTx(tx, wagerTxIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadataJSON json.RawMessage
+	if len(metadata) > 0 {
+		metadataJSON, _ = json.Marshal(metadata)
 	}
 
-	// Track created transactions and their IDs
 	var transactions []*models.Transaction
 	var txIDs []int
+	for _, o := range original {
+		if !o.Type.IsStake() {
+			continue
+		}
+		refundType := models.FlowTypesOf(o.Currency).Refund
 
-	// Handle Gold Coins stake
-	if stakeGC > 0 {
-		gcBalance, err := s.repo.GetCurrentBalance(tx, userID, models.CurrencyGC)
+		balance, err := s.repo.GetCurrentBalance(tx, userID, o.Currency)
 		if err != nil {
 			return nil, err
 		}
 
-		if gcBalance < stakeGC {
-			return nil, fmt.Errorf("%w: gold coins - have %d, need %d", ErrInsufficientFunds, gcBalance, stakeGC)
-		}
-
-		// Create wager transaction
-		wagerTx := &models.Transaction{
+		refundTx := &models.Transaction{
 			UserID:       userID,
-			Currency:     models.CurrencyGC,
-			Type:         models.TransactionTypeWagerGC,
-			Amount:       stakeGC,
-			BalanceAfter: gcBalance - stakeGC,
+			Currency:     o.Currency,
+			Type:         refundType,
+			Amount:       o.Amount,
+			BalanceAfter: refundType.Apply(balance, o.Amount),
+			Metadata:     metadataJSON,
 		}
-		err = s.repo.CreateTransaction(tx, wagerTx)
+		err = s.repo.CreateTransaction(tx, refundTx)
 		if err != nil {
 			return nil, err
 		}
-		transactions = append(transactions, wagerTx)
-		txIDs = append(txIDs, wagerTx.ID)
-	}
+		transactions = append(transactions, refundTx)
+		txIDs = append(txIDs, refundTx.ID)
 
-	// Handle Gold Coins payout (independent of stake)
-	if payoutGC > 0 {
-		gcBalance, err := s.repo.GetCurrentBalance(tx, userID, models.CurrencyGC)
-		if err != nil {
+		// Take the refunded stake off the running totals of the day it was wagered
+		if err := s.repo.AddWagerTotalsTx(tx, userID, o.Currency, wagerDay(o.CreatedAt), -o.Amount, 0); err != nil {
 			return nil, err
 		}
-
-		winTx := &models.Transaction{
-			UserID:       userID,
-			Currency:     models.CurrencyGC,
-			Type:         models.TransactionTypeWinGC,
-			Amount:       payoutGC,
-			BalanceAfter: gcBalance + payoutGC,
-		}
-		err = s.repo.CreateTransaction(tx, winTx)
-		if err != nil {
+		if err := s.repo.RefundGameSessionStakeTx(tx, userID, o.Currency, o.Amount, o.CreatedAt); err != nil {
 			return nil, err
 		}
-		transactions = append(transactions, winTx)
-		txIDs = append(txIDs, winTx.ID)
 	}
 
-	// Handle Sweeps Coins stake
-	if stakeSC > 0 {
-		scBalance, err := s.repo.GetCurrentBalance(tx, userID, models.CurrencySC)
-		if err != nil {
-			return nil, err
-		}
+	if len(transactions) == 0 {
+		return nil, fmt.Errorf("wager %s has no stake to refund: %w", wagerIdempotencyKey, ErrInvalidInput)
+	}
 
-		if scBalance < stakeSC {
-			return nil, fmt.Errorf("%w: sweeps coins - have %d, need %d", ErrInsufficientFunds, scBalance, stakeSC)
-		}
+	// Save idempotency key with all transaction IDs
+	err = s.repo.SaveIdempotencyKey(tx, idempotencyKey, userID, txIDs)
+	if err != nil {
+		return nil, err
+	}
 
-		// Create wager transaction
-		wagerTx := &models.Transaction{
-			UserID:       userID,
-			Currency:     models.CurrencySC,
-			Type:         models.TransactionTypeWagerSC,
-			Amount:       stakeSC,
-			BalanceAfter: scBalance - stakeSC,
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// validateWager checks wager amounts before any repository access
+func validateWager(legs []models.WagerLeg) error {
+	seen := make(map[models.Currency]bool, len(legs))
+	placed := false
+	for _, l := range legs {
+		if l.Stake < 0 || l.Payout < 0 {
+			return fmt.Errorf("amounts cannot be negative: %w", ErrInvalidInput)
 		}
-		err = s.repo.CreateTransaction(tx, wagerTx)
-		if err != nil {
-			return nil, err
+		if seen[l.Currency] {
+			return fmt.Errorf("duplicate currency %s: %w", l.Currency, ErrInvalidInput)
 		}
-		transactions = append(transactions, wagerTx)
-		txIDs = append(txIDs, wagerTx.ID)
+		seen[l.Currency] = true
+		placed = placed || l.Stake > 0 || l.Payout > 0
 	}
+	if !placed {
+		return fmt.Errorf("at least one amount must be greater than zero: %w", ErrInvalidInput)
+	}
+	return nil
+}
 
-	// Handle Sweeps Coins payout (independent of stake)
-	if payoutSC > 0 {
-		scBalance, err := s.repo.GetCurrentBalance(tx, userID, models.CurrencySC)
-		if err != nil {
-			return nil, err
+// wagerStaked reports whether any leg of a wager has a stake
+func wagerStaked(legs []models.WagerLeg) bool {
+	for _, l := range legs {
+		if l.Stake > 0 {
+			return true
 		}
+	}
+	return false
+}
 
-		winTx := &models.Transaction{
-			UserID:       userID,
-			Currency:     models.CurrencySC,
-			Type:         models.TransactionTypeWinSC,
-			Amount:       payoutSC,
-			BalanceAfter: scBalance + payoutSC,
+// createWagerTransactions writes the stake and payout rows of a wager within tx,
+// checking each stake against the current balance, and scores the wager in
+// tournamentID if set. The caller must hold the user lock, and funds jackpot
+// pools from the stakes with contributeToJackpots just before committing, so
+// pool rows stay locked as briefly as possible.
+func (s *WalletService) createWagerTransactions(tx *sql.Tx, userID int, legs []models.WagerLeg, metadata json.RawMessage, tournamentID string) ([]*models.Transaction, []int, error) {
+	now := time.Now()
+
+	// Only currencies the registry marks wagerable can be staked
+	for _, l := range legs {
+		if l.Stake > 0 {
+			if err := s.requireCurrencyFlag(l.Currency, "wagerable", isWagerable); err != nil {
+				return nil, nil, err
+			}
 		}
-		err = s.repo.CreateTransaction(tx, winTx)
-		if err != nil {
-			return nil, err
+	}
+	staked := wagerStaked(legs)
+
+	// Self-excluded players cannot stake; payouts of rounds already in play still settle
+	if staked {
+		if err := s.checkSelfExclusion(tx, userID, now); err != nil {
+			return nil, nil, err
 		}
-		transactions = append(transactions, winTx)
-		txIDs = append(txIDs, winTx.ID)
 	}
 
-	// Save idempotency key with all transaction IDs
-	err = s.repo.SaveIdempotencyKey(tx, idempotencyKey, userID, txIDs)
+	// Responsible-gaming wager and loss limits
+	if err := s.checkWagerLimits(tx, userID, legs, now); err != nil {
+		return nil, nil, err
+	}
+
+	// The first wager opens a play session; reality checks and loss caps apply to stakes
+	session, err := s.openGameSession(tx, userID, now)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if staked {
+		if err := checkGameSession(session, legs, now); err != nil {
+			return nil, nil, err
+		}
 	}
 
-	// Commit transaction
-	err = tx.Commit()
+	transactions, txIDs, err := s.postLegs(tx, userID, wagerPostingLegs(legs, metadata))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return transactions, nil
+	// Keep today's running totals in step with the rows just written
+	if err := s.recordWagerTotals(tx, userID, transactions, now); err != nil {
+		return nil, nil, err
+	}
+	if err := s.recordGameSessionActivity(tx, session, transactions); err != nil {
+		return nil, nil, err
+	}
+
+	if tournamentID != "" {
+		if err := s.scoreTournamentWager(tx, tournamentID, userID, transactions); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return transactions, txIDs, nil
+}
+
+// wagerPostingLegs returns the rows of a wager, each leg's stake before its
+// payout, so a payout never funds the stake of its own currency
+func wagerPostingLegs(legs []models.WagerLeg, metadata json.RawMessage) []models.PostingLeg {
+	var postings []models.PostingLeg
+	for _, l := range legs {
+		types := models.FlowTypesOf(l.Currency)
+		if l.Stake > 0 {
+			postings = append(postings, models.PostingLeg{Currency: l.Currency, Type: types.Wager, Amount: l.Stake, Metadata: metadata})
+		}
+		if l.Payout > 0 {
+			postings = append(postings, models.PostingLeg{Currency: l.Currency, Type: types.Win, Amount: l.Payout, Metadata: metadata})
+		}
+	}
+	return postings
 }
 
-// Redeem handles redeeming Sweeps Coins
-func (s *WalletService) Redeem(userID int, amount int64, idempotencyKey string) (*models.Transaction, error) {
+// Redeem handles redeeming a redeemable currency, such as Sweeps Coins. An
+// amount above the currency's redemption approval threshold is not posted but
+// held as a pending request, reported with an ApprovalRequiredError.
+func (s *WalletService) Redeem(userID int, currency models.Currency, amount int64, idempotencyKey string) (*models.Transaction, error) {
 	// Serialize all operations for this user
 	s.lockUser(userID)
 	defer s.unlockUser(userID)
@@ -344,6 +577,9 @@ func (s *WalletService) Redeem(userID int, amount int64, idempotencyKey string)
 	if amount <= 0 {
 		return nil, fmt.Errorf("redemption amount must be positive: %w", ErrInvalidInput)
 	}
+	if err := s.requireCurrencyFlag(currency, "redeemable", isRedeemable); err != nil {
+		return nil, err
+	}
 
 	// Verify user exists
 	_, err := s.repo.GetUser(userID)
@@ -351,6 +587,11 @@ func (s *WalletService) Redeem(userID int, amount int64, idempotencyKey string)
 		return nil, err
 	}
 
+	threshold, err := s.approvalThreshold(models.ApprovalOperationRedemption, currency, amount)
+	if err != nil {
+		return nil, err
+	}
+
 	// Start transaction
 	tx, err := s.repo.BeginTx()
 	if err != nil {
@@ -359,42 +600,50 @@ func (s *WalletService) Redeem(userID int, amount int64, idempotencyKey string)
 	defer tx.Rollback()
 
 	// Check idempotency
-	existingTxIDs, err := s.repo.CheckIdempotencyKey(tx, idempotencyKey, userID)
+	existing, err := s.replayIdempotencyKey(tx, idempotencyKey, userID)
 	if err != nil {
 		return nil, err
 	}
-	if len(existingTxIDs) > 0 {
-		// Already processed, return existing transaction
-		tx.Commit()
-		return s.repo.GetTransaction(existingTxIDs[0])
+	if existing != nil {
+		return existing[0], nil
 	}
-
-	// Get current balance
-	scBalance, err := s.repo.GetCurrentBalance(tx, userID, models.CurrencySC)
+	held, err := s.repo.GetApprovalRequestByKeyTx(tx, userID, idempotencyKey)
 	if err != nil {
 		return nil, err
 	}
-
-	if scBalance < amount {
-		return nil, fmt.Errorf("%w: sweeps coins - have %d, need %d", ErrInsufficientFunds, scBalance, amount)
-	}
-
-	// Create redeem transaction
-	redeemTx := &models.Transaction{
-		UserID:       userID,
-		Currency:     models.CurrencySC,
-		Type:         models.TransactionTypeRedeemSC,
-		Amount:       amount,
-		BalanceAfter: scBalance - amount,
+	if held != nil {
+		tx.Commit()
+		return nil, &ApprovalRequiredError{Request: held}
 	}
 
-	err = s.repo.CreateTransaction(tx, redeemTx)
+	// Large redemptions wait for an admin; the balance is checked now and again on approval
+	if threshold != nil {
+		balance, err := s.repo.GetCurrentBalance(tx, userID, currency)
+		if err != nil {
+			return nil, err
+		}
+		if balance < amount {
+			return nil, s.insufficientFunds(currency, balance, amount)
+		}
+		return nil, s.holdForApproval(tx, &models.ApprovalRequest{
+			Operation:      models.ApprovalOperationRedemption,
+			UserID:         userID,
+			Currency:       currency,
+			Amount:         amount,
+			IdempotencyKey: idempotencyKey,
+		}, threshold)
+	}
+
+	// Post the redemption, checked against the current balance
+	transactions, txIDs, err := s.postLegs(tx, userID, []models.PostingLeg{
+		{Currency: currency, Type: models.FlowTypesOf(currency).Redeem, Amount: amount},
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	// Save idempotency key
-	err = s.repo.SaveIdempotencyKey(tx, idempotencyKey, userID, []int{redeemTx.ID})
+	err = s.repo.SaveIdempotencyKey(tx, idempotencyKey, userID, txIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -405,5 +654,5 @@ func (s *WalletService) Redeem(userID int, amount int64, idempotencyKey string)
 		return nil, err
 	}
 
-	return redeemTx, nil
+	return transactions[0], nil
 }
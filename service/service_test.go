@@ -1,8 +1,14 @@
 package service
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
+	"strings"
 	"testing"
+	"time"
+	"wallet-ledger/models"
 )
 
 // Testing Strategy:
@@ -25,7 +31,7 @@ func TestPurchase_InvalidPackage(t *testing.T) {
 	// The service will check package validity first
 	service := &WalletService{repo: nil}
 
-	_, err := service.Purchase(1, "invalid_package", "key-001")
+	_, err := service.Purchase(1, "invalid_package", "", "key-001")
 
 	if !errors.Is(err, ErrInvalidPackage) {
 		t.Errorf("expected ErrInvalidPackage, got %v", err)
@@ -52,7 +58,7 @@ func TestWager_NegativeAmounts(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := service.Wager(1, tt.stakeGC, tt.payoutGC, tt.stakeSC, tt.payoutSC, "key-001")
+			_, err := service.Wager(1, "starburst", "", models.CoinWagerLegs(tt.stakeGC, tt.payoutGC, tt.stakeSC, tt.payoutSC), "key-001")
 
 			if !errors.Is(err, ErrInvalidInput) {
 				t.Errorf("expected ErrInvalidInput, got %v", err)
@@ -70,7 +76,7 @@ func TestWager_AllZeros(t *testing.T) {
 	// Test validation: at least one amount must be > 0
 	service := &WalletService{repo: nil}
 
-	_, err := service.Wager(1, 0, 0, 0, 0, "key-001")
+	_, err := service.Wager(1, "starburst", "", nil, "key-001")
 
 	if !errors.Is(err, ErrInvalidInput) {
 		t.Errorf("expected ErrInvalidInput, got %v", err)
@@ -86,7 +92,7 @@ func TestRedeem_NegativeAmount(t *testing.T) {
 	// Test input validation before any repository calls
 	service := &WalletService{repo: nil}
 
-	_, err := service.Redeem(1, -10, "key-001")
+	_, err := service.Redeem(1, models.CurrencySC, -10, "key-001")
 
 	if !errors.Is(err, ErrInvalidInput) {
 		t.Errorf("expected ErrInvalidInput, got %v", err)
@@ -102,7 +108,7 @@ func TestRedeem_ZeroAmount(t *testing.T) {
 	// Test validation: amount must be positive
 	service := &WalletService{repo: nil}
 
-	_, err := service.Redeem(1, 0, "key-001")
+	_, err := service.Redeem(1, models.CurrencySC, 0, "key-001")
 
 	if !errors.Is(err, ErrInvalidInput) {
 		t.Errorf("expected ErrInvalidInput, got %v", err)
@@ -130,3 +136,1259 @@ func TestUserLevelLocks(t *testing.T) {
 		t.Error("expected different lock instances for different users")
 	}
 }
+
+// Test WagerBatch - Batch Size Limits (validation logic)
+func TestWagerBatch_Size(t *testing.T) {
+	service := &WalletService{repo: nil}
+
+	if _, err := service.WagerBatch(nil); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for empty batch, got %v", err)
+	}
+
+	items := make([]models.WagerBatchItem, MaxWagerBatchSize+1)
+	if _, err := service.WagerBatch(items); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for oversized batch, got %v", err)
+	}
+}
+
+// Test WagerBatch - Invalid Items Reported Per Item (validation logic)
+func TestWagerBatch_InvalidItems(t *testing.T) {
+	// Invalid items are rejected before grouping, so no repository calls happen
+	service := &WalletService{repo: nil}
+
+	result, err := service.WagerBatch([]models.WagerBatchItem{
+		{UserID: 1, GameID: "starburst", Legs: models.CoinWagerLegs(100, 0, 0, 0)},
+		{UserID: 1, GameID: "starburst", Legs: models.CoinWagerLegs(-100, 0, 0, 0), IdempotencyKey: "key-002"},
+		{UserID: 2, GameID: "starburst", IdempotencyKey: "key-003"},
+		{UserID: 2, Legs: models.CoinWagerLegs(100, 0, 0, 0), IdempotencyKey: "key-004"},
+	})
+	if err != nil {
+		t.Fatalf("expected per-item results, got %v", err)
+	}
+
+	for i, r := range result.Results {
+		if r.Index != i {
+			t.Errorf("expected result %d to keep its index, got %d", i, r.Index)
+		}
+		if r.Status != models.WagerBatchStatusInvalid {
+			t.Errorf("expected item %d to be invalid, got %s", i, r.Status)
+		}
+	}
+
+	if result.Summary.Total != 4 || result.Summary.Failed != 4 {
+		t.Errorf("expected 4 failed of 4, got %+v", result.Summary)
+	}
+}
+
+// Test failed batch items are classified like single wagers
+func TestBatchItemFailure(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status models.WagerBatchStatus
+	}{
+		{"insufficient funds", fmt.Errorf("%w: gold coins", ErrInsufficientFunds), models.WagerBatchStatusInsufficientFunds},
+		{"invalid input", fmt.Errorf("bad metadata: %w", ErrInvalidInput), models.WagerBatchStatusInvalid},
+		{"currency not allowed", fmt.Errorf("%w: SC", ErrCurrencyNotAllowed), models.WagerBatchStatusInvalid},
+		{"stake out of range", fmt.Errorf("%w: 5", ErrStakeOutOfRange), models.WagerBatchStatusInvalid},
+		{"self-excluded", ErrSelfExcluded, models.WagerBatchStatusInvalid},
+		{"unexpected", errors.New("connection reset"), models.WagerBatchStatusError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, message := batchItemFailure(tt.err)
+			if status != tt.status {
+				t.Errorf("expected %s, got %s", tt.status, status)
+			}
+			if status == models.WagerBatchStatusError && message != "failed to process wager" {
+				t.Errorf("expected internal errors to be hidden, got %q", message)
+			}
+		})
+	}
+}
+
+// Test Wager - Missing Game (validation logic)
+func TestWager_MissingGame(t *testing.T) {
+	service := &WalletService{repo: nil}
+
+	_, err := service.Wager(1, "", "", models.CoinWagerLegs(100, 0, 0, 0), "key-001")
+
+	if !errors.Is(err, ErrInvalidGame) {
+		t.Errorf("expected ErrInvalidGame, got %v", err)
+	}
+}
+
+// Test Game Rules - Enabled Flag, Currencies and Stake Limits
+func TestValidateGameWager(t *testing.T) {
+	game := &models.Game{
+		ID:      "blackjack_classic",
+		Enabled: true,
+		Currencies: []models.GameCurrency{
+			{Currency: models.CurrencyGC, MinStake: 500, MaxStake: 500000},
+		},
+	}
+	disabled := *game
+	disabled.Enabled = false
+
+	tests := []struct {
+		name     string
+		game     *models.Game
+		stakeGC  int64
+		payoutGC int64
+		stakeSC  int64
+		expected error
+	}{
+		{"valid stake", game, 1000, 0, 0, nil},
+		{"payout only below minimum", game, 0, 100, 0, nil},
+		{"disabled game", &disabled, 1000, 0, 0, ErrGameDisabled},
+		{"currency not allowed", game, 0, 0, 5, ErrCurrencyNotAllowed},
+		{"below minimum stake", game, 100, 0, 0, ErrStakeOutOfRange},
+		{"above maximum stake", game, 600000, 0, 0, ErrStakeOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateGameWager(tt.game, models.CoinWagerLegs(tt.stakeGC, tt.payoutGC, tt.stakeSC, 0))
+
+			if tt.expected == nil && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			if tt.expected != nil && !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+// Test jackpot contributions are the configured share of the stake, rounded down
+func TestJackpotContribution(t *testing.T) {
+	tests := []struct {
+		stake    int64
+		bps      int64
+		expected int64
+	}{
+		{10000, 50, 50},
+		{200, 50, 1},
+		{199, 50, 0},
+		{100, 10000, 100},
+		{100, 0, 0},
+	}
+
+	for _, tt := range tests {
+		if got := jackpotContribution(tt.stake, tt.bps); got != tt.expected {
+			t.Errorf("jackpotContribution(%d, %d) = %d, expected %d", tt.stake, tt.bps, got, tt.expected)
+		}
+	}
+}
+
+// Test SaveJackpotPool - Invalid Definitions (validation logic)
+func TestSaveJackpotPool_Invalid(t *testing.T) {
+	service := &WalletService{repo: nil}
+
+	tests := []struct {
+		name string
+		pool models.JackpotPool
+	}{
+		{"missing id", models.JackpotPool{Name: "Mega", Currency: models.CurrencyGC, ContributionBPS: 50}},
+		{"invalid currency", models.JackpotPool{ID: "mega", Name: "Mega", Currency: "EUR", ContributionBPS: 50}},
+		{"contribution above 100%", models.JackpotPool{ID: "mega", Name: "Mega", Currency: models.CurrencyGC, ContributionBPS: 10001}},
+		{"negative seed", models.JackpotPool{ID: "mega", Name: "Mega", Currency: models.CurrencySC, SeedAmount: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := service.SaveJackpotPool(&tt.pool); !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("expected ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+}
+
+// Test currency definitions are validated before being saved
+func TestSaveCurrency_Invalid(t *testing.T) {
+	service := &WalletService{repo: nil}
+
+	tests := []struct {
+		name     string
+		currency models.CurrencyInfo
+	}{
+		{"lower-case code", models.CurrencyInfo{Code: "eur", Name: "Euro", MinorUnits: 2}},
+		{"code too short", models.CurrencyInfo{Code: "E", Name: "Euro", MinorUnits: 2}},
+		{"missing name", models.CurrencyInfo{Code: "EUR", MinorUnits: 2}},
+		{"too many minor units", models.CurrencyInfo{Code: "EUR", Name: "Euro", MinorUnits: 9}},
+		{"negative minor units", models.CurrencyInfo{Code: "EUR", Name: "Euro", MinorUnits: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := service.SaveCurrency(&tt.currency); !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("expected ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+}
+
+// Test the currency registry falls back to the built-in currencies and its
+// flags gate configuration and operations
+func TestCurrencyRegistry(t *testing.T) {
+	service := &WalletService{repo: nil}
+
+	if _, err := service.GetCurrency(models.CurrencySC); err != nil {
+		t.Fatalf("expected built-in SC, got %v", err)
+	}
+	if _, err := service.GetCurrency("EUR"); !errors.Is(err, ErrCurrencyNotFound) {
+		t.Errorf("expected ErrCurrencyNotFound, got %v", err)
+	}
+	if err := service.requireCurrencyFlag(models.CurrencyGC, "redeemable", isRedeemable); !errors.Is(err, ErrCurrencyNotAllowed) {
+		t.Errorf("expected GC not to be redeemable, got %v", err)
+	}
+
+	service.currencies.Store(newCurrencyRegistry([]models.CurrencyInfo{
+		{Code: models.CurrencyGC, Name: "Gold Coins", Purchasable: true, Wagerable: true},
+		{Code: "PTS", Name: "Points"},
+	}))
+
+	if _, err := service.GetCurrency(models.CurrencySC); !errors.Is(err, ErrCurrencyNotFound) {
+		t.Errorf("expected loaded registry to replace built-ins, got %v", err)
+	}
+	pool := models.JackpotPool{ID: "mega", Name: "Mega", Currency: "PTS", ContributionBPS: 50}
+	if err := service.SaveJackpotPool(&pool); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected non-wagerable currency to be rejected, got %v", err)
+	}
+	if err := service.requireCurrencyFlag(models.CurrencySC, "wagerable", isWagerable); !errors.Is(err, ErrCurrencyNotAllowed) {
+		t.Errorf("expected unregistered currency to be rejected, got %v", err)
+	}
+}
+
+// Test tournament scores for each scoring rule
+func TestTournamentScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		scoring  models.TournamentScoring
+		current  int64
+		stake    int64
+		payout   int64
+		expected int64
+	}{
+		{"total wagered adds stake", models.TournamentScoringTotalWagered, 500, 100, 0, 600},
+		{"total wagered ignores payout", models.TournamentScoringTotalWagered, 0, 0, 1000, 0},
+		{"new best multiplier", models.TournamentScoringBiggestMultiplier, 200, 100, 1250, 1250},
+		{"lower multiplier keeps best", models.TournamentScoringBiggestMultiplier, 1250, 100, 300, 1250},
+		{"multiplier needs a stake", models.TournamentScoringBiggestMultiplier, 0, 0, 1000, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tournamentScore(tt.scoring, tt.current, tt.stake, tt.payout); got != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+// Test entry fees are checked against the wager limits of their currency
+func TestEntryFeeLegs(t *testing.T) {
+	for _, currency := range []models.Currency{models.CurrencyGC, models.CurrencySC, "PTS"} {
+		legs := entryFeeLegs(&models.Tournament{Currency: currency, EntryFee: 1000})
+		if len(legs) != 1 || legs[0].Currency != currency || legs[0].Stake != 1000 || legs[0].Payout != 0 {
+			t.Errorf("%s: expected a 1000 stake, got %+v", currency, legs)
+		}
+	}
+}
+
+// Test a tournament with entries keeps its currency, entry fee and scoring
+func TestCheckTournamentTerms(t *testing.T) {
+	existing := &models.Tournament{ID: "weekly", Currency: models.CurrencySC, EntryFee: 500, Scoring: models.TournamentScoringTotalWagered}
+
+	// Name, prizes and schedule may still change
+	renamed := *existing
+	renamed.Name = "Weekly Race"
+	renamed.Prizes = []int64{10000, 5000}
+	if err := checkTournamentTerms(existing, &renamed); err != nil {
+		t.Errorf("expected other changes to be allowed, got %v", err)
+	}
+
+	changes := []func(*models.Tournament){
+		func(t *models.Tournament) { t.Currency = models.CurrencyGC },
+		func(t *models.Tournament) { t.EntryFee = 0 },
+		func(t *models.Tournament) { t.Scoring = models.TournamentScoringBiggestMultiplier },
+	}
+	for i, change := range changes {
+		changed := *existing
+		change(&changed)
+		if err := checkTournamentTerms(existing, &changed); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("change %d: expected ErrInvalidInput, got %v", i, err)
+		}
+	}
+}
+
+// Test wagers validate and post their legs in any currency, with the GC and SC
+// specific types kept for those
+func TestWagerLegs(t *testing.T) {
+	legs := []models.WagerLeg{
+		{Currency: "PTS", Stake: 500, Payout: 200},
+		{Currency: models.CurrencyGC, Stake: 100},
+	}
+	if err := validateWager(legs); err != nil {
+		t.Fatalf("expected legs to be valid, got %v", err)
+	}
+	if err := validateWager(append(legs, models.WagerLeg{Currency: "PTS", Payout: 1})); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected duplicate currency to be rejected, got %v", err)
+	}
+
+	postings := wagerPostingLegs(legs, nil)
+	want := []models.PostingLeg{
+		{Currency: "PTS", Type: models.TransactionTypeWager, Amount: 500},
+		{Currency: "PTS", Type: models.TransactionTypeWin, Amount: 200},
+		{Currency: models.CurrencyGC, Type: models.TransactionTypeWagerGC, Amount: 100},
+	}
+	if len(postings) != len(want) {
+		t.Fatalf("expected %d postings, got %+v", len(want), postings)
+	}
+	for i := range want {
+		if postings[i].Currency != want[i].Currency || postings[i].Type != want[i].Type || postings[i].Amount != want[i].Amount {
+			t.Errorf("posting %d: expected %+v, got %+v", i, want[i], postings[i])
+		}
+	}
+}
+
+// Test purchases post every currency of a package, in registry order, and
+// need one of them to be purchasable
+func TestPurchaseLegs(t *testing.T) {
+	service := &WalletService{repo: nil}
+
+	legs, err := service.purchaseLegs(models.Packages["starter_10k"])
+	if err != nil || len(legs) != 2 || legs[0].Currency != models.CurrencyGC || legs[1].Currency != models.CurrencySC {
+		t.Errorf("expected GC and SC legs, got %+v (%v)", legs, err)
+	}
+	if _, err := service.purchaseLegs(models.Package{Code: "sc_only", SweepCoins: 100}); !errors.Is(err, ErrCurrencyNotAllowed) {
+		t.Errorf("expected a package without a purchasable currency to be rejected, got %v", err)
+	}
+	if _, err := service.purchaseLegs(models.Package{Code: "unknown", GoldCoins: 100, Coins: map[models.Currency]int64{"PTS": 5}}); !errors.Is(err, ErrInvalidPackage) {
+		t.Errorf("expected an unregistered currency to be rejected, got %v", err)
+	}
+
+	// A registered currency can be sold on its own
+	service.currencies.Store(newCurrencyRegistry([]models.CurrencyInfo{
+		{Code: models.CurrencyGC, Name: "Gold Coins", Wagerable: true},
+		{Code: "PTS", Name: "Points", Purchasable: true},
+	}))
+	legs, err = service.purchaseLegs(models.Package{Code: "points", Coins: map[models.Currency]int64{"PTS": 500}})
+	if err != nil || len(legs) != 1 || legs[0].Currency != "PTS" || legs[0].Amount != 500 {
+		t.Errorf("expected a PTS leg, got %+v (%v)", legs, err)
+	}
+}
+
+// Test Wager - Tournament Tag Must Be A String (validation logic)
+func TestWager_InvalidTournamentTag(t *testing.T) {
+	service := &WalletService{repo: nil}
+
+	_, err := service.WagerWithMetadata(1, "starburst", "", models.CoinWagerLegs(100, 0, 0, 0), "key-001", map[string]interface{}{
+		TournamentMetadataKey: 42,
+	})
+
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+// Test GrantBonus - Invalid Amounts (validation logic)
+func TestGrantBonus_InvalidInput(t *testing.T) {
+	service := &WalletService{repo: nil}
+
+	tests := []struct {
+		name       string
+		campaignID string
+		amountGC   int64
+		amountSC   int64
+	}{
+		{"missing campaign", "", 100, 0},
+		{"negative GC", "welcome", -100, 0},
+		{"negative SC", "welcome", 0, -1},
+		{"all zeros", "welcome", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.GrantBonus(1, tt.campaignID, tt.amountGC, tt.amountSC, "alice", "key-001")
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("expected ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+}
+
+// Test campaign budgets and per-user caps
+func TestCheckCampaignLimits(t *testing.T) {
+	campaign := &models.BonusCampaign{
+		ID:           "welcome",
+		BudgetGC:     1000,
+		BudgetSC:     0, // unlimited
+		PerUserCapGC: 300,
+		PerUserCapSC: 5,
+		IssuedGC:     900,
+	}
+
+	tests := []struct {
+		name     string
+		userGC   int64
+		userSC   int64
+		amountGC int64
+		amountSC int64
+		expected error
+	}{
+		{"within limits", 0, 0, 100, 5, nil},
+		{"budget exceeded", 0, 0, 101, 0, ErrBonusBudgetExceeded},
+		{"user GC cap exceeded", 250, 0, 60, 0, ErrBonusCapExceeded},
+		{"user SC cap exceeded", 0, 3, 0, 3, ErrBonusCapExceeded},
+		{"unlimited SC budget", 0, 0, 0, 5, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkCampaignLimits(campaign, tt.userGC, tt.userSC, tt.amountGC, tt.amountSC)
+			if tt.expected == nil && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			if tt.expected != nil && !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	// Remaining amounts are shown in coins, not minor units
+	err := checkCampaignLimits(campaign, 0, 3, 0, 3)
+	if err == nil || !strings.Contains(err.Error(), "0.02 SC left") {
+		t.Errorf("expected 0.02 SC left, got %v", err)
+	}
+}
+
+// Test daily bonus streaks continue only on consecutive days
+func TestDailyBonusStreak(t *testing.T) {
+	tests := []struct {
+		name       string
+		lastDate   string
+		lastStreak int
+		today      string
+		expected   int
+	}{
+		{"first claim", "", 0, "2025-11-14", 1},
+		{"consecutive day", "2025-11-13", 3, "2025-11-14", 4},
+		{"across month end", "2025-10-31", 6, "2025-11-01", 7},
+		{"missed a day", "2025-11-12", 5, "2025-11-14", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dailyBonusStreak(tt.lastDate, tt.lastStreak, tt.today); got != tt.expected {
+				t.Errorf("expected streak %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+// Test the claim day follows the user's time zone
+func TestClaimDay_TimeZone(t *testing.T) {
+	now := time.Date(2025, 11, 14, 3, 0, 0, 0, time.UTC)
+
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("failed to load time zone: %v", err)
+	}
+
+	if today, _ := claimDay(now, time.UTC); today != "2025-11-14" {
+		t.Errorf("expected 2025-11-14 in UTC, got %s", today)
+	}
+
+	today, next := claimDay(now, newYork)
+	if today != "2025-11-13" {
+		t.Errorf("expected 2025-11-13 in New York, got %s", today)
+	}
+	if expected := time.Date(2025, 11, 14, 0, 0, 0, 0, newYork); !next.Equal(expected) {
+		t.Errorf("expected next claim at %s, got %s", expected, next)
+	}
+}
+
+// Test a time zone change does not open a second claim on the same day
+func TestDailyBonusClaimed_TimeZoneChange(t *testing.T) {
+	baker := time.FixedZone("UTC-12", -12*60*60)
+	kiritimati := time.FixedZone("UTC+14", 14*60*60)
+
+	// Claimed late on the 1st at UTC-12, which is already the 3rd at UTC+14
+	claimedAt := time.Date(2025, 11, 1, 23, 0, 0, 0, baker)
+	last := &models.DailyBonusClaim{ClaimDate: "2025-11-01", ClaimedAt: claimedAt}
+
+	// Moving ahead to UTC+14 makes the same moment a new date, but it is still claimed
+	today, _ := claimDay(claimedAt.Add(time.Hour), kiritimati)
+	if today != "2025-11-03" {
+		t.Fatalf("expected 2025-11-03 at UTC+14, got %s", today)
+	}
+	if !dailyBonusClaimed(last, today, kiritimati) {
+		t.Errorf("expected a claim after a time zone change on the same day to be refused")
+	}
+
+	// The next calendar day in the new zone opens a claim again
+	today, _ = claimDay(claimedAt.Add(23*time.Hour), kiritimati)
+	if dailyBonusClaimed(last, today, kiritimati) {
+		t.Errorf("expected a claim on %s at UTC+14 to be allowed", today)
+	}
+
+	// Without a change, the next day in the user's zone opens a claim
+	today, _ = claimDay(claimedAt.Add(2*time.Hour), baker)
+	if dailyBonusClaimed(last, today, baker) {
+		t.Errorf("expected a claim on %s at UTC-12 to be allowed", today)
+	}
+	if dailyBonusClaimed(nil, today, baker) {
+		t.Errorf("expected a first claim to be allowed")
+	}
+}
+
+// Test streaks longer than the schedule keep the last day's reward
+func TestDailyBonusReward(t *testing.T) {
+	schedule := []models.DailyBonusDay{
+		{Day: 1, AmountGC: 1000},
+		{Day: 2, AmountGC: 2000, AmountSC: 1},
+	}
+
+	if got := dailyBonusReward(schedule, 1); got.AmountGC != 1000 {
+		t.Errorf("expected day 1 reward, got %+v", got)
+	}
+	if got := dailyBonusReward(schedule, 5); got.Day != 2 {
+		t.Errorf("expected last day reward for a long streak, got %+v", got)
+	}
+
+	if err := validateDailyBonusSchedule([]models.DailyBonusDay{{Day: 2, AmountGC: 100}}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for a schedule not starting at day 1, got %v", err)
+	}
+}
+
+// Test generated AMOE codes survive transcription from a letter or form
+func TestAMOECode_Normalize(t *testing.T) {
+	code, err := generateAMOECode()
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+
+	if got, ok := normalizeAMOECode(code); !ok || got != code {
+		t.Errorf("expected %s to normalize to itself, got %q", code, got)
+	}
+
+	transcribed := strings.ToLower(strings.ReplaceAll(code, "-", " "))
+	if got, ok := normalizeAMOECode(transcribed); !ok || got != code {
+		t.Errorf("expected %q to normalize to %s, got %q", transcribed, code, got)
+	}
+
+	for _, bad := range []string{"", "ABCD-EFGH", "0OOO-1111-BBBB", "ACDE-FGHJ-KMNP-QRTU"} {
+		if _, ok := normalizeAMOECode(bad); ok {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+// Test AMOE entries and reviews are validated before any repository access
+func TestAMOE_InvalidInput(t *testing.T) {
+	svc := &WalletService{
+		repo: nil,
+	}
+
+	tests := []struct {
+		name   string
+		submit func() error
+	}{
+		{"malformed code", func() error {
+			_, err := svc.SubmitAMOEEntry("not a code", models.AMOEMethodWeb, "")
+			return err
+		}},
+		{"unknown method", func() error {
+			_, err := svc.SubmitAMOEEntry("ACDE-FGHJ-KMNP", "fax", "")
+			return err
+		}},
+		{"mail without submitter", func() error {
+			_, err := svc.SubmitAMOEEntry("ACDE-FGHJ-KMNP", models.AMOEMethodMail, "")
+			return err
+		}},
+		{"review without reviewer", func() error {
+			_, err := svc.ApproveAMOEEntry(1, "", "")
+			return err
+		}},
+		{"zero amount setting", func() error {
+			return svc.SaveAMOESettings(&models.AMOESettings{AmountSC: 0})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.submit(); !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("expected ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+}
+
+// Test promo codes are checked for window, limit and package, with distinct errors
+func TestCheckPromoCode(t *testing.T) {
+	now := time.Date(2025, 11, 14, 12, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Hour)
+	later := now.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		promo    models.PromoCode
+		pkg      string
+		expected error
+	}{
+		{"valid", models.PromoCode{Enabled: true, StartsAt: &earlier, EndsAt: &later}, "starter_10k", nil},
+		{"disabled", models.PromoCode{}, "starter_10k", ErrPromoCodeInvalid},
+		{"not started", models.PromoCode{Enabled: true, StartsAt: &later}, "starter_10k", ErrPromoCodeInvalid},
+		{"expired", models.PromoCode{Enabled: true, EndsAt: &earlier}, "starter_10k", ErrPromoCodeExpired},
+		{"fully redeemed", models.PromoCode{Enabled: true, MaxRedemptions: 5, Redemptions: 5}, "starter_10k", ErrPromoCodeExhausted},
+		{"other package", models.PromoCode{Enabled: true, PackageCodes: []string{"grinder_50k"}}, "starter_10k", ErrPromoCodeNotApplicable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPromoCode(&tt.promo, tt.pkg, now)
+			if tt.expected == nil && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			if tt.expected != nil && !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+// Test fixed and percentage promo bonuses
+func TestPromoCodeBonus(t *testing.T) {
+	pkg := models.Package{Code: "starter_10k", GoldCoins: 10000, SweepCoins: 1005}
+
+	percent := models.PromoCode{Kind: models.PromoKindPercent, ValueGC: 25, ValueSC: 15}
+	if gc, sc := percent.Bonus(pkg); gc != 2500 || sc != 150 {
+		t.Errorf("expected 2500 GC and 1.50 SC (rounded down), got %d GC and %d SC minor units", gc, sc)
+	}
+
+	fixed := models.PromoCode{Kind: models.PromoKindFixed, ValueGC: 5000}
+	if gc, sc := fixed.Bonus(pkg); gc != 5000 || sc != 0 {
+		t.Errorf("expected 5000 GC and 0 SC, got %d GC and %d SC", gc, sc)
+	}
+
+	svc := &WalletService{repo: nil}
+	err := svc.SavePromoCode(&models.PromoCode{Code: "spring", Kind: "double"})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an unknown kind, got %v", err)
+	}
+}
+
+// Test segmented offers are only open to eligible users
+func TestCheckOfferEligibility(t *testing.T) {
+	now := time.Date(2025, 11, 14, 12, 0, 0, 0, time.UTC)
+	longAgo := now.AddDate(0, -2, 0)
+	recently := now.AddDate(0, 0, -3)
+
+	newUser := &models.User{ID: 1, CreatedAt: recently}
+	lapsedUser := &models.User{ID: 2, CreatedAt: longAgo}
+	vipUser := &models.User{ID: 3, CreatedAt: longAgo, VIPTier: 2}
+
+	tests := []struct {
+		name     string
+		pkg      string
+		user     *models.User
+		history  models.PurchaseHistory
+		eligible bool
+	}{
+		{"regular package", "starter_10k", newUser, models.PurchaseHistory{HasPurchased: true}, true},
+		{"first purchase", "first_purchase_25k", newUser, models.PurchaseHistory{}, true},
+		{"first purchase after buying", "first_purchase_25k", newUser, models.PurchaseHistory{HasPurchased: true}, false},
+		{"daily deal", "daily_deal_20k", newUser, models.PurchaseHistory{BoughtToday: []string{"starter_10k"}}, true},
+		{"daily deal bought today", "daily_deal_20k", newUser, models.PurchaseHistory{BoughtToday: []string{"daily_deal_20k"}}, false},
+		{"win back lapsed", "welcome_back_30k", lapsedUser, models.PurchaseHistory{LastActivityAt: &longAgo}, true},
+		{"win back recently active", "welcome_back_30k", lapsedUser, models.PurchaseHistory{LastActivityAt: &recently}, false},
+		{"win back new user", "welcome_back_30k", newUser, models.PurchaseHistory{}, false},
+		{"vip", "vip_500k", vipUser, models.PurchaseHistory{}, true},
+		{"vip not a vip", "vip_500k", lapsedUser, models.PurchaseHistory{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkOfferEligibility(models.Packages[tt.pkg], tt.user, &tt.history, now)
+			if tt.eligible && err != nil {
+				t.Errorf("expected eligible, got %v", err)
+			}
+			if !tt.eligible && !errors.Is(err, ErrOfferNotEligible) {
+				t.Errorf("expected ErrOfferNotEligible, got %v", err)
+			}
+		})
+	}
+}
+
+// Test which spend limit changes must wait out the cooling-off period
+func TestSpendLimitRules(t *testing.T) {
+	increases := []struct {
+		old, new int64
+		expected bool
+	}{
+		{0, 5000, false},    // setting a first limit
+		{5000, 1000, false}, // lowering
+		{5000, 5000, false}, // unchanged
+		{5000, 9000, true},  // raising
+		{5000, 0, true},     // removing
+	}
+	for _, tt := range increases {
+		if got := isLimitIncrease(tt.old, tt.new); got != tt.expected {
+			t.Errorf("isLimitIncrease(%d, %d) = %v, expected %v", tt.old, tt.new, got, tt.expected)
+		}
+	}
+
+	if got := effectiveSpendLimit(0, 100000); got != 100000 {
+		t.Errorf("expected the operator cap without a player limit, got %d", got)
+	}
+	if got := effectiveSpendLimit(5000, 100000); got != 5000 {
+		t.Errorf("expected the tighter player limit, got %d", got)
+	}
+	if got := effectiveSpendLimit(500000, 100000); got != 100000 {
+		t.Errorf("expected the operator cap to bound a looser player limit, got %d", got)
+	}
+
+	now := time.Now()
+	pending := int64(9000)
+	due := now.Add(-time.Minute)
+	limit := models.SpendLimit{LimitCents: 5000, PendingLimitCents: &pending, PendingEffectiveAt: &due}
+	if !limit.ApplyDue(now) || limit.LimitCents != 9000 || limit.PendingEffectiveAt != nil {
+		t.Errorf("expected the pending limit to apply after cooling off, got %+v", limit)
+	}
+
+	svc := &WalletService{repo: nil}
+	if _, err := svc.SetSpendLimit(1, "yearly", 1000); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an unknown period, got %v", err)
+	}
+	if _, err := svc.SetSpendLimit(1, models.SpendLimitDaily, -1); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for a negative limit, got %v", err)
+	}
+}
+
+// Test wager limits report the allowance left under the limit that was hit
+func TestCheckWagerLimit(t *testing.T) {
+	limits := map[models.WagerLimitKind]int64{
+		models.WagerLimitMaxStake:     500,
+		models.WagerLimitDailyWagered: 2000,
+		models.WagerLimitDailyLoss:    1000,
+	}
+
+	tests := []struct {
+		name      string
+		totals    models.WagerTotals
+		stake     int64
+		kind      models.WagerLimitKind // empty if the stake is allowed
+		remaining int64
+	}{
+		{"within limits", models.WagerTotals{Wagered: 1000, Won: 800}, 500, "", 0},
+		{"stake too large", models.WagerTotals{}, 600, models.WagerLimitMaxStake, 500},
+		{"daily wagered reached", models.WagerTotals{Wagered: 1800, Won: 1800}, 300, models.WagerLimitDailyWagered, 200},
+		{"daily loss reached", models.WagerTotals{Wagered: 1000, Won: 100}, 200, models.WagerLimitDailyLoss, 100},
+		{"winnings offset losses", models.WagerTotals{Wagered: 1500, Won: 1400}, 500, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkWagerLimit(models.CurrencySC, limits, tt.totals, tt.stake)
+			if tt.kind == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			var limitErr *WagerLimitError
+			if !errors.As(err, &limitErr) || !errors.Is(err, ErrWagerLimitExceeded) {
+				t.Fatalf("expected a WagerLimitError, got %v", err)
+			}
+			if limitErr.Kind != tt.kind || limitErr.Remaining != tt.remaining {
+				t.Errorf("expected %s with %d remaining, got %s with %d", tt.kind, tt.remaining, limitErr.Kind, limitErr.Remaining)
+			}
+		})
+	}
+
+	svc := &WalletService{repo: nil}
+	if _, err := svc.SetWagerLimit(1, models.CurrencySC, "weekly_loss", 100); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an unknown kind, got %v", err)
+	}
+}
+
+func TestSelfExclusionPeriods(t *testing.T) {
+	start := time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)
+
+	day := &models.SelfExclusion{Period: models.SelfExclusion24Hours, StartsAt: start, EndsAt: models.SelfExclusion24Hours.EndsAt(start)}
+	week := &models.SelfExclusion{Period: models.SelfExclusion7Days, StartsAt: start, EndsAt: models.SelfExclusion7Days.EndsAt(start)}
+	forever := &models.SelfExclusion{Period: models.SelfExclusionPermanent, StartsAt: start, EndsAt: models.SelfExclusionPermanent.EndsAt(start)}
+
+	if !day.EndsAt.Equal(start.Add(24 * time.Hour)) {
+		t.Errorf("expected 24h exclusion to end a day later, got %v", day.EndsAt)
+	}
+	if end := models.SelfExclusion6Months.EndsAt(start); end == nil || end.Month() != time.July {
+		t.Errorf("expected 6m exclusion to end in July, got %v", end)
+	}
+	if forever.EndsAt != nil {
+		t.Errorf("expected permanent exclusion to have no end, got %v", forever.EndsAt)
+	}
+
+	if !day.IsActive(start) || day.IsActive(start.Add(24*time.Hour)) {
+		t.Error("expected 24h exclusion to be active from its start until its end")
+	}
+	if !forever.IsActive(start.AddDate(10, 0, 0)) {
+		t.Error("expected permanent exclusion to stay active")
+	}
+
+	if !day.OutlastedBy(week) || week.OutlastedBy(day) {
+		t.Error("expected only a longer exclusion to outlast a shorter one")
+	}
+	if forever.OutlastedBy(week) || !week.OutlastedBy(forever) {
+		t.Error("expected nothing to outlast a permanent exclusion")
+	}
+
+	svc := &WalletService{repo: nil}
+	if _, err := svc.SelfExclude(1, "1y"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an unknown period, got %v", err)
+	}
+}
+
+func TestCheckGameSession(t *testing.T) {
+	start := time.Date(2024, 3, 1, 20, 0, 0, 0, time.UTC)
+	session := &models.GameSession{
+		StartedAt:           start,
+		LastActivityAt:      start.Add(10 * time.Minute),
+		SCStaked:            900,
+		SCWon:               500,
+		LossCapSC:           1000,
+		RealityCheckMinutes: 60,
+		RealityCheckAt:      start,
+	}
+
+	tests := []struct {
+		name    string
+		stakeGC int64
+		stakeSC int64
+		now     time.Time
+		want    error
+	}{
+		{"within cap", 0, 600, start.Add(30 * time.Minute), nil},
+		{"over sc loss cap", 0, 601, start.Add(30 * time.Minute), ErrSessionLossCapReached},
+		{"gc has no cap", 1000000, 0, start.Add(30 * time.Minute), nil},
+		{"reality check due", 0, 10, start.Add(time.Hour), ErrRealityCheckDue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkGameSession(session, models.CoinWagerLegs(tt.stakeGC, 0, tt.stakeSC, 0), tt.now)
+			if tt.want == nil && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	if isGameSessionIdle(session, session.LastActivityAt.Add(GameSessionIdleTimeout-time.Second)) {
+		t.Error("expected session to stay open within the idle timeout")
+	}
+	if !isGameSessionIdle(session, session.LastActivityAt.Add(GameSessionIdleTimeout)) {
+		t.Error("expected session to be idle after the idle timeout")
+	}
+
+	summary := session.Summary(start.Add(90 * time.Minute))
+	if summary.NetSC != -400 || summary.DurationSeconds != 5400 || !summary.RealityCheckDue {
+		t.Errorf("unexpected summary: net %d, duration %d, due %v", summary.NetSC, summary.DurationSeconds, summary.RealityCheckDue)
+	}
+
+	svc := &WalletService{repo: nil}
+	if err := svc.SaveGameSessionSettings(&models.GameSessionSettings{UserID: 1, LossCapSC: -1}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for a negative loss cap, got %v", err)
+	}
+}
+
+func TestPlanSCExpiry(t *testing.T) {
+	now := time.Date(2024, 6, 1, 3, 0, 0, 0, time.UTC)
+	settings := &models.SCExpirySettings{InactivityDays: 90, WarningDays: 7}
+	warnedAt := func(days int) *time.Time {
+		at := now.AddDate(0, 0, days)
+		return &at
+	}
+
+	balances := []models.InactiveSCBalance{
+		{UserID: 1, LastActivityAt: now.AddDate(0, 0, -83), BalanceSC: 10},                               // entering the warning window
+		{UserID: 2, LastActivityAt: now.AddDate(0, 0, -85), BalanceSC: 10, WarnedExpiresAt: warnedAt(5)}, // warned, not yet due
+		{UserID: 3, LastActivityAt: now.AddDate(0, 0, -90), BalanceSC: 10, WarnedExpiresAt: warnedAt(0)}, // warned and due
+		{UserID: 4, LastActivityAt: now.AddDate(0, 0, -200), BalanceSC: 10},                              // past due, never warned
+	}
+
+	warn, expire := planSCExpiry(balances, settings, now)
+
+	if len(warn) != 2 || warn[0].UserID != 1 || warn[1].UserID != 4 {
+		t.Fatalf("expected warnings for users 1 and 4, got %+v", warn)
+	}
+	if !warn[0].ExpiresAt.Equal(balances[0].LastActivityAt.AddDate(0, 0, 90)) {
+		t.Errorf("expected user 1 to expire 90 days after last activity, got %v", warn[0].ExpiresAt)
+	}
+	if !warn[1].ExpiresAt.Equal(now.AddDate(0, 0, 7)) {
+		t.Errorf("expected user 4 to get the full warning period, got %v", warn[1].ExpiresAt)
+	}
+	if len(expire) != 1 || expire[0].UserID != 3 {
+		t.Errorf("expected only user 3 to expire, got %+v", expire)
+	}
+
+	// Without warnings balances expire as soon as the threshold passes
+	_, expire = planSCExpiry(balances[3:], &models.SCExpirySettings{InactivityDays: 90}, now)
+	if len(expire) != 1 {
+		t.Errorf("expected unwarned balance to expire when warnings are off, got %+v", expire)
+	}
+
+	svc := &WalletService{repo: nil}
+	if err := svc.SaveSCExpirySettings(&models.SCExpirySettings{InactivityDays: 30, WarningDays: 30}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for warning_days >= inactivity_days, got %v", err)
+	}
+}
+
+// Test the ledger checker replays balances through the posting rules and
+// reports each broken row once
+func TestLedgerChecker(t *testing.T) {
+	checker := newLedgerChecker()
+	for _, tx := range []models.Transaction{
+		{ID: 1, Currency: models.CurrencyGC, Type: models.TransactionTypePurchase, Amount: 10000, BalanceAfter: 10000},
+		{ID: 2, Currency: models.CurrencySC, Type: models.TransactionTypePurchase, Amount: 1000, BalanceAfter: 1000},
+		{ID: 3, Currency: models.CurrencyGC, Type: models.TransactionTypeWagerGC, Amount: 500, BalanceAfter: 9500},
+		{ID: 4, Currency: models.CurrencyGC, Type: models.TransactionTypeWinGC, Amount: 900, BalanceAfter: 10500}, // should be 10400
+		{ID: 5, Currency: models.CurrencyGC, Type: models.TransactionTypeRefundGC, Amount: 500, BalanceAfter: 11000},
+		{ID: 6, Currency: models.CurrencyGC, Type: models.TransactionTypeRedeemSC, Amount: 100, BalanceAfter: 10900},
+	} {
+		checker.check(&tx)
+	}
+	checker.compareSums(map[models.Currency]int64{models.CurrencyGC: 10800, models.CurrencySC: 1000})
+
+	if checker.checked != 6 {
+		t.Errorf("expected 6 transactions checked, got %d", checker.checked)
+	}
+	if checker.balances[models.CurrencyGC] != 10900 || checker.balances[models.CurrencySC] != 1000 {
+		t.Errorf("unexpected balances %v", checker.balances)
+	}
+
+	var got []string
+	for _, d := range checker.discrepancies {
+		got = append(got, fmt.Sprintf("%d %s %d/%d", d.TransactionID, d.Currency, d.Expected, d.Actual))
+	}
+	expected := []string{
+		"4 GC 10400/10500", // wrong balance_after
+		"6 GC 0/0",         // SC-only type posted in GC
+		"0 GC 10900/10800", // summed balance mismatch
+	}
+	if strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected discrepancies %v, got %v", expected, got)
+	}
+}
+
+// Test Post - leg validation happens before any repository access
+func TestPost_InvalidLegs(t *testing.T) {
+	service := &WalletService{repo: nil}
+
+	tests := []struct {
+		name string
+		legs []models.PostingLeg
+	}{
+		{"no legs", nil},
+		{"unknown type", []models.PostingLeg{{Currency: models.CurrencyGC, Type: "gift", Amount: 100}}},
+		{"reserved type", []models.PostingLeg{{Currency: models.CurrencySC, Type: models.TransactionTypeRedeemSC, Amount: 100}}},
+		{"unknown currency", []models.PostingLeg{{Currency: "XYZ", Type: models.TransactionTypeTournamentPrize, Amount: 100}}},
+		{"bonus outside its campaign", []models.PostingLeg{{Currency: models.CurrencySC, Type: models.TransactionTypeBonusSC, Amount: 100}}},
+		{"promo outside its code", []models.PostingLeg{{Currency: models.CurrencyGC, Type: models.TransactionTypePromoGC, Amount: 100}}},
+		{"zero amount", []models.PostingLeg{{Currency: models.CurrencyGC, Type: models.TransactionTypeTournamentPrize, Amount: 0}}},
+		{"metadata not an object", []models.PostingLeg{{Currency: models.CurrencyGC, Type: models.TransactionTypeTournamentPrize, Amount: 100, Metadata: json.RawMessage(`[1]`)}}},
+		{"second leg invalid", []models.PostingLeg{
+			{Currency: models.CurrencyGC, Type: models.TransactionTypeTournamentPrize, Amount: 100},
+			{Currency: models.CurrencySC, Type: models.TransactionTypeTournamentPrize, Amount: -5},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.Post(1, tt.legs, "key-001")
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("expected ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+
+	legs := []models.PostingLeg{{Currency: models.CurrencySC, Type: models.TransactionTypeRedeemSC, Amount: 100}}
+	if err := service.validateLegs(legs, true); err != nil {
+		t.Errorf("expected reserved leg to be accepted from its own flow, got %v", err)
+	}
+}
+
+// Test the general-purpose types accept credit and debit legs, and only the
+// credits count towards the approval threshold
+func TestPost_CreditAndDebitLegs(t *testing.T) {
+	service := &WalletService{repo: nil}
+
+	legs := []models.PostingLeg{
+		{Currency: models.CurrencySC, Type: models.TransactionTypeReferralSC, Amount: 500, Metadata: json.RawMessage(`{"referred_user_id": 42}`)},
+		{Currency: models.CurrencyGC, Type: models.TransactionTypeCorrection, Amount: -250, Metadata: json.RawMessage(`{"ticket": "SUP-1"}`)},
+		{Currency: models.CurrencyGC, Type: models.TransactionTypeCorrection, Amount: 100},
+	}
+	if err := service.validateLegs(legs, false); err != nil {
+		t.Fatalf("expected credit and debit legs to be accepted, got %v", err)
+	}
+
+	currencies, credited := postingCredits(legs)
+	if len(currencies) != 2 || credited[models.CurrencySC] != 500 || credited[models.CurrencyGC] != 100 {
+		t.Errorf("expected credits of 500 SC and 100 GC, got %v %v", currencies, credited)
+	}
+
+	invalid := []models.PostingLeg{{Currency: models.CurrencyGC, Type: models.TransactionTypeReferralSC, Amount: 500}}
+	if err := service.validateLegs(invalid, false); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected referral_sc in GC to be rejected, got %v", err)
+	}
+	negative := []models.PostingLeg{{Currency: models.CurrencyGC, Type: models.TransactionTypeReferralGC, Amount: -500}}
+	if err := service.validateLegs(negative, false); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected a negative referral to be rejected, got %v", err)
+	}
+}
+
+// Test a batch mixing credits and debits across users is accepted, its users
+// are locked in ID order and its credits are totalled across users
+func TestPostBatch_MixedLegs(t *testing.T) {
+	service := &WalletService{repo: nil}
+
+	legs := []models.BatchPostingLeg{
+		{UserID: 9, PostingLeg: models.PostingLeg{Currency: models.CurrencyGC, Type: models.TransactionTypeReferralGC, Amount: 1000}},
+		{UserID: 3, PostingLeg: models.PostingLeg{Currency: models.CurrencySC, Type: models.TransactionTypeCorrection, Amount: -150}},
+		{UserID: 9, PostingLeg: models.PostingLeg{Currency: models.CurrencySC, Type: models.TransactionTypeReferralSC, Amount: 200}},
+		{UserID: 5, PostingLeg: models.PostingLeg{Currency: models.CurrencySC, Type: models.TransactionTypeCorrection, Amount: 300}},
+	}
+
+	postingLegs, userIDs := splitBatchLegs(legs)
+	if err := service.validateLegs(postingLegs, false); err != nil {
+		t.Fatalf("expected mixed legs to be accepted, got %v", err)
+	}
+	if len(userIDs) != 3 || userIDs[0] != 3 || userIDs[1] != 5 || userIDs[2] != 9 {
+		t.Errorf("expected users 3, 5, 9, got %v", userIDs)
+	}
+
+	_, credited := postingCredits(postingLegs)
+	if credited[models.CurrencyGC] != 1000 || credited[models.CurrencySC] != 500 {
+		t.Errorf("expected batch credits of 1000 GC and 500 SC, got %v", credited)
+	}
+}
+
+// Test the credits of a posting are totalled per currency for the approval threshold
+func TestPostingCredits(t *testing.T) {
+	legs := []models.PostingLeg{
+		{Currency: models.CurrencySC, Type: models.TransactionTypeTournamentPrize, Amount: 5000},
+		{Currency: models.CurrencyGC, Type: models.TransactionTypeTournamentPrize, Amount: 100},
+		{Currency: models.CurrencySC, Type: models.TransactionTypeTournamentEntry, Amount: 2000},
+		{Currency: models.CurrencySC, Type: models.TransactionTypeTournamentPrize, Amount: 2500},
+		{Currency: models.CurrencyGC, Type: models.TransactionTypeTournamentPrize, Amount: math.MaxInt64},
+	}
+
+	currencies, credited := postingCredits(legs)
+	if len(currencies) != 2 || currencies[0] != models.CurrencySC || currencies[1] != models.CurrencyGC {
+		t.Errorf("expected SC then GC, got %v", currencies)
+	}
+	if credited[models.CurrencySC] != 7500 {
+		t.Errorf("expected debits to be left out of the SC credits, got %d", credited[models.CurrencySC])
+	}
+	if credited[models.CurrencyGC] != math.MaxInt64 {
+		t.Errorf("expected the GC credits to saturate, got %d", credited[models.CurrencyGC])
+	}
+}
+
+// Test PostBatch - the batch key and every leg are validated before any user is locked
+func TestPostBatch_Invalid(t *testing.T) {
+	service := &WalletService{repo: nil}
+
+	leg := models.BatchPostingLeg{UserID: 1, PostingLeg: models.PostingLeg{Currency: models.CurrencyGC, Type: models.TransactionTypeTournamentPrize, Amount: 100}}
+	reserved := models.BatchPostingLeg{UserID: 2, PostingLeg: models.PostingLeg{Currency: models.CurrencySC, Type: models.TransactionTypeWinSC, Amount: 100}}
+	bonus := models.BatchPostingLeg{UserID: 3, PostingLeg: models.PostingLeg{Currency: models.CurrencySC, Type: models.TransactionTypeBonusSC, Amount: 100}}
+	tooMany := make([]models.BatchPostingLeg, MaxPostingBatchSize+1)
+	for i := range tooMany {
+		tooMany[i] = leg
+	}
+
+	tests := []struct {
+		name string
+		legs []models.BatchPostingLeg
+		key  string
+	}{
+		{"missing key", []models.BatchPostingLeg{leg}, ""},
+		{"no legs", nil, "batch-001"},
+		{"too many legs", tooMany, "batch-001"},
+		{"reserved type", []models.BatchPostingLeg{leg, reserved}, "batch-001"},
+		{"bonus outside its campaign", []models.BatchPostingLeg{leg, bonus}, "batch-001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.PostBatch(tt.legs, tt.key)
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("expected ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+}
+
+// Test a batch key replays only for the same legs
+func TestBatchDigest(t *testing.T) {
+	leg := func(userID int, amount int64, metadata string) models.BatchPostingLeg {
+		return models.BatchPostingLeg{UserID: userID, PostingLeg: models.PostingLeg{
+			Currency: models.CurrencySC, Type: models.TransactionTypeTournamentPrize, Amount: amount, Metadata: json.RawMessage(metadata),
+		}}
+	}
+	digest := func(legs ...models.BatchPostingLeg) string {
+		d, err := batchDigest(legs)
+		if err != nil {
+			t.Fatalf("batchDigest: %v", err)
+		}
+		return d
+	}
+
+	original := digest(leg(1, 5000, `{"tournament_id": "weekly"}`), leg(7, 2500, `{"tournament_id": "weekly"}`))
+	if got := digest(leg(1, 5000, `{"tournament_id":"weekly"}`), leg(7, 2500, ` {"tournament_id": "weekly"}`)); got != original {
+		t.Error("expected whitespace in metadata not to change the digest")
+	}
+
+	changed := map[string]string{
+		"amount":   digest(leg(1, 5000, `{"tournament_id": "weekly"}`), leg(7, 250000, `{"tournament_id": "weekly"}`)),
+		"user":     digest(leg(1, 5000, `{"tournament_id": "weekly"}`), leg(8, 2500, `{"tournament_id": "weekly"}`)),
+		"order":    digest(leg(7, 2500, `{"tournament_id": "weekly"}`), leg(1, 5000, `{"tournament_id": "weekly"}`)),
+		"metadata": digest(leg(1, 5000, `{"tournament_id": "weekly"}`), leg(7, 2500, `{"tournament_id": "daily"}`)),
+		"extra":    digest(leg(1, 5000, `{"tournament_id": "weekly"}`), leg(7, 2500, `{"tournament_id": "weekly"}`), leg(9, 100, "")),
+	}
+	for name, d := range changed {
+		if d == original {
+			t.Errorf("expected a different %s to change the digest", name)
+		}
+	}
+}
+
+// Test AdjustBalance - reason, note, admin and amount are validated before any repository access
+func TestAdjustBalance_Invalid(t *testing.T) {
+	service := &WalletService{repo: nil}
+
+	tests := []struct {
+		name     string
+		currency models.Currency
+		amount   int64
+		reason   models.AdjustmentReason
+		note     string
+		admin    string
+	}{
+		{"unknown reason", models.CurrencySC, 100, "oops", "refund of stuck round", "alice"},
+		{"missing note", models.CurrencySC, 100, models.AdjustmentReasonGoodwill, "  ", "alice"},
+		{"missing admin", models.CurrencySC, 100, models.AdjustmentReasonGoodwill, "refund of stuck round", ""},
+		{"zero amount", models.CurrencySC, 0, models.AdjustmentReasonCorrection, "refund of stuck round", "alice"},
+		{"unknown currency", "XYZ", 100, models.AdjustmentReasonCorrection, "refund of stuck round", "alice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.AdjustBalance(1, tt.currency, tt.amount, tt.reason, tt.note, tt.admin, "key-001")
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("expected ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+
+	// Adjustments are reserved to their own flow
+	legs := []models.PostingLeg{{Currency: models.CurrencyGC, Type: models.TransactionTypeAdjustment, Amount: -100}}
+	if _, err := service.Post(1, legs, "key-001"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected a generic posting of an adjustment to be rejected, got %v", err)
+	}
+}
+
+// Test approval thresholds compare the size of signed amounts and 0 disables them
+func TestExceedsThreshold(t *testing.T) {
+	tests := []struct {
+		amount, threshold int64
+		expected          bool
+	}{
+		{100000, 100000, false},
+		{100001, 100000, true},
+		{-100001, 100000, true},
+		{-50, 100000, false},
+		{999999999, 0, false},
+	}
+
+	for _, tt := range tests {
+		if got := exceedsThreshold(tt.amount, tt.threshold); got != tt.expected {
+			t.Errorf("exceedsThreshold(%d, %d) = %v, expected %v", tt.amount, tt.threshold, got, tt.expected)
+		}
+	}
+}
+
+// Test bonus grants above the threshold are held for approval in their currency
+func TestBonusApproval(t *testing.T) {
+	thresholdGC := &models.ApprovalThreshold{Operation: models.ApprovalOperationBonus, Currency: models.CurrencyGC, Threshold: 1000000}
+	thresholdSC := &models.ApprovalThreshold{Operation: models.ApprovalOperationBonus, Currency: models.CurrencySC, Threshold: 100000}
+
+	// 10,000.00 SC is above the 1,000.00 SC threshold
+	request, threshold, err := bonusApproval(thresholdGC, thresholdSC, 0, 1000000)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if request == nil || threshold != thresholdSC {
+		t.Fatalf("expected the grant to be held under the SC threshold, got %+v, %+v", request, threshold)
+	}
+	if request.Operation != models.ApprovalOperationBonus || request.Currency != models.CurrencySC || request.Amount != 1000000 {
+		t.Errorf("unexpected approval request %+v", request)
+	}
+
+	request, threshold, err = bonusApproval(thresholdGC, thresholdSC, 2000000, 0)
+	if err != nil || request == nil || threshold != thresholdGC || request.Currency != models.CurrencyGC {
+		t.Errorf("expected the GC grant to be held, got %+v, %+v, %v", request, threshold, err)
+	}
+
+	// At the threshold, without one, or with approval disabled, grants post now
+	for _, tt := range []struct {
+		gc, sc   *models.ApprovalThreshold
+		amountSC int64
+	}{
+		{thresholdGC, thresholdSC, 100000},
+		{thresholdGC, nil, 1000000},
+		{thresholdGC, &models.ApprovalThreshold{Currency: models.CurrencySC}, 1000000},
+	} {
+		if request, _, err := bonusApproval(tt.gc, tt.sc, 5000, tt.amountSC); request != nil || err != nil {
+			t.Errorf("expected %d SC to post without approval, got %+v, %v", tt.amountSC, request, err)
+		}
+	}
+
+	// A held request has one currency, so a large grant cannot mix GC and SC
+	if _, _, err := bonusApproval(thresholdGC, thresholdSC, 5000, 1000000); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for a mixed grant above the threshold, got %v", err)
+	}
+}
+
+// Test approval thresholds and decisions are validated before any repository access
+func TestApprovalValidation(t *testing.T) {
+	service := &WalletService{repo: nil}
+
+	thresholds := []models.ApprovalThreshold{
+		{Operation: "transfer", Currency: models.CurrencySC, Threshold: 100, ExpiresAfterHours: 24},
+		{Operation: models.ApprovalOperationAdjustment, Currency: "XYZ", Threshold: 100, ExpiresAfterHours: 24},
+		{Operation: models.ApprovalOperationRedemption, Currency: models.CurrencyGC, Threshold: 100, ExpiresAfterHours: 24},
+		{Operation: models.ApprovalOperationAdjustment, Currency: models.CurrencySC, Threshold: -1, ExpiresAfterHours: 24},
+		{Operation: models.ApprovalOperationAdjustment, Currency: models.CurrencySC, Threshold: 100, ExpiresAfterHours: 0},
+		{Operation: models.ApprovalOperationBonus, Currency: "XYZ", Threshold: 100, ExpiresAfterHours: 24},
+	}
+	for i, threshold := range thresholds {
+		if err := service.SaveApprovalThreshold(&threshold); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("threshold %d: expected ErrInvalidInput, got %v", i, err)
+		}
+	}
+
+	if _, err := service.ApproveRequest(1, " ", ""); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for a missing admin, got %v", err)
+	}
+
+	var err error = &ApprovalRequiredError{Request: &models.ApprovalRequest{ID: 7, Status: models.ApprovalStatusPending}}
+	if !errors.Is(err, ErrApprovalRequired) || err.Error() != "approval required: request 7 is pending" {
+		t.Errorf("unexpected approval error %v", err)
+	}
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+	"wallet-ledger/models"
+)
+
+// limitCoolingOff is how long a player waits before a raised or removed
+// responsible-gaming limit takes effect
+const limitCoolingOff = 24 * time.Hour
+
+// GetSpendLimits retrieves a user's spend limits for every period together
+// with the operator caps and what was spent under them
+func (s *WalletService) GetSpendLimits(userID int) ([]models.SpendLimitStatus, error) {
+	// Verify user exists
+	_, err := s.repo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults, err := s.repo.GetSpendLimitDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.repo.BeginTx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	limits, err := s.repo.GetSpendLimitsTx(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	statuses := make([]models.SpendLimitStatus, 0, len(models.SpendLimitPeriods))
+	for _, period := range models.SpendLimitPeriods {
+		limit := userSpendLimit(limits, userID, period)
+		limit.ApplyDue(now)
+
+		spent, err := s.repo.GetPurchaseSpendTx(tx, userID, now.Add(-period.Window()))
+		if err != nil {
+			return nil, err
+		}
+
+		status := models.SpendLimitStatus{
+			SpendLimit:          *limit,
+			OperatorCapCents:    defaults.Cap(period),
+			EffectiveLimitCents: effectiveSpendLimit(limit.LimitCents, defaults.Cap(period)),
+			SpentCents:          spent,
+		}
+		if status.EffectiveLimitCents > 0 {
+			remaining := max(status.EffectiveLimitCents-spent, 0)
+			status.RemainingCents = &remaining
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// SetSpendLimit changes a user's own spend limit for a period. Lowering a
+// limit (or setting one where there was none) applies immediately; raising or
+// removing one (0) only applies after the cooling-off period. Every request is
+// recorded in the user's spend limit history.
+func (s *WalletService) SetSpendLimit(userID int, period models.SpendLimitPeriod, limitCents int64) (*models.SpendLimit, error) {
+	if period.Window() == 0 {
+		return nil, fmt.Errorf("period must be daily, weekly or monthly: %w", ErrInvalidInput)
+	}
+	if limitCents < 0 {
+		return nil, fmt.Errorf("limit_cents cannot be negative: %w", ErrInvalidInput)
+	}
+
+	// Serialize all operations for this user
+	s.lockUser(userID)
+	defer s.unlockUser(userID)
+
+	// Verify user exists
+	_, err := s.repo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transaction
+	tx, err := s.repo.BeginTx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	limits, err := s.repo.GetSpendLimitsTx(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	limit := userSpendLimit(limits, userID, period)
+	limit.ApplyDue(now)
+
+	change := &models.SpendLimitChange{
+		UserID:        userID,
+		Period:        period,
+		OldLimitCents: limit.LimitCents,
+		NewLimitCents: limitCents,
+		EffectiveAt:   now,
+	}
+
+	if isLimitIncrease(limit.LimitCents, limitCents) {
+		effectiveAt := now.Add(limitCoolingOff)
+		limit.PendingLimitCents = &limitCents
+		limit.PendingEffectiveAt = &effectiveAt
+		change.EffectiveAt = effectiveAt
+	} else {
+		// A tighter (or unchanged) limit also cancels any pending increase
+		limit.LimitCents = limitCents
+		limit.PendingLimitCents = nil
+		limit.PendingEffectiveAt = nil
+	}
+
+	if err := s.repo.SaveSpendLimitTx(tx, limit); err != nil {
+		return nil, err
+	}
+	if err := s.repo.AddSpendLimitChange(tx, change); err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return limit, nil
+}
+
+// ListSpendLimitHistory retrieves every spend limit change a user requested, newest first
+func (s *WalletService) ListSpendLimitHistory(userID int) ([]models.SpendLimitChange, error) {
+	// Verify user exists
+	_, err := s.repo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListSpendLimitChanges(userID)
+}
+
+// GetSpendLimitDefaults retrieves the operator-wide spend caps
+func (s *WalletService) GetSpendLimitDefaults() (*models.SpendLimitDefaults, error) {
+	return s.repo.GetSpendLimitDefaults()
+}
+
+// SaveSpendLimitDefaults changes the operator-wide spend caps; they apply to
+// every player immediately
+func (s *WalletService) SaveSpendLimitDefaults(defaults *models.SpendLimitDefaults) error {
+	if defaults.DailyCents < 0 || defaults.WeeklyCents < 0 || defaults.MonthlyCents < 0 {
+		return fmt.Errorf("caps cannot be negative: %w", ErrInvalidInput)
+	}
+	return s.repo.SaveSpendLimitDefaults(defaults)
+}
+
+// checkSpendLimits rejects a purchase of priceCents that would take the user
+// over any effective spend limit. The caller must hold the user lock.
+func (s *WalletService) checkSpendLimits(tx *sql.Tx, userID int, priceCents int64, now time.Time) error {
+	defaults, err := s.repo.GetSpendLimitDefaults()
+	if err != nil {
+		return err
+	}
+
+	limits, err := s.repo.GetSpendLimitsTx(tx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, period := range models.SpendLimitPeriods {
+		limit := userSpendLimit(limits, userID, period)
+		limit.ApplyDue(now)
+
+		effective := effectiveSpendLimit(limit.LimitCents, defaults.Cap(period))
+		if effective == 0 {
+			continue
+		}
+
+		spent, err := s.repo.GetPurchaseSpendTx(tx, userID, now.Add(-period.Window()))
+		if err != nil {
+			return err
+		}
+		if spent+priceCents > effective {
+			return fmt.Errorf("%w: %s limit of $%s has $%s remaining", ErrSpendLimitExceeded, period,
+				models.FormatAmount(effective, models.USDMinorUnits), models.FormatAmount(max(effective-spent, 0), models.USDMinorUnits))
+		}
+	}
+	return nil
+}
+
+// userSpendLimit returns the user's limit for a period, or an empty one if
+// the user never set it
+func userSpendLimit(limits map[models.SpendLimitPeriod]*models.SpendLimit, userID int, period models.SpendLimitPeriod) *models.SpendLimit {
+	if l, ok := limits[period]; ok {
+		return l
+	}
+	return &models.SpendLimit{UserID: userID, Period: period}
+}
+
+// effectiveSpendLimit returns the lower of a player limit and an operator cap,
+// where 0 means no limit
+func effectiveSpendLimit(userLimit, operatorCap int64) int64 {
+	if userLimit == 0 {
+		return operatorCap
+	}
+	if operatorCap == 0 {
+		return userLimit
+	}
+	return min(userLimit, operatorCap)
+}
+
+// isLimitIncrease reports whether going from old to new loosens a
+// responsible-gaming limit, where 0 means no limit
+func isLimitIncrease(old, new int64) bool {
+	if old == 0 {
+		return false
+	}
+	return new == 0 || new > old
+}
@@ -0,0 +1,460 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+	"wallet-ledger/models"
+	"wallet-ledger/repository"
+)
+
+// TournamentMetadataKey tags a wager's metadata with the tournament it is scored in
+const TournamentMetadataKey = "tournament_id"
+
+// tournamentSettleAttempts bounds how often settlement restarts when the
+// leaders change between reading the leaderboard and locking the winners
+const tournamentSettleAttempts = 3
+
+// ListTournaments retrieves all tournaments
+func (s *WalletService) ListTournaments() ([]models.Tournament, error) {
+	return s.repo.ListTournaments()
+}
+
+// GetTournament retrieves a single tournament
+func (s *WalletService) GetTournament(tournamentID string) (*models.Tournament, error) {
+	return s.repo.GetTournament(tournamentID)
+}
+
+// SaveTournament creates or replaces a tournament that has not been settled
+// yet. Once anyone has entered, its currency, entry fee and scoring are fixed.
+func (s *WalletService) SaveTournament(t *models.Tournament) error {
+	if err := s.validateTournament(t); err != nil {
+		return err
+	}
+
+	// Start transaction
+	tx, err := s.repo.BeginTx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Lock the tournament so no one joins under the old terms while they change
+	existing, err := s.repo.GetTournamentTx(tx, t.ID, true)
+	if err != nil && !errors.Is(err, ErrTournamentNotFound) {
+		return err
+	}
+	if existing != nil {
+		if existing.SettledAt != nil {
+			return fmt.Errorf("tournament %s is already settled: %w", t.ID, ErrInvalidInput)
+		}
+		entered, err := s.repo.HasTournamentEntriesTx(tx, t.ID)
+		if err != nil {
+			return err
+		}
+		if entered {
+			if err := checkTournamentTerms(existing, t); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := s.repo.SaveTournament(tx, t); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit()
+}
+
+// JoinTournament enters a user into a tournament, charging the entry fee once.
+// Joining again returns the existing entry without charging. Self-excluded
+// players cannot enter, and the entry fee counts as a stake against the
+// player's wager limits.
+func (s *WalletService) JoinTournament(tournamentID string, userID int) (*models.TournamentEntry, error) {
+	// Serialize all operations for this user
+	s.lockUser(userID)
+	defer s.unlockUser(userID)
+
+	// Verify user exists
+	_, err := s.repo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transaction
+	tx, err := s.repo.BeginTx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Lock the tournament so its terms cannot change while the entry is charged
+	t, err := s.repo.GetTournamentTx(tx, tournamentID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Already entered, return existing entry
+	entry, err := s.repo.GetTournamentEntryTx(tx, tournamentID, userID)
+	if err == nil {
+		tx.Commit()
+		entry.Currency = t.Currency
+		return entry, nil
+	}
+	if !errors.Is(err, repository.ErrTournamentEntryNotFound) {
+		return nil, err
+	}
+
+	// Entries are accepted until the tournament ends
+	now := time.Now()
+	if t.SettledAt != nil || !now.Before(t.EndsAt) {
+		return nil, fmt.Errorf("%w: %s has ended", ErrTournamentClosed, tournamentID)
+	}
+
+	if err := s.checkSelfExclusion(tx, userID, now); err != nil {
+		return nil, err
+	}
+	if err := s.checkWagerLimits(tx, userID, entryFeeLegs(t), now); err != nil {
+		return nil, err
+	}
+
+	entry = &models.TournamentEntry{
+		TournamentID: tournamentID,
+		UserID:       userID,
+		Currency:     t.Currency,
+	}
+
+	if t.EntryFee > 0 {
+		balance, err := s.repo.GetCurrentBalance(tx, userID, t.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		if balance < t.EntryFee {
+			return nil, fmt.Errorf("%w: %s - have %s, need %s", ErrInsufficientFunds, t.Currency,
+				models.FormatAmount(balance, t.Currency.MinorUnits()), models.FormatAmount(t.EntryFee, t.Currency.MinorUnits()))
+		}
+
+		metadataJSON, _ := json.Marshal(map[string]interface{}{
+			"tournament_id": tournamentID,
+		})
+
+		feeTx := &models.Transaction{
+			UserID:       userID,
+			Currency:     t.Currency,
+			Type:         models.TransactionTypeTournamentEntry,
+			Amount:       t.EntryFee,
+			BalanceAfter: models.TransactionTypeTournamentEntry.Apply(balance, t.EntryFee),
+			Metadata:     metadataJSON,
+		}
+		err = s.repo.CreateTransaction(tx, feeTx)
+		if err != nil {
+			return nil, err
+		}
+		entry.EntryTransactionID = &feeTx.ID
+
+		if err := s.repo.AddWagerTotalsTx(tx, userID, t.Currency, wagerDay(now), t.EntryFee, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.repo.CreateTournamentEntry(tx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// GetLeaderboard retrieves the top entries of a tournament. Prizes are projected
+// from the current ranks until the tournament is settled.
+func (s *WalletService) GetLeaderboard(tournamentID string, limit int) (*models.Leaderboard, error) {
+	t, err := s.repo.GetTournament(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.repo.ListTournamentEntries(tournamentID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range entries {
+		entries[i].Currency = t.Currency
+		entries[i].Rank = i + 1
+		if t.SettledAt == nil {
+			entries[i].Prize = t.Prize(entries[i].Rank)
+		}
+	}
+
+	return &models.Leaderboard{
+		Tournament: t,
+		Entries:    entries,
+	}, nil
+}
+
+// SettleTournament pays the prizes of an ended tournament to its leaders in a
+// single DB transaction. Settling an already settled tournament returns the
+// original results without paying again.
+func (s *WalletService) SettleTournament(tournamentID string) (*models.Leaderboard, error) {
+	for attempt := 0; attempt < tournamentSettleAttempts; attempt++ {
+		t, err := s.repo.GetTournament(tournamentID)
+		if err != nil {
+			return nil, err
+		}
+		if t.SettledAt != nil {
+			return s.GetLeaderboard(tournamentID, len(t.Prizes))
+		}
+		if time.Now().Before(t.EndsAt) {
+			return nil, fmt.Errorf("%w: %s ends at %s", ErrTournamentNotEnded, tournamentID, t.EndsAt.Format(time.RFC3339))
+		}
+
+		leaders, err := s.repo.ListTournamentEntries(tournamentID, len(t.Prizes))
+		if err != nil {
+			return nil, err
+		}
+		userIDs := make([]int, len(leaders))
+		for i, e := range leaders {
+			userIDs[i] = e.UserID
+		}
+
+		board, settled, err := s.settleTournament(tournamentID, userIDs)
+		if err != nil || settled {
+			return board, err
+		}
+	}
+
+	return nil, fmt.Errorf("leaderboard of tournament %s kept changing during settlement", tournamentID)
+}
+
+// settleTournament pays the prizes while holding the locks of userIDs. It reports
+// settled = false, without paying anything, if the leaders are no longer a
+// subset of userIDs.
+func (s *WalletService) settleTournament(tournamentID string, userIDs []int) (*models.Leaderboard, bool, error) {
+	// Serialize with all other operations of the prize winners
+	unlock := s.lockUsers(userIDs)
+	defer unlock()
+
+	locked := make(map[int]bool, len(userIDs))
+	for _, id := range userIDs {
+		locked[id] = true
+	}
+
+	// Start transaction
+	tx, err := s.repo.BeginTx()
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback()
+
+	// Lock the tournament so it is settled at most once
+	t, err := s.repo.GetTournamentTx(tx, tournamentID, true)
+	if err != nil {
+		return nil, false, err
+	}
+	if t.SettledAt != nil {
+		tx.Commit()
+		board, err := s.GetLeaderboard(tournamentID, len(t.Prizes))
+		return board, true, err
+	}
+	if time.Now().Before(t.EndsAt) {
+		return nil, false, fmt.Errorf("%w: %s ends at %s", ErrTournamentNotEnded, tournamentID, t.EndsAt.Format(time.RFC3339))
+	}
+
+	// Locking the leaders waits for wagers still scoring them to finish
+	leaders, err := s.repo.LockTournamentLeadersTx(tx, tournamentID, len(t.Prizes))
+	if err != nil {
+		return nil, false, err
+	}
+	for _, e := range leaders {
+		if !locked[e.UserID] {
+			return nil, false, nil
+		}
+	}
+
+	for i := range leaders {
+		e := &leaders[i]
+		e.Currency = t.Currency
+		e.Rank = i + 1
+		prize := t.Prize(e.Rank)
+		if prize <= 0 {
+			continue
+		}
+
+		balance, err := s.repo.GetCurrentBalance(tx, e.UserID, t.Currency)
+		if err != nil {
+			return nil, false, err
+		}
+
+		metadataJSON, _ := json.Marshal(map[string]interface{}{
+			"tournament_id": tournamentID,
+			"rank":          e.Rank,
+		})
+
+		prizeTx := &models.Transaction{
+			UserID:       e.UserID,
+			Currency:     t.Currency,
+			Type:         models.TransactionTypeTournamentPrize,
+			Amount:       prize,
+			BalanceAfter: models.TransactionTypeTournamentPrize.Apply(balance, prize),
+			Metadata:     metadataJSON,
+		}
+		err = s.repo.CreateTransaction(tx, prizeTx)
+		if err != nil {
+			return nil, false, err
+		}
+
+		err = s.repo.SetTournamentPrize(tx, tournamentID, e.UserID, prize, prizeTx.ID)
+		if err != nil {
+			return nil, false, err
+		}
+		e.Prize = prize
+		e.PrizeTransactionID = &prizeTx.ID
+	}
+
+	err = s.repo.MarkTournamentSettled(tx, t)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &models.Leaderboard{
+		Tournament: t,
+		Entries:    leaders,
+	}, true, nil
+}
+
+// scoreTournamentWager updates the user's tournament score from the rows of a
+// tagged wager, within the wager's DB transaction
+func (s *WalletService) scoreTournamentWager(tx *sql.Tx, tournamentID string, userID int, transactions []*models.Transaction) error {
+	t, err := s.repo.GetTournamentTx(tx, tournamentID, false)
+	if err != nil {
+		return err
+	}
+
+	entry, err := s.repo.GetTournamentEntryTx(tx, tournamentID, userID)
+	if errors.Is(err, repository.ErrTournamentEntryNotFound) {
+		return fmt.Errorf("%w: %s", ErrTournamentNotEntered, tournamentID)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Checked after locking the entry, so settlement never misses a score
+	now := time.Now()
+	if !t.IsRunning(now) {
+		return fmt.Errorf("%w: %s is not running", ErrTournamentClosed, tournamentID)
+	}
+
+	var stake, payout int64
+	for _, tr := range transactions {
+		if tr.Currency != t.Currency {
+			continue
+		}
+		switch {
+		case tr.Type.IsStake():
+			stake += tr.Amount
+		case tr.Type.IsPayout():
+			payout += tr.Amount
+		}
+	}
+
+	score := tournamentScore(t.Scoring, entry.Score, stake, payout)
+	if score == entry.Score {
+		return nil
+	}
+	return s.repo.UpdateTournamentScore(tx, tournamentID, userID, score, now)
+}
+
+// tournamentScore returns an entry's new score after a wager
+func tournamentScore(scoring models.TournamentScoring, current, stake, payout int64) int64 {
+	switch scoring {
+	case models.TournamentScoringTotalWagered:
+		return current + stake
+	case models.TournamentScoringBiggestMultiplier:
+		if stake > 0 {
+			if multiplier := payout * 100 / stake; multiplier > current {
+				return multiplier
+			}
+		}
+	}
+	return current
+}
+
+// tournamentFromMetadata returns the tournament a wager is tagged with, if any
+func tournamentFromMetadata(metadata map[string]interface{}) (string, error) {
+	value, ok := metadata[TournamentMetadataKey]
+	if !ok {
+		return "", nil
+	}
+	tournamentID, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("%s must be a string: %w", TournamentMetadataKey, ErrInvalidInput)
+	}
+	return tournamentID, nil
+}
+
+// validateTournament checks a tournament definition before it is saved
+func (s *WalletService) validateTournament(t *models.Tournament) error {
+	if t.ID == "" || len(t.ID) > 64 {
+		return fmt.Errorf("tournament id must be 1-64 characters: %w", ErrInvalidInput)
+	}
+	if t.Name == "" {
+		return fmt.Errorf("name is required: %w", ErrInvalidInput)
+	}
+	if err := s.validateCurrency(t.Currency, true); err != nil {
+		return err
+	}
+	if t.EntryFee < 0 {
+		return fmt.Errorf("entry_fee cannot be negative: %w", ErrInvalidInput)
+	}
+	if !t.Scoring.IsValid() {
+		return fmt.Errorf("invalid scoring %q: must be total_wagered or biggest_multiplier: %w", t.Scoring, ErrInvalidInput)
+	}
+	if t.StartsAt.IsZero() || !t.EndsAt.After(t.StartsAt) {
+		return fmt.Errorf("ends_at must be after starts_at: %w", ErrInvalidInput)
+	}
+	for _, prize := range t.Prizes {
+		if prize < 0 {
+			return fmt.Errorf("prizes cannot be negative: %w", ErrInvalidInput)
+		}
+	}
+	if t.Prizes == nil {
+		t.Prizes = []int64{}
+	}
+	return nil
+}
+
+// checkTournamentTerms refuses changes to the terms players entered under:
+// the currency, the entry fee they paid and how they are scored
+func checkTournamentTerms(existing, t *models.Tournament) error {
+	switch {
+	case t.Currency != existing.Currency:
+		return fmt.Errorf("tournament %s has entries; its currency cannot change: %w", t.ID, ErrInvalidInput)
+	case t.EntryFee != existing.EntryFee:
+		return fmt.Errorf("tournament %s has entries; its entry fee cannot change: %w", t.ID, ErrInvalidInput)
+	case t.Scoring != existing.Scoring:
+		return fmt.Errorf("tournament %s has entries; its scoring cannot change: %w", t.ID, ErrInvalidInput)
+	}
+	return nil
+}
+
+// entryFeeLegs returns a tournament's entry fee as the stake it is checked
+// against wager limits as
+func entryFeeLegs(t *models.Tournament) []models.WagerLeg {
+	return []models.WagerLeg{{Currency: t.Currency, Stake: t.EntryFee}}
+}
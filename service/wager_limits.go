@@ -0,0 +1,276 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+	"wallet-ledger/models"
+)
+
+// WagerLimitError reports a wager rejected by a wager or loss limit, with the
+// allowance left so game clients can show it
+type WagerLimitError struct {
+	Currency  models.Currency
+	Kind      models.WagerLimitKind
+	Limit     int64
+	Remaining int64 // largest stake that would still be accepted
+}
+
+func (e *WagerLimitError) Error() string {
+	scale := e.Currency.MinorUnits()
+	return fmt.Sprintf("%s: %s %s limit of %s has %s remaining", ErrWagerLimitExceeded, e.Currency, e.Kind,
+		models.FormatAmount(e.Limit, scale), models.FormatAmount(e.Remaining, scale))
+}
+
+func (e *WagerLimitError) Unwrap() error {
+	return ErrWagerLimitExceeded
+}
+
+// GetWagerLimits retrieves a user's wager limits for every currency and kind
+// together with today's usage
+func (s *WalletService) GetWagerLimits(userID int) ([]models.WagerLimitStatus, error) {
+	// Verify user exists
+	_, err := s.repo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.repo.BeginTx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	limits, err := s.wagerLimits(tx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := s.repo.GetWagerTotalsTx(tx, userID, wagerDay(now))
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]models.WagerLimitStatus, 0, len(limits))
+	for _, c := range s.ListCurrencies() {
+		if !c.Wagerable {
+			continue
+		}
+		currency := c.Code
+		for _, kind := range models.WagerLimitKinds {
+			limit := limits[wagerLimitKey{currency, kind}]
+			status := models.WagerLimitStatus{WagerLimit: *limit}
+
+			switch kind {
+			case models.WagerLimitDailyWagered:
+				status.Used = totals[currency].Wagered
+			case models.WagerLimitDailyLoss:
+				status.Used = totals[currency].NetLoss()
+			}
+			if limit.Limit > 0 {
+				remaining := max(limit.Limit-status.Used, 0)
+				status.Remaining = &remaining
+			}
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses, nil
+}
+
+// SetWagerLimit changes one of a user's wager limits. Lowering a limit (or
+// setting one where there was none) applies immediately; raising or removing
+// one (0) only applies after the cooling-off period. Every request is recorded
+// in the user's wager limit history.
+func (s *WalletService) SetWagerLimit(userID int, currency models.Currency, kind models.WagerLimitKind, limitAmount int64) (*models.WagerLimit, error) {
+	if err := s.validateCurrency(currency, true); err != nil {
+		return nil, err
+	}
+	if !kind.IsValid() {
+		return nil, fmt.Errorf("kind must be max_stake, daily_wagered or daily_loss: %w", ErrInvalidInput)
+	}
+	if limitAmount < 0 {
+		return nil, fmt.Errorf("limit cannot be negative: %w", ErrInvalidInput)
+	}
+
+	// Serialize all operations for this user
+	s.lockUser(userID)
+	defer s.unlockUser(userID)
+
+	// Verify user exists
+	_, err := s.repo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transaction
+	tx, err := s.repo.BeginTx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	limits, err := s.wagerLimits(tx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	limit := limits[wagerLimitKey{currency, kind}]
+
+	change := &models.WagerLimitChange{
+		UserID:      userID,
+		Currency:    currency,
+		Kind:        kind,
+		OldLimit:    limit.Limit,
+		NewLimit:    limitAmount,
+		EffectiveAt: now,
+	}
+
+	if isLimitIncrease(limit.Limit, limitAmount) {
+		effectiveAt := now.Add(limitCoolingOff)
+		limit.PendingLimit = &limitAmount
+		limit.PendingEffectiveAt = &effectiveAt
+		change.EffectiveAt = effectiveAt
+	} else {
+		// A tighter (or unchanged) limit also cancels any pending increase
+		limit.Limit = limitAmount
+		limit.PendingLimit = nil
+		limit.PendingEffectiveAt = nil
+	}
+
+	if err := s.repo.SaveWagerLimitTx(tx, limit); err != nil {
+		return nil, err
+	}
+	if err := s.repo.AddWagerLimitChange(tx, change); err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return limit, nil
+}
+
+// ListWagerLimitHistory retrieves every wager limit change a user requested, newest first
+func (s *WalletService) ListWagerLimitHistory(userID int) ([]models.WagerLimitChange, error) {
+	// Verify user exists
+	_, err := s.repo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListWagerLimitChanges(userID)
+}
+
+// checkWagerLimits rejects stakes that would break any of the user's wager
+// limits. The caller must hold the user lock, which together with tx keeps
+// the running totals from moving underneath the check.
+func (s *WalletService) checkWagerLimits(tx *sql.Tx, userID int, legs []models.WagerLeg, now time.Time) error {
+	if !wagerStaked(legs) {
+		return nil
+	}
+
+	limits, err := s.wagerLimits(tx, userID, now)
+	if err != nil {
+		return err
+	}
+	totals, err := s.repo.GetWagerTotalsTx(tx, userID, wagerDay(now))
+	if err != nil {
+		return err
+	}
+
+	for _, l := range legs {
+		currency, stake := l.Currency, l.Stake
+		if stake == 0 {
+			continue
+		}
+		active := make(map[models.WagerLimitKind]int64, len(models.WagerLimitKinds))
+		for _, kind := range models.WagerLimitKinds {
+			active[kind] = limits[wagerLimitKey{currency, kind}].Limit
+		}
+		if err := checkWagerLimit(currency, active, totals[currency], stake); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordWagerTotals adds newly written wager and win rows to today's running totals
+func (s *WalletService) recordWagerTotals(tx *sql.Tx, userID int, transactions []*models.Transaction, now time.Time) error {
+	totals := wagerTotals(transactions)
+	for _, c := range s.ListCurrencies() {
+		currency := c.Code
+		t := totals[currency]
+		if t.Wagered == 0 && t.Won == 0 {
+			continue
+		}
+		if err := s.repo.AddWagerTotalsTx(tx, userID, currency, wagerDay(now), t.Wagered, t.Won); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// wagerTotals sums the stakes and payouts among transactions per currency
+func wagerTotals(transactions []*models.Transaction) map[models.Currency]models.WagerTotals {
+	totals := make(map[models.Currency]models.WagerTotals)
+	for _, t := range transactions {
+		total := totals[t.Currency]
+		switch {
+		case t.Type.IsStake():
+			total.Wagered += t.Amount
+		case t.Type.IsPayout():
+			total.Won += t.Amount
+		}
+		totals[t.Currency] = total
+	}
+	return totals
+}
+
+type wagerLimitKey struct {
+	currency models.Currency
+	kind     models.WagerLimitKind
+}
+
+// wagerLimits returns every wager limit of a user, with due pending limits
+// applied and empty limits for those the user never set
+func (s *WalletService) wagerLimits(tx *sql.Tx, userID int, now time.Time) (map[wagerLimitKey]*models.WagerLimit, error) {
+	stored, err := s.repo.GetWagerLimitsTx(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := make(map[wagerLimitKey]*models.WagerLimit)
+	for _, c := range s.ListCurrencies() {
+		for _, kind := range models.WagerLimitKinds {
+			limits[wagerLimitKey{c.Code, kind}] = &models.WagerLimit{UserID: userID, Currency: c.Code, Kind: kind}
+		}
+	}
+	for _, l := range stored {
+		l.ApplyDue(now)
+		limits[wagerLimitKey{l.Currency, l.Kind}] = l
+	}
+	return limits, nil
+}
+
+// checkWagerLimit checks a stake against one currency's limits and today's
+// totals. The daily loss limit assumes the stake is lost, since a round's
+// payout may arrive in a later call.
+func checkWagerLimit(currency models.Currency, limits map[models.WagerLimitKind]int64, totals models.WagerTotals, stake int64) error {
+	if limit := limits[models.WagerLimitMaxStake]; limit > 0 && stake > limit {
+		return &WagerLimitError{Currency: currency, Kind: models.WagerLimitMaxStake, Limit: limit, Remaining: limit}
+	}
+	if limit := limits[models.WagerLimitDailyWagered]; limit > 0 && totals.Wagered+stake > limit {
+		return &WagerLimitError{Currency: currency, Kind: models.WagerLimitDailyWagered, Limit: limit, Remaining: max(limit-totals.Wagered, 0)}
+	}
+	if limit := limits[models.WagerLimitDailyLoss]; limit > 0 && totals.NetLoss()+stake > limit {
+		return &WagerLimitError{Currency: currency, Kind: models.WagerLimitDailyLoss, Limit: limit, Remaining: max(limit-totals.NetLoss(), 0)}
+	}
+	return nil
+}
+
+// wagerDay returns the UTC day running wager totals are kept under
+func wagerDay(t time.Time) string {
+	return t.UTC().Format(dateLayout)
+}
@@ -3,6 +3,7 @@ Write-Host "=== Concurrency Test Report ===" -ForegroundColor Cyan
 Write-Host ""
 
 $baseUrl = "http://localhost:8080"
+$headers = @{ "X-API-Key" = "dev-admin-key-change-me-0123456789abcdef" }  # development key from docker-compose.yml
 
 # TEST 1: Idempotency Race Condition
 Write-Host "[TEST 1] Idempotency Race Condition Test" -ForegroundColor Yellow
@@ -13,16 +14,16 @@ Write-Host ""
 $jobs = @()
 1..10 | ForEach-Object {
     $jobs += Start-Job -ScriptBlock {
-        param($url)
+        param($url, $headers)
         try {
             $body = '{"package_code":"starter_10k","idempotency_key":"race-test-001"}'
-            $response = Invoke-RestMethod -Uri "$url/users/1/purchase" -Method Post -Body $body -ContentType "application/json" -ErrorAction Stop
+            $response = Invoke-RestMethod -Uri "$url/users/1/purchase" -Headers $headers -Method Post -Body $body -ContentType "application/json" -ErrorAction Stop
             $ids = ($response | ForEach-Object { $_.id }) -join ","
             return "SUCCESS:$ids"
         } catch {
             return "ERROR"
         }
-    } -ArgumentList $baseUrl
+    } -ArgumentList $baseUrl, $headers
 }
 
 $results = $jobs | Wait-Job | Receive-Job
@@ -56,15 +57,15 @@ $startTime = Get-Date
 1..30 | ForEach-Object {
     $num = $_
     $jobs += Start-Job -ScriptBlock {
-        param($url, $n)
+        param($url, $n, $headers)
         try {
-            $body = "{`"stake_gc`":100,`"payout_gc`":50,`"idempotency_key`":`"deadlock-test-$n`"}"
-            Invoke-RestMethod -Uri "$url/users/1/wager" -Method Post -Body $body -ContentType "application/json" -ErrorAction Stop | Out-Null
+            $body = "{`"game_id`":`"demo_slots`",`"stake_gc`":100,`"payout_gc`":50,`"idempotency_key`":`"deadlock-test-$n`"}"
+            Invoke-RestMethod -Uri "$url/users/1/wager" -Headers $headers -Method Post -Body $body -ContentType "application/json" -ErrorAction Stop | Out-Null
             return "SUCCESS"
         } catch {
             return "ERROR"
         }
-    } -ArgumentList $baseUrl, $num
+    } -ArgumentList $baseUrl, $num, $headers
 }
 
 $results = $jobs | Wait-Job | Receive-Job
@@ -101,30 +102,30 @@ $jobs = @()
 1..5 | ForEach-Object {
     $num = $_
     $jobs += Start-Job -ScriptBlock {
-        param($url, $n)
+        param($url, $n, $headers)
         try {
             $body = "{`"package_code`":`"starter_10k`",`"idempotency_key`":`"contention-purchase-$n`"}"
-            Invoke-RestMethod -Uri "$url/users/1/purchase" -Method Post -Body $body -ContentType "application/json" -ErrorAction Stop | Out-Null
+            Invoke-RestMethod -Uri "$url/users/1/purchase" -Headers $headers -Method Post -Body $body -ContentType "application/json" -ErrorAction Stop | Out-Null
             return "Purchase"
         } catch {
             return "Purchase-FAIL"
         }
-    } -ArgumentList $baseUrl, $num
+    } -ArgumentList $baseUrl, $num, $headers
 }
 
 # 10 wagers
 1..10 | ForEach-Object {
     $num = $_
     $jobs += Start-Job -ScriptBlock {
-        param($url, $n)
+        param($url, $n, $headers)
         try {
-            $body = "{`"stake_gc`":10,`"payout_gc`":20,`"idempotency_key`":`"contention-wager-$n`"}"
-            Invoke-RestMethod -Uri "$url/users/1/wager" -Method Post -Body $body -ContentType "application/json" -ErrorAction Stop | Out-Null
+            $body = "{`"game_id`":`"demo_slots`",`"stake_gc`":10,`"payout_gc`":20,`"idempotency_key`":`"contention-wager-$n`"}"
+            Invoke-RestMethod -Uri "$url/users/1/wager" -Headers $headers -Method Post -Body $body -ContentType "application/json" -ErrorAction Stop | Out-Null
             return "Wager"
         } catch {
             return "Wager-FAIL"
         }
-    } -ArgumentList $baseUrl, $num
+    } -ArgumentList $baseUrl, $num, $headers
 }
 
 $results = $jobs | Wait-Job | Receive-Job
@@ -148,7 +149,7 @@ Write-Host ""
 
 # Final Balance Check
 Write-Host "[VERIFICATION] Final Balance Consistency" -ForegroundColor Yellow
-$user = Invoke-RestMethod -Uri "$baseUrl/users/1" -Method Get
+$user = Invoke-RestMethod -Uri "$baseUrl/users/1" -Headers $headers -Method Get
 
 Write-Host "  Gold Coins: $($user.gold_balance)"
 Write-Host "  Sweep Coins: $($user.sweeps_balance)"
@@ -1,6 +1,9 @@
 # PowerShell Test Script for Wallet Transaction Ledger Service
 # Run this script after starting the service with: docker-compose up -d
 
+# Send the development key from docker-compose.yml with every request
+$PSDefaultParameterValues['Invoke-RestMethod:Headers'] = @{ "X-API-Key" = "dev-admin-key-change-me-0123456789abcdef" }
+
 Write-Host "=== Wallet Transaction Ledger Service - PowerShell Test Script ===" -ForegroundColor Cyan
 Write-Host ""
 
@@ -44,43 +47,43 @@ Write-Host ""
 
 # Wager Gold Coins (Win)
 Write-Host "8. Wager Gold Coins (Win: stake 500, payout 900)" -ForegroundColor Yellow
-$body = @{ stake_gc = 500; payout_gc = 900; idempotency_key = "wager-gc-win-001" } | ConvertTo-Json
+$body = @{ game_id = "demo_slots"; stake_gc = 500; payout_gc = 900; idempotency_key = "wager-gc-win-001" } | ConvertTo-Json
 Invoke-RestMethod -Uri "http://localhost:8080/users/1/wager" -Method Post -Body $body -ContentType "application/json"
 Write-Host ""
 
 # Wager Gold Coins (Lose)
 Write-Host "9. Wager Gold Coins (Lose: stake 1000, payout 0)" -ForegroundColor Yellow
-$body = @{ stake_gc = 1000; payout_gc = 0; idempotency_key = "wager-gc-lose-001" } | ConvertTo-Json
+$body = @{ game_id = "demo_slots"; stake_gc = 1000; payout_gc = 0; idempotency_key = "wager-gc-lose-001" } | ConvertTo-Json
 Invoke-RestMethod -Uri "http://localhost:8080/users/1/wager" -Method Post -Body $body -ContentType "application/json"
 Write-Host ""
 
 # Wager Sweeps Coins (Win)
 Write-Host "10. Wager Sweeps Coins (Win: stake 5, payout 9)" -ForegroundColor Yellow
-$body = @{ stake_sc = 5; payout_sc = 9; idempotency_key = "wager-sc-win-001" } | ConvertTo-Json
+$body = @{ game_id = "demo_slots"; stake_sc = 5; payout_sc = 9; idempotency_key = "wager-sc-win-001" } | ConvertTo-Json
 Invoke-RestMethod -Uri "http://localhost:8080/users/1/wager" -Method Post -Body $body -ContentType "application/json"
 Write-Host ""
 
 # Wager Sweeps Coins (Lose)
 Write-Host "11. Wager Sweeps Coins (Lose: stake 2, payout 0)" -ForegroundColor Yellow
-$body = @{ stake_sc = 2; payout_sc = 0; idempotency_key = "wager-sc-lose-001" } | ConvertTo-Json
+$body = @{ game_id = "demo_slots"; stake_sc = 2; payout_sc = 0; idempotency_key = "wager-sc-lose-001" } | ConvertTo-Json
 Invoke-RestMethod -Uri "http://localhost:8080/users/1/wager" -Method Post -Body $body -ContentType "application/json"
 Write-Host ""
 
 # Wager - Payout Only GC (Free Spins/Bonus)
 Write-Host "11a. Wager - Payout Only GC (payout 1000, no stake)" -ForegroundColor Yellow
-$body = @{ payout_gc = 1000; idempotency_key = "wager-payout-gc-001" } | ConvertTo-Json
+$body = @{ game_id = "demo_slots"; payout_gc = 1000; idempotency_key = "wager-payout-gc-001" } | ConvertTo-Json
 Invoke-RestMethod -Uri "http://localhost:8080/users/1/wager" -Method Post -Body $body -ContentType "application/json"
 Write-Host ""
 
 # Wager - Payout Only SC
 Write-Host "11b. Wager - Payout Only SC (payout 5, no stake)" -ForegroundColor Yellow
-$body = @{ payout_sc = 5; idempotency_key = "wager-payout-sc-001" } | ConvertTo-Json
+$body = @{ game_id = "demo_slots"; payout_sc = 5; idempotency_key = "wager-payout-sc-001" } | ConvertTo-Json
 Invoke-RestMethod -Uri "http://localhost:8080/users/1/wager" -Method Post -Body $body -ContentType "application/json"
 Write-Host ""
 
 # Wager - All Four Fields (complex settlement)
 Write-Host "11c. Wager - All Currencies (GC stake+payout, SC stake+payout)" -ForegroundColor Yellow
-$body = @{ stake_gc = 50; payout_gc = 75; stake_sc = 1; payout_sc = 2; idempotency_key = "wager-all-001" } | ConvertTo-Json
+$body = @{ game_id = "demo_slots"; stake_gc = 50; payout_gc = 75; stake_sc = 1; payout_sc = 2; idempotency_key = "wager-all-001" } | ConvertTo-Json
 Invoke-RestMethod -Uri "http://localhost:8080/users/1/wager" -Method Post -Body $body -ContentType "application/json"
 Write-Host ""
 
@@ -107,7 +110,7 @@ Write-Host ""
 # Test Insufficient GC
 Write-Host "15. Test Insufficient Gold Coins (should fail)" -ForegroundColor Yellow
 try {
-    $body = @{ stake_gc = 999999999; idempotency_key = "wager-insufficient-gc" } | ConvertTo-Json
+    $body = @{ game_id = "demo_slots"; stake_gc = 999999999; idempotency_key = "wager-insufficient-gc" } | ConvertTo-Json
     Invoke-RestMethod -Uri "http://localhost:8080/users/1/wager" -Method Post -Body $body -ContentType "application/json" -ErrorAction Stop
     Write-Host "FAILED: Should have returned error" -ForegroundColor Red
 } catch {
@@ -119,7 +122,7 @@ Write-Host ""
 # Test Insufficient SC
 Write-Host "16. Test Insufficient Sweep Coins (should fail)" -ForegroundColor Yellow
 try {
-    $body = @{ stake_sc = 999999; idempotency_key = "wager-insufficient-sc" } | ConvertTo-Json
+    $body = @{ game_id = "demo_slots"; stake_sc = 999999; idempotency_key = "wager-insufficient-sc" } | ConvertTo-Json
     Invoke-RestMethod -Uri "http://localhost:8080/users/1/wager" -Method Post -Body $body -ContentType "application/json" -ErrorAction Stop
     Write-Host "FAILED: Should have returned error" -ForegroundColor Red
 } catch {
@@ -143,7 +146,7 @@ Write-Host ""
 # Test Negative GC Amount
 Write-Host "18. Test Negative Gold Coins Amount (should fail)" -ForegroundColor Yellow
 try {
-    $body = @{ stake_gc = -100; idempotency_key = "wager-negative-gc" } | ConvertTo-Json
+    $body = @{ game_id = "demo_slots"; stake_gc = -100; idempotency_key = "wager-negative-gc" } | ConvertTo-Json
     Invoke-RestMethod -Uri "http://localhost:8080/users/1/wager" -Method Post -Body $body -ContentType "application/json" -ErrorAction Stop
     Write-Host "FAILED: Should have returned error" -ForegroundColor Red
 } catch {
@@ -155,7 +158,7 @@ Write-Host ""
 # Test Negative SC Amount
 Write-Host "19. Test Negative Sweep Coins Amount (should fail)" -ForegroundColor Yellow
 try {
-    $body = @{ payout_sc = -50; idempotency_key = "wager-negative-sc" } | ConvertTo-Json
+    $body = @{ game_id = "demo_slots"; payout_sc = -50; idempotency_key = "wager-negative-sc" } | ConvertTo-Json
     Invoke-RestMethod -Uri "http://localhost:8080/users/1/wager" -Method Post -Body $body -ContentType "application/json" -ErrorAction Stop
     Write-Host "FAILED: Should have returned error" -ForegroundColor Red
 } catch {
@@ -167,7 +170,7 @@ Write-Host ""
 # Test All Fields Zero
 Write-Host "20. Test All Fields Zero (should fail)" -ForegroundColor Yellow
 try {
-    $body = @{ stake_gc = 0; payout_gc = 0; stake_sc = 0; payout_sc = 0; idempotency_key = "wager-all-zero" } | ConvertTo-Json
+    $body = @{ game_id = "demo_slots"; stake_gc = 0; payout_gc = 0; stake_sc = 0; payout_sc = 0; idempotency_key = "wager-all-zero" } | ConvertTo-Json
     Invoke-RestMethod -Uri "http://localhost:8080/users/1/wager" -Method Post -Body $body -ContentType "application/json" -ErrorAction Stop
     Write-Host "FAILED: Should have returned error" -ForegroundColor Red
 } catch {
@@ -205,7 +208,7 @@ Write-Host ""
 
 # Test Idempotency - Wager
 Write-Host "24. Test Idempotency - Wager Same Key (should return same transactions without duplicate)" -ForegroundColor Yellow
-$body = @{ stake_gc = 500; payout_gc = 900; idempotency_key = "wager-gc-win-001" } | ConvertTo-Json
+$body = @{ game_id = "demo_slots"; stake_gc = 500; payout_gc = 900; idempotency_key = "wager-gc-win-001" } | ConvertTo-Json
 Invoke-RestMethod -Uri "http://localhost:8080/users/1/wager" -Method Post -Body $body -ContentType "application/json"
 Write-Host ""
 